@@ -19,6 +19,7 @@ import (
 	"github.com/google/kf/pkg/reconciler/route"
 	"github.com/google/kf/pkg/reconciler/source"
 	"github.com/google/kf/pkg/reconciler/space"
+	"github.com/google/kf/pkg/reconciler/task"
 	"knative.dev/pkg/injection/sharedmain"
 )
 
@@ -28,6 +29,8 @@ func main() {
 		space.NewController,
 		source.NewController,
 		route.NewController,
+		route.NewClaimController,
 		app.NewController,
+		task.NewController,
 	)
 }
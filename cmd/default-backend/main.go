@@ -0,0 +1,68 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// default-backend serves the page shown for requests to routes that no App
+// receives. The VirtualServices of the routes set headers with the route and
+// space the request was for.
+package main
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/google/kf/pkg/reconciler/route/resources"
+)
+
+var page = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>No app mapped</title>
+</head>
+<body>
+  <h1>404 Not Found: No app mapped</h1>
+  {{- if .Route}}
+  <p>The route <code>{{.Route}}</code> in space <code>{{.Space}}</code> doesn't have an App mapped to it.</p>
+  <p>Map an App to the route with <code>kf map-route</code>.</p>
+  {{- else}}
+  <p>The requested route doesn't have an App mapped to it.</p>
+  {{- end}}
+</body>
+</html>
+`))
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusNotFound)
+
+		if err := page.Execute(w, struct{ Route, Space string }{
+			Route: r.Header.Get(resources.DefaultBackendRouteHeader),
+			Space: r.Header.Get(resources.DefaultBackendSpaceHeader),
+		}); err != nil {
+			log.Printf("failed to write page: %s", err)
+		}
+	})
+
+	log.Printf("listening on :%s", port)
+	log.Fatal(http.ListenAndServe(":"+port, nil))
+}
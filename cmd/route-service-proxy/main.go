@@ -0,0 +1,60 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// route-service-proxy sends the traffic of routes with a route service bound
+// through the route service, see the routeservice package.
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	"github.com/google/kf/pkg/routeservice"
+)
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	keyFile := os.Getenv("KEY_FILE")
+	if keyFile == "" {
+		keyFile = "/etc/route-service-key/" + resources.RouteServiceKeySecretKey
+	}
+
+	key, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		log.Fatalf("failed to read route service key: %s", err)
+	}
+
+	crypto, err := routeservice.NewCrypto(key)
+	if err != nil {
+		log.Fatalf("failed to load route service key: %s", err)
+	}
+
+	proxy := &routeservice.Proxy{
+		Crypto: crypto,
+		Gateways: map[string]bool{
+			resources.GatewayHost:             true,
+			resources.ClusterLocalGatewayHost: true,
+		},
+	}
+
+	log.Printf("listening on :%s", port)
+	log.Fatal(http.ListenAndServe(":"+port, proxy))
+}
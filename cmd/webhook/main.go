@@ -18,16 +18,22 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd"
 
 	"go.uber.org/zap"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kfv1alpha1 "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
+	"github.com/google/kf/pkg/webhook/instanceindex"
 	apiconfig "github.com/knative/serving/pkg/apis/config"
 	"github.com/knative/serving/pkg/apis/serving/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	cv1alpha3 "knative.dev/pkg/client/clientset/versioned/typed/istio/v1alpha3"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
@@ -85,6 +91,11 @@ func main() {
 		logger.Fatalw("Failed to get the istio client set", zap.Error(err))
 	}
 
+	kfClient, err := kfv1alpha1.NewForConfig(clusterConfig)
+	if err != nil {
+		logger.Fatalw("Failed to get the kf client set", zap.Error(err))
+	}
+
 	// Watch the logging config map and dynamically update logging levels.
 	configMapWatcher := configmap.NewInformedWatcher(kubeClient, system.Namespace())
 	configMapWatcher.Watch(logging.ConfigMapName(), logging.UpdateLevelFromConfigMap(logger, atomicLevel, component))
@@ -96,6 +107,37 @@ func main() {
 		logger.Fatalw("Failed to start the ConfigMap watcher", zap.Error(err))
 	}
 
+	// Pods get their instance index from a separate webhook because the
+	// admission controller only handles Kf's types. It only needs to see
+	// Pods that Kf manages.
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(
+		kubeClient,
+		10*time.Hour,
+		kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = v1alpha1.ManagedByLabel + "=kf"
+		}),
+	)
+	podInformer := kubeInformerFactory.Core().V1().Pods()
+	kubeInformerFactory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, podInformer.Informer().HasSynced) {
+		logger.Fatal("Failed to sync the Pod informer")
+	}
+
+	go func() {
+		handler := &instanceindex.Handler{
+			Assigner: instanceindex.NewAssigner(podInformer.Lister()),
+			Logger:   logger.Named("instance-index"),
+		}
+		opts := instanceindex.Options{
+			ServiceName: "pod-webhook",
+			Namespace:   system.Namespace(),
+			Port:        8444,
+		}
+		if err := instanceindex.Run(kubeClient, handler, opts, stopCh); err != nil {
+			logger.Fatalw("Failed to start the instance index webhook", zap.Error(err))
+		}
+	}()
+
 	options := webhook.ControllerOptions{
 		ServiceName:    "webhook",
 		DeploymentName: "webhook",
@@ -108,9 +150,12 @@ func main() {
 		Client:  kubeClient,
 		Options: options,
 		Handlers: map[schema.GroupVersionKind]webhook.GenericCRD{
-			v1alpha1.SchemeGroupVersion.WithKind("Space"): &v1alpha1.Space{},
-			v1alpha1.SchemeGroupVersion.WithKind("App"):   &v1alpha1.App{},
-			v1alpha1.SchemeGroupVersion.WithKind("Route"): &v1alpha1.Route{},
+			v1alpha1.SchemeGroupVersion.WithKind("Space"):      &v1alpha1.Space{},
+			v1alpha1.SchemeGroupVersion.WithKind("App"):        &v1alpha1.App{},
+			v1alpha1.SchemeGroupVersion.WithKind("Route"):      &v1alpha1.Route{},
+			v1alpha1.SchemeGroupVersion.WithKind("RouteClaim"): &v1alpha1.RouteClaim{},
+			v1alpha1.SchemeGroupVersion.WithKind("Task"):       &v1alpha1.Task{},
+			v1alpha1.SchemeGroupVersion.WithKind("Domain"):     &v1alpha1.Domain{},
 		},
 		Logger:                logger,
 		DisallowUnknownFields: true,
@@ -121,6 +166,12 @@ func main() {
 			// deployed.
 			ctx = v1alpha1.SetupIstioClient(ctx, istioClient)
 
+			// Routes are checked against the cluster's Domains.
+			ctx = v1alpha1.SetupDomainClient(ctx, kfClient.Domains())
+
+			// RouteClaims are checked against the claims of other spaces.
+			ctx = v1alpha1.SetupRouteClaimLister(ctx, kfClient.RouteClaims(metav1.NamespaceAll))
+
 			return v1beta1.WithUpgradeViaDefaulting(store.ToContext(ctx))
 		},
 	}
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"path"
+	"sort"
 
 	"github.com/google/kf/pkg/kf/algorithms"
 	servicecatalogv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
@@ -93,20 +94,45 @@ func (h HTTPRoutes) Len() int {
 	return len(h)
 }
 
-// Less implements Interface.
+// Less implements Interface. Routes are ordered by their URI matchers, then
+// by how many headers they match and finally by the headers themselves. When
+// sorted in reverse, routes that match more headers on the same URI come
+// first so the most specific match wins.
 func (h HTTPRoutes) Less(i int, j int) bool {
-	f := func(h v1alpha3.HTTPRoute) string {
-		var m string
+	f := func(h v1alpha3.HTTPRoute) (uri string, headerCount int, headerMatch string) {
 		for _, s := range h.Match {
-			if s.URI == nil {
-				continue
+			if s.URI != nil {
+				uri += s.URI.Exact + s.URI.Prefix + s.URI.Suffix + s.URI.Regex
+			}
+
+			// Routes for the same URI that match different headers are
+			// distinct.
+			var headers []string
+			for name := range s.Headers {
+				headers = append(headers, name)
 			}
-			m += s.URI.Exact + s.URI.Prefix + s.URI.Suffix + s.URI.Regex
+			sort.Strings(headers)
+
+			for _, name := range headers {
+				hm := s.Headers[name]
+				headerMatch += name + hm.Exact + hm.Prefix + hm.Suffix + hm.Regex
+			}
+			headerCount += len(headers)
 		}
-		return m
+		return uri, headerCount, headerMatch
 	}
 
-	return f(h[i]) < f(h[j])
+	uriI, countI, headersI := f(h[i])
+	uriJ, countJ, headersJ := f(h[j])
+
+	switch {
+	case uriI != uriJ:
+		return uriI < uriJ
+	case countI != countJ:
+		return countI < countJ
+	default:
+		return headersI < headersJ
+	}
 }
 
 // Swap implements Interface.
@@ -145,10 +171,12 @@ func (d SpaceDomains) Len() int {
 
 // Less implements Interface.
 func (d SpaceDomains) Less(i int, j int) bool {
-	// We don't want to lose default information.
+	// We don't want to lose default or internal information.
 	if d[i].Domain == d[j].Domain {
 		d[i].Default = d[i].Default || d[j].Default
 		d[j].Default = d[i].Default || d[j].Default
+		d[i].Internal = d[i].Internal || d[j].Internal
+		d[j].Internal = d[i].Internal || d[j].Internal
 	}
 
 	return d[i].Domain < d[j].Domain
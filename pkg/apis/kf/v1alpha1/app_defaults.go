@@ -38,6 +38,14 @@ const (
 	// DefaultHealthCheckProbeEndpoint is the default endpoint to use for HTTP
 	// Get health checks.
 	DefaultHealthCheckProbeEndpoint = "/"
+
+	// DefaultStrategyTimeoutSeconds is the default time a new revision has to
+	// become healthy when it's rolled out with a strategy.
+	DefaultStrategyTimeoutSeconds = 300
+
+	// DefaultRevisionHistoryLimit is the default number of old revisions kept
+	// in an App's history.
+	DefaultRevisionHistoryLimit = 10
 )
 
 // SetDefaults implements apis.Defaultable
@@ -50,6 +58,12 @@ func (k *AppSpec) SetDefaults(ctx context.Context) {
 	k.SetSourceDefaults(ctx)
 	k.Template.SetDefaults(ctx)
 	k.SetServiceBindingDefaults(ctx)
+	k.Strategy.SetDefaults(ctx)
+
+	if k.RevisionHistoryLimit == nil {
+		limit := DefaultRevisionHistoryLimit
+		k.RevisionHistoryLimit = &limit
+	}
 }
 
 // SetSourceDefaults implements apis.Defaultable for the embedded SourceSpec.
@@ -90,6 +104,14 @@ func (k *AppSpecServiceBinding) SetDefaults(ctx context.Context) {
 	}
 }
 
+// SetDefaults sets the defaults for an AppSpecStrategy.
+func (k *AppSpecStrategy) SetDefaults(ctx context.Context) {
+	if k.Type != "" && k.TimeoutSeconds == nil {
+		timeout := DefaultStrategyTimeoutSeconds
+		k.TimeoutSeconds = &timeout
+	}
+}
+
 // SetDefaults implements apis.Defaultable
 func (k *AppSpecTemplate) SetDefaults(ctx context.Context) {
 
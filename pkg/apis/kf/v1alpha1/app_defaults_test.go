@@ -35,6 +35,19 @@ func TestAppSpec_SetDefaults_BlankContainer(t *testing.T) {
 	testutil.AssertEqual(t, "spec.template.spec.containers.name", "", app.Spec.Template.Spec.Containers[0].Name)
 }
 
+func TestAppSpec_SetDefaults_RevisionHistoryLimit(t *testing.T) {
+	t.Parallel()
+
+	app := &App{}
+	app.SetDefaults(context.Background())
+	testutil.AssertEqual(t, "default limit", DefaultRevisionHistoryLimit, *app.Spec.RevisionHistoryLimit)
+
+	custom := 3
+	app = &App{Spec: AppSpec{RevisionHistoryLimit: &custom}}
+	app.SetDefaults(context.Background())
+	testutil.AssertEqual(t, "custom limit", 3, *app.Spec.RevisionHistoryLimit)
+}
+
 func TestAppSpec_SetDefaults_ResourceLimits_AlreadySet(t *testing.T) {
 	t.Parallel()
 
@@ -326,3 +339,35 @@ func TestAppSpec_SetServiceBindingsDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSpecStrategy_SetDefaults(t *testing.T) {
+	customTimeout := 30
+	defaultTimeout := DefaultStrategyTimeoutSeconds
+
+	cases := map[string]struct {
+		current AppSpecStrategy
+		want    AppSpecStrategy
+	}{
+		"no strategy": {
+			current: AppSpecStrategy{},
+			want:    AppSpecStrategy{},
+		},
+		"strategy without timeout": {
+			current: AppSpecStrategy{Type: RollingStrategy},
+			want:    AppSpecStrategy{Type: RollingStrategy, TimeoutSeconds: &defaultTimeout},
+		},
+		"strategy with timeout": {
+			current: AppSpecStrategy{Type: BlueGreenStrategy, TimeoutSeconds: &customTimeout},
+			want:    AppSpecStrategy{Type: BlueGreenStrategy, TimeoutSeconds: &customTimeout},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := tc.current
+			actual.SetDefaults(context.Background())
+
+			testutil.AssertEqual(t, "defaulted", tc.want, actual)
+		})
+	}
+}
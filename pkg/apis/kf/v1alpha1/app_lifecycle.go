@@ -16,10 +16,15 @@ package v1alpha1
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
 	servicecatalogv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -48,6 +53,25 @@ const (
 	AppConditionEnvVarSecretReady apis.ConditionType = "EnvVarSecretReady"
 	// AppConditionServiceBindingsReady is set when all service bindings are ready.
 	AppConditionServiceBindingsReady apis.ConditionType = "ServiceBindingsReady"
+	// AppConditionProcessesReady is set when all additional processes are ready.
+	AppConditionProcessesReady apis.ConditionType = "ProcessesReady"
+	// AppConditionRolloutReady is set when the latest revision receives all
+	// traffic, or traffic has been rolled back from it.
+	AppConditionRolloutReady apis.ConditionType = "RolloutReady"
+	// AppConditionInstancesHealthy is set when none of the instances of the
+	// latest revision are crashing or stuck starting.
+	AppConditionInstancesHealthy apis.ConditionType = "InstancesHealthy"
+
+	// RolledBackReason is the reason for AppConditionRolloutReady when traffic
+	// was moved back to the stable revision.
+	RolledBackReason = "RolledBack"
+
+	// rolloutStepPercent is the percent of traffic moved to a new revision at
+	// each step of a rolling deployment.
+	rolloutStepPercent = 25
+
+	// rolloutStepInterval is the time between steps of a rolling deployment.
+	rolloutStepInterval = 30 * time.Second
 )
 
 func (status *AppStatus) manage() apis.ConditionManager {
@@ -99,6 +123,17 @@ func (status *AppStatus) ServiceBindingCondition() SingleConditionManager {
 	return NewSingleConditionManager(status.manage(), AppConditionServiceBindingsReady, "Service Bindings")
 }
 
+// ProcessesCondition gets a manager for the state of the additional
+// processes.
+func (status *AppStatus) ProcessesCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), AppConditionProcessesReady, "Processes")
+}
+
+// RolloutCondition gets a manager for the state of the rollout.
+func (status *AppStatus) RolloutCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), AppConditionRolloutReady, "Rollout")
+}
+
 // PropagateSourceStatus copies the source status to the app's.
 func (status *AppStatus) PropagateSourceStatus(source *Source) {
 	status.LatestCreatedSourceName = source.Name
@@ -131,6 +166,19 @@ func (status *AppStatus) PropagateEnvVarSecretStatus(secret *v1.Secret) {
 	status.manage().MarkTrue(AppConditionEnvVarSecretReady)
 }
 
+// PropagateServiceCredentialsHash records the hash of the service credentials
+// injected into the App. A change of a known hash is recorded so the App's
+// instances are rolled to pick up the new credentials.
+func (status *AppStatus) PropagateServiceCredentialsHash(hash string) {
+	credentials := &status.ServiceCredentials
+	if credentials.Hash != "" && credentials.Hash != hash {
+		now := metav1.Now()
+		credentials.LastChanged = &now
+	}
+
+	credentials.Hash = hash
+}
+
 // PropagateServiceBindingsStatus updates the service binding readiness status.
 func (status *AppStatus) PropagateServiceBindingsStatus(bindings []servicecatalogv1beta1.ServiceBinding) {
 
@@ -177,6 +225,463 @@ func (status *AppStatus) PropagateServiceBindingsStatus(bindings []servicecatalo
 	}
 }
 
+// PropagateDeploymentsStatus updates the processes readiness status from the
+// Deployments running them.
+func (status *AppStatus) PropagateDeploymentsStatus(deployments []*appsv1.Deployment) {
+	for _, deployment := range deployments {
+		processType := deployment.Labels[ProcessTypeLabel]
+
+		for _, cond := range deployment.Status.Conditions {
+			if cond.Type == appsv1.DeploymentProgressing && cond.Status == v1.ConditionFalse {
+				status.manage().MarkFalse(AppConditionProcessesReady, cond.Reason, "process %s failed: %s", processType, cond.Message)
+				return
+			}
+		}
+
+		if deployment.Generation > deployment.Status.ObservedGeneration {
+			status.manage().MarkUnknown(AppConditionProcessesReady, "Updating", "process %s is updating", processType)
+			return
+		}
+
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+
+		if available := deployment.Status.AvailableReplicas; available < desired {
+			status.manage().MarkUnknown(AppConditionProcessesReady, "Scaling", "process %s has %d of %d instances available", processType, available, desired)
+			return
+		}
+	}
+
+	status.manage().MarkTrue(AppConditionProcessesReady)
+}
+
+// PropagateInstanceStatus summarizes the Pods running the App's web process.
+func (status *AppStatus) PropagateInstanceStatus(instances AppSpecInstances, pods []*v1.Pod) {
+	var (
+		summary        AppInstancesStatus
+		running        int
+		lastTerminated metav1.Time
+	)
+
+	for _, pod := range pods {
+		instance, terminated := makeInstanceStatus(pod)
+		summary.Restarts += instance.Restarts
+
+		if instance.LastTerminationReason != "" && !terminated.Before(&lastTerminated) {
+			summary.LastTerminationReason = instance.LastTerminationReason
+			lastTerminated = terminated
+		}
+
+		if pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		running++
+		if instance.Ready {
+			summary.Ready++
+		}
+	}
+
+	summary.Desired = instances.DesiredInstances(running)
+	status.Instances = summary
+}
+
+// DescribeInstances describes the instances running in the Pods, sorted by
+// name.
+func DescribeInstances(pods []v1.Pod) []AppInstanceStatus {
+	var instances []AppInstanceStatus
+	for i := range pods {
+		instance, _ := makeInstanceStatus(&pods[i])
+		instances = append(instances, instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Name < instances[j].Name
+	})
+
+	return instances
+}
+
+// makeInstanceStatus describes the instance running in the Pod. It also
+// returns when the instance's last termination happened.
+func makeInstanceStatus(pod *v1.Pod) (AppInstanceStatus, metav1.Time) {
+	instance := AppInstanceStatus{
+		Name:              pod.Name,
+		NodeName:          pod.Spec.NodeName,
+		CreationTimestamp: pod.CreationTimestamp,
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			instance.Ready = cond.Status == v1.ConditionTrue
+		}
+	}
+
+	var (
+		waitingReason  string
+		lastTerminated metav1.Time
+	)
+	for _, container := range pod.Status.ContainerStatuses {
+		instance.Restarts += container.RestartCount
+
+		for _, terminated := range []*v1.ContainerStateTerminated{
+			container.LastTerminationState.Terminated,
+			container.State.Terminated,
+		} {
+			if terminated != nil && terminated.Reason != "" && !terminated.FinishedAt.Before(&lastTerminated) {
+				instance.LastTerminationReason = terminated.Reason
+				lastTerminated = terminated.FinishedAt
+			}
+		}
+
+		// Containers wait while they're being created too, only the
+		// reasons that need attention are reported.
+		if waiting := container.State.Waiting; waiting != nil && waitingReason == "" {
+			switch waiting.Reason {
+			case "", "ContainerCreating", "PodInitializing":
+			default:
+				waitingReason = waiting.Reason
+			}
+		}
+	}
+
+	switch {
+	case pod.GetDeletionTimestamp() != nil:
+		instance.State = "Terminating"
+	case waitingReason != "":
+		instance.State = waitingReason
+	case pod.Status.Phase == v1.PodRunning && instance.Ready:
+		instance.State = "Running"
+	case pod.Status.Phase == v1.PodRunning:
+		instance.State = "Starting"
+	case pod.Status.Phase == "":
+		instance.State = string(v1.PodPending)
+	default:
+		instance.State = string(pod.Status.Phase)
+	}
+
+	return instance, lastTerminated
+}
+
+// InstanceFailure describes why an instance of an App is failing.
+// +k8s:deepcopy-gen=false
+type InstanceFailure struct {
+	// PodName is the name of the Pod running the instance.
+	PodName string
+
+	// ContainerName is the name of the failing container, it's empty if the
+	// Pod couldn't start.
+	ContainerName string
+
+	// Reason is a CamelCase reason for the failure, e.g. CrashLoopBackOff or
+	// FailedScheduling.
+	Reason string
+
+	// Message is a human readable description of the failure.
+	Message string
+
+	// Crashed is true if the container exited, so its logs may explain the
+	// failure.
+	Crashed bool
+}
+
+// containerFailureReasons are the reasons a container waits for that don't
+// go away on their own.
+var containerFailureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// DiagnoseInstances returns the failure of the first failing Pod, by name, or
+// nil if none of the Pods are failing. Events are the Warning Events about
+// the Pods, they explain why Pods are stuck pending.
+func DiagnoseInstances(pods []*v1.Pod, events []v1.Event) *InstanceFailure {
+	sorted := append([]*v1.Pod(nil), pods...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	for _, pod := range sorted {
+		if pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		if failure := diagnoseContainers(pod); failure != nil {
+			return failure
+		}
+
+		if pod.Status.Phase != "" && pod.Status.Phase != v1.PodPending {
+			continue
+		}
+
+		// Pending Pods have no container statuses if they couldn't be
+		// scheduled or their volumes couldn't be mounted, only Events say why.
+		var latest *v1.Event
+		for i := range events {
+			event := &events[i]
+			if event.InvolvedObject.UID != pod.UID || event.Type != v1.EventTypeWarning {
+				continue
+			}
+
+			if latest == nil || !event.LastTimestamp.Before(&latest.LastTimestamp) {
+				latest = event
+			}
+		}
+
+		if latest != nil {
+			return &InstanceFailure{
+				PodName: pod.Name,
+				Reason:  latest.Reason,
+				Message: latest.Message,
+			}
+		}
+	}
+
+	return nil
+}
+
+// diagnoseContainers returns the failure of the first failing container in
+// the Pod or nil if none of them are failing.
+func diagnoseContainers(pod *v1.Pod) *InstanceFailure {
+	for _, container := range pod.Status.ContainerStatuses {
+		last := container.LastTerminationState.Terminated
+
+		if waiting := container.State.Waiting; waiting != nil && containerFailureReasons[waiting.Reason] {
+			detail := waiting.Message
+			if detail == "" {
+				detail = waiting.Reason
+			}
+
+			failure := &InstanceFailure{
+				PodName:       pod.Name,
+				ContainerName: container.Name,
+				Reason:        waiting.Reason,
+				Message:       fmt.Sprintf("container %s is waiting: %s", container.Name, detail),
+			}
+
+			if last != nil {
+				failure.Message = fmt.Sprintf("container %s is crashing, it last exited with %s (exit code %d)", container.Name, last.Reason, last.ExitCode)
+				failure.Crashed = true
+			}
+
+			return failure
+		}
+
+		if terminated := container.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return &InstanceFailure{
+				PodName:       pod.Name,
+				ContainerName: container.Name,
+				Reason:        terminated.Reason,
+				Message:       fmt.Sprintf("container %s exited with %s (exit code %d)", container.Name, terminated.Reason, terminated.ExitCode),
+				Crashed:       true,
+			}
+		}
+	}
+
+	return nil
+}
+
+// PropagateInstancesHealth sets the InstancesHealthy condition from the
+// failure of the latest revision's instances, nil if they're healthy. Only
+// the reason and exit code are recorded, the output of crashed containers
+// can contain secrets so kf push and kf logs read it from the Pod instead.
+func (status *AppStatus) PropagateInstancesHealth(failure *InstanceFailure) {
+	if failure == nil {
+		status.manage().MarkTrue(AppConditionInstancesHealthy)
+		return
+	}
+
+	message := fmt.Sprintf("instance %s failed: %s", failure.PodName, failure.Message)
+	if failure.Crashed {
+		message += ", run kf logs to see its output"
+	}
+
+	status.manage().MarkFalse(AppConditionInstancesHealthy, failure.Reason, "%s", message)
+}
+
+// MarkInstancesHealthUnknown notes that the revision of the App's current
+// template hasn't been created yet, so its instances can't be judged.
+func (status *AppStatus) MarkInstancesHealthUnknown() {
+	status.manage().MarkUnknown(AppConditionInstancesHealthy, "WaitingForRevision",
+		"Waiting for the revision of the latest change to be created.")
+}
+
+// PropagateRolloutStatus moves traffic between the stable revision and the
+// latest revision of the Knative service according to the strategy. The
+// service is nil for stopped apps and latest is the service's most recently
+// created revision if it exists. The returned duration is how long to wait
+// before the rollout should be checked again, zero means it doesn't need to be.
+func (status *AppStatus) PropagateRolloutStatus(
+	strategy AppSpecStrategy,
+	service *serving.Service,
+	latest *serving.Revision,
+	now time.Time,
+) time.Duration {
+	previous := status.GetCondition(AppConditionRolloutReady)
+
+	switch {
+	case service == nil:
+		// Stopped apps have no revisions to keep, they start over.
+		status.StableRevisionName = ""
+		status.RolloutPercent = 0
+		status.manage().MarkTrue(AppConditionRolloutReady)
+		return 0
+
+	case strategy.Type == "":
+		// Knative moves traffic to the latest ready revision on its own, track
+		// it so a strategy can be applied to the next push.
+		status.StableRevisionName = service.Status.LatestReadyRevisionName
+		status.RolloutPercent = 0
+		status.manage().MarkTrue(AppConditionRolloutReady)
+		return 0
+
+	case service.Generation != service.Status.ObservedGeneration || latest == nil:
+		status.manage().MarkUnknown(AppConditionRolloutReady, "WaitingForRevision", "waiting for a new revision to be created")
+		return 0
+	}
+
+	latestReady := latest.Status.GetCondition(apis.ConditionReady)
+	isReady := latestReady != nil && latestReady.IsTrue()
+	isFailed := latestReady != nil && latestReady.IsFalse()
+
+	switch {
+	case status.StableRevisionName == latest.Name:
+		status.RolloutPercent = 0
+		status.manage().MarkTrue(AppConditionRolloutReady)
+		return 0
+
+	case status.StableRevisionName == "":
+		// There's nothing to roll back to for the first revision.
+		if isReady {
+			status.StableRevisionName = latest.Name
+			status.RolloutPercent = 0
+			status.manage().MarkTrue(AppConditionRolloutReady)
+		} else {
+			status.manage().MarkUnknown(AppConditionRolloutReady, "WaitingForRevision", "waiting for revision %s to become healthy", latest.Name)
+		}
+		return 0
+	}
+
+	// Traffic sent to an earlier revision doesn't carry over to a newer one,
+	// nor does its roll back.
+	if status.RolloutRevisionName != latest.Name {
+		status.RolloutRevisionName = latest.Name
+		status.RolloutPercent = 0
+		previous = nil
+	}
+
+	timeout := time.Duration(DefaultStrategyTimeoutSeconds) * time.Second
+	if strategy.TimeoutSeconds != nil {
+		timeout = time.Duration(*strategy.TimeoutSeconds) * time.Second
+	}
+	deadline := latest.CreationTimestamp.Add(timeout)
+
+	// A revision that was already rolled back stays that way even if it
+	// becomes healthy later.
+	rolledBack := previous != nil && previous.IsFalse() && previous.Reason == RolledBackReason
+
+	switch {
+	case isFailed:
+		status.RolloutPercent = 0
+		status.manage().MarkFalse(
+			AppConditionRolloutReady,
+			RolledBackReason,
+			"revision %s failed: %s; traffic was rolled back to %s",
+			latest.Name,
+			latestReady.Message,
+			status.StableRevisionName,
+		)
+		return 0
+
+	case !now.Before(deadline) && (!isReady || rolledBack):
+		status.RolloutPercent = 0
+		status.manage().MarkFalse(
+			AppConditionRolloutReady,
+			RolledBackReason,
+			"revision %s didn't become healthy within %v; traffic was rolled back to %s",
+			latest.Name,
+			timeout,
+			status.StableRevisionName,
+		)
+		return 0
+
+	case !isReady:
+		status.manage().MarkUnknown(AppConditionRolloutReady, "WaitingForRevision", "waiting for revision %s to become healthy", latest.Name)
+		return deadline.Sub(now)
+	}
+
+	step := 100
+	if strategy.Type == RollingStrategy {
+		step = rolloutStepPercent
+
+		// The condition changes at every step so its transition time records
+		// when traffic last moved.
+		if status.RolloutPercent > 0 && previous != nil && previous.IsUnknown() {
+			if elapsed := now.Sub(previous.LastTransitionTime.Inner.Time); elapsed < rolloutStepInterval {
+				return rolloutStepInterval - elapsed
+			}
+		}
+	}
+
+	status.RolloutPercent += step
+	if status.RolloutPercent >= 100 {
+		status.StableRevisionName = latest.Name
+		status.RolloutPercent = 0
+		status.manage().MarkTrue(AppConditionRolloutReady)
+		return 0
+	}
+
+	status.manage().MarkUnknown(
+		AppConditionRolloutReady,
+		"RollingOut",
+		"%d%% of traffic is sent to revision %s",
+		status.RolloutPercent,
+		latest.Name,
+	)
+	return rolloutStepInterval
+}
+
+// RecordRevision adds a deployed revision to the App's history unless it's
+// already the latest entry. The revision is numbered after the one before it
+// and only the latest revision plus limit old ones are kept.
+func (status *AppStatus) RecordRevision(revision AppRevision, limit int) {
+	revision.Revision = 1
+	if latest := status.LatestRevision(); latest != nil {
+		if latest.Name == revision.Name {
+			return
+		}
+
+		revision.Revision = latest.Revision + 1
+	}
+
+	status.History = append(status.History, revision)
+
+	if limit < 0 {
+		limit = 0
+	}
+
+	if excess := len(status.History) - (limit + 1); excess > 0 {
+		status.History = status.History[excess:]
+	}
+}
+
+// LatestRevision returns the most recently deployed revision in the App's
+// history or nil if nothing has been deployed.
+func (status *AppStatus) LatestRevision() *AppRevision {
+	if len(status.History) == 0 {
+		return nil
+	}
+
+	return &status.History[len(status.History)-1]
+}
+
 // MarkSpaceHealthy notes that the space was able to be retrieved and
 // defaults can be applied from it.
 func (status *AppStatus) MarkSpaceHealthy() {
@@ -191,3 +696,42 @@ func (status *AppStatus) MarkSpaceUnhealthy(reason, message string) {
 func (status *AppStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
+
+// InstanceIndexOf returns the instance index of the Pod and whether it has
+// been assigned one.
+func InstanceIndexOf(pod *v1.Pod) (int, bool) {
+	index, err := strconv.Atoi(pod.Annotations[InstanceIndexAnnotation])
+	if err != nil || index < 0 {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// FreeInstanceIndex returns the lowest instance index that isn't held by one
+// of the running Pods of a process or reserved, like Cloud Foundry's
+// instance index. Terminating Pods don't hold their index so their
+// replacements take it over.
+func FreeInstanceIndex(pods []*v1.Pod, reserved map[int]bool) int {
+	taken := make(map[int]bool)
+	for index := range reserved {
+		taken[index] = true
+	}
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		if index, ok := InstanceIndexOf(pod); ok {
+			taken[index] = true
+		}
+	}
+
+	index := 0
+	for taken[index] {
+		index++
+	}
+
+	return index
+}
@@ -16,9 +16,11 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/kf/pkg/kf/testutil"
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -382,3 +384,856 @@ func TestAppStatus_lifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestAppStatus_PropagateDeploymentsStatus(t *testing.T) {
+	two := int32(2)
+
+	deployment := func(available int32, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{ProcessTypeLabel: "worker"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &two,
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: available,
+				Conditions:        conditions,
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		deployments  []*appsv1.Deployment
+		wantStatus   corev1.ConditionStatus
+		wantReason   string
+		wantContains string
+	}{
+		"no processes": {
+			wantStatus: corev1.ConditionTrue,
+		},
+		"all available": {
+			deployments: []*appsv1.Deployment{deployment(2)},
+			wantStatus:  corev1.ConditionTrue,
+		},
+		"scaling": {
+			deployments:  []*appsv1.Deployment{deployment(1)},
+			wantStatus:   corev1.ConditionUnknown,
+			wantReason:   "Scaling",
+			wantContains: "process worker has 1 of 2 instances available",
+		},
+		"progress deadline exceeded": {
+			deployments: []*appsv1.Deployment{deployment(0, appsv1.DeploymentCondition{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: "too slow",
+			})},
+			wantStatus:   corev1.ConditionFalse,
+			wantReason:   "ProgressDeadlineExceeded",
+			wantContains: "process worker failed: too slow",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initTestAppStatus(t)
+
+			status.PropagateDeploymentsStatus(tc.deployments)
+
+			cond := status.GetCondition(AppConditionProcessesReady)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.Status)
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+			testutil.AssertContainsAll(t, cond.Message, []string{tc.wantContains})
+		})
+	}
+}
+
+func TestAppStatus_PropagateRolloutStatus(t *testing.T) {
+	now := time.Now()
+	timeout := 60
+
+	service := func(latestReady string) *serving.Service {
+		svc := &serving.Service{}
+		svc.Generation = 2
+		svc.Status.ObservedGeneration = 2
+		svc.Status.LatestReadyRevisionName = latestReady
+		return svc
+	}
+
+	revision := func(name string, age time.Duration, ready corev1.ConditionStatus) *serving.Revision {
+		rev := &serving.Revision{}
+		rev.Name = name
+		rev.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		rev.Status.Conditions = duckv1beta1.Conditions{
+			{Type: apis.ConditionReady, Status: ready, Message: "some-message"},
+		}
+		return rev
+	}
+
+	rolling := AppSpecStrategy{Type: RollingStrategy, TimeoutSeconds: &timeout}
+	blueGreen := AppSpecStrategy{Type: BlueGreenStrategy, TimeoutSeconds: &timeout}
+
+	cases := map[string]struct {
+		init     func(status *AppStatus)
+		strategy AppSpecStrategy
+		service  *serving.Service
+		latest   *serving.Revision
+
+		wantStable  string
+		wantPercent int
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantRequeue time.Duration
+	}{
+		"no strategy tracks the latest ready revision": {
+			service:    service("rev-1"),
+			latest:     revision("rev-2", time.Second, corev1.ConditionUnknown),
+			wantStable: "rev-1",
+			wantStatus: corev1.ConditionTrue,
+		},
+		"stopped app starts over": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+			},
+			strategy:   rolling,
+			wantStatus: corev1.ConditionTrue,
+		},
+		"service not yet updated": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+			},
+			strategy: rolling,
+			service: func() *serving.Service {
+				svc := service("rev-1")
+				svc.Generation = 3
+				return svc
+			}(),
+			latest:     revision("rev-1", time.Hour, corev1.ConditionTrue),
+			wantStable: "rev-1",
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: "WaitingForRevision",
+		},
+		"first revision is adopted when ready": {
+			strategy:   blueGreen,
+			service:    service("rev-1"),
+			latest:     revision("rev-1", time.Second, corev1.ConditionTrue),
+			wantStable: "rev-1",
+			wantStatus: corev1.ConditionTrue,
+		},
+		"new revision pending": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+			},
+			strategy:    blueGreen,
+			service:     service("rev-1"),
+			latest:      revision("rev-2", 20*time.Second, corev1.ConditionUnknown),
+			wantStable:  "rev-1",
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  "WaitingForRevision",
+			wantRequeue: 40 * time.Second,
+		},
+		"blue-green switches all traffic": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+			},
+			strategy:   blueGreen,
+			service:    service("rev-2"),
+			latest:     revision("rev-2", 20*time.Second, corev1.ConditionTrue),
+			wantStable: "rev-2",
+			wantStatus: corev1.ConditionTrue,
+		},
+		"rolling takes the first step": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+			},
+			strategy:    rolling,
+			service:     service("rev-2"),
+			latest:      revision("rev-2", 20*time.Second, corev1.ConditionTrue),
+			wantStable:  "rev-1",
+			wantPercent: 25,
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  "RollingOut",
+			wantRequeue: rolloutStepInterval,
+		},
+		"rolling waits between steps": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+				status.RolloutPercent = 50
+				status.RolloutRevisionName = "rev-2"
+				status.manage().MarkUnknown(AppConditionRolloutReady, "RollingOut", "50% of traffic is sent to revision rev-2")
+
+				// Traffic last moved 10 seconds ago.
+				for i := range status.Conditions {
+					if status.Conditions[i].Type == AppConditionRolloutReady {
+						status.Conditions[i].LastTransitionTime = apis.VolatileTime{
+							Inner: metav1.NewTime(now.Add(-10 * time.Second)),
+						}
+					}
+				}
+			},
+			strategy:    rolling,
+			service:     service("rev-2"),
+			latest:      revision("rev-2", 20*time.Second, corev1.ConditionTrue),
+			wantStable:  "rev-1",
+			wantPercent: 50,
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  "RollingOut",
+			wantRequeue: 20 * time.Second,
+		},
+		"rolling finishes": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+				status.RolloutPercent = 75
+				status.RolloutRevisionName = "rev-2"
+			},
+			strategy:   rolling,
+			service:    service("rev-2"),
+			latest:     revision("rev-2", 20*time.Second, corev1.ConditionTrue),
+			wantStable: "rev-2",
+			wantStatus: corev1.ConditionTrue,
+		},
+		"failed revision is rolled back": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+			},
+			strategy:   rolling,
+			service:    service("rev-1"),
+			latest:     revision("rev-2", 20*time.Second, corev1.ConditionFalse),
+			wantStable: "rev-1",
+			wantStatus: corev1.ConditionFalse,
+			wantReason: RolledBackReason,
+		},
+		"timed out revision is rolled back": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+			},
+			strategy:   blueGreen,
+			service:    service("rev-1"),
+			latest:     revision("rev-2", 2*time.Minute, corev1.ConditionUnknown),
+			wantStable: "rev-1",
+			wantStatus: corev1.ConditionFalse,
+			wantReason: RolledBackReason,
+		},
+		"rolled back revision stays rolled back": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+				status.RolloutRevisionName = "rev-2"
+				status.manage().MarkFalse(AppConditionRolloutReady, RolledBackReason, "rolled back")
+			},
+			strategy:   blueGreen,
+			service:    service("rev-2"),
+			latest:     revision("rev-2", 2*time.Minute, corev1.ConditionTrue),
+			wantStable: "rev-1",
+			wantStatus: corev1.ConditionFalse,
+			wantReason: RolledBackReason,
+		},
+		"newer revision starts the rollout over": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+				status.RolloutPercent = 50
+				status.RolloutRevisionName = "rev-2"
+				status.manage().MarkUnknown(AppConditionRolloutReady, "RollingOut", "50% of traffic is sent to revision rev-2")
+			},
+			strategy:    rolling,
+			service:     service("rev-3"),
+			latest:      revision("rev-3", 20*time.Second, corev1.ConditionTrue),
+			wantStable:  "rev-1",
+			wantPercent: 25,
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  "RollingOut",
+			wantRequeue: rolloutStepInterval,
+		},
+		"newer revision isn't rolled back with the previous one": {
+			init: func(status *AppStatus) {
+				status.StableRevisionName = "rev-1"
+				status.RolloutRevisionName = "rev-2"
+				status.manage().MarkFalse(AppConditionRolloutReady, RolledBackReason, "rolled back")
+			},
+			strategy:   blueGreen,
+			service:    service("rev-3"),
+			latest:     revision("rev-3", 2*time.Minute, corev1.ConditionTrue),
+			wantStable: "rev-3",
+			wantStatus: corev1.ConditionTrue,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initTestAppStatus(t)
+			if tc.init != nil {
+				tc.init(status)
+			}
+
+			requeue := status.PropagateRolloutStatus(tc.strategy, tc.service, tc.latest, now)
+
+			cond := status.GetCondition(AppConditionRolloutReady)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.Status)
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+			testutil.AssertEqual(t, "stable revision", tc.wantStable, status.StableRevisionName)
+			testutil.AssertEqual(t, "rollout percent", tc.wantPercent, status.RolloutPercent)
+			testutil.AssertEqual(t, "requeue", tc.wantRequeue, requeue)
+		})
+	}
+}
+
+func TestAppStatus_RecordRevision(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		history  []AppRevision
+		revision string
+		limit    int
+
+		wantNames     []string
+		wantRevisions []int
+	}{
+		"first revision": {
+			revision:      "rev-1",
+			limit:         2,
+			wantNames:     []string{"rev-1"},
+			wantRevisions: []int{1},
+		},
+		"latest revision isn't duplicated": {
+			history:       []AppRevision{{Name: "rev-1", Revision: 1}},
+			revision:      "rev-1",
+			limit:         2,
+			wantNames:     []string{"rev-1"},
+			wantRevisions: []int{1},
+		},
+		"revisions are numbered in order": {
+			history:       []AppRevision{{Name: "rev-1", Revision: 1}, {Name: "rev-2", Revision: 2}},
+			revision:      "rev-3",
+			limit:         2,
+			wantNames:     []string{"rev-1", "rev-2", "rev-3"},
+			wantRevisions: []int{1, 2, 3},
+		},
+		"old revisions are dropped": {
+			history:       []AppRevision{{Name: "rev-1", Revision: 1}, {Name: "rev-2", Revision: 2}},
+			revision:      "rev-3",
+			limit:         1,
+			wantNames:     []string{"rev-2", "rev-3"},
+			wantRevisions: []int{2, 3},
+		},
+		"zero limit keeps the latest": {
+			history:       []AppRevision{{Name: "rev-1", Revision: 1}, {Name: "rev-2", Revision: 2}},
+			revision:      "rev-3",
+			limit:         0,
+			wantNames:     []string{"rev-3"},
+			wantRevisions: []int{3},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &AppStatus{History: tc.history}
+
+			status.RecordRevision(AppRevision{Name: tc.revision}, tc.limit)
+
+			var names []string
+			var revisions []int
+			for _, rev := range status.History {
+				names = append(names, rev.Name)
+				revisions = append(revisions, rev.Revision)
+			}
+
+			testutil.AssertEqual(t, "names", tc.wantNames, names)
+			testutil.AssertEqual(t, "revisions", tc.wantRevisions, revisions)
+			testutil.AssertEqual(t, "latest", tc.revision, status.LatestRevision().Name)
+		})
+	}
+}
+
+func TestAppStatus_PropagateInstanceStatus(t *testing.T) {
+	t.Parallel()
+
+	created := metav1.NewTime(time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC))
+	earlier := metav1.NewTime(created.Add(time.Minute))
+	later := metav1.NewTime(created.Add(time.Hour))
+
+	readyCondition := func(ready bool) []corev1.PodCondition {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+
+		return []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+	}
+
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-b", CreationTimestamp: created},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: readyCondition(true),
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					RestartCount: 1,
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "Error", FinishedAt: earlier},
+					},
+				},
+			},
+		},
+	}
+
+	crashingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-a", CreationTimestamp: created},
+		Spec:       corev1.PodSpec{NodeName: "node-2"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: readyCondition(false),
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					RestartCount: 4,
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: later},
+					},
+				},
+				{RestartCount: 1},
+			},
+		},
+	}
+
+	pendingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-c"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		},
+	}
+
+	terminatingPod := runningPod.DeepCopy()
+	terminatingPod.Name = "app-d"
+	terminatingPod.DeletionTimestamp = &later
+
+	cases := map[string]struct {
+		instances AppSpecInstances
+		pods      []*corev1.Pod
+
+		want          AppInstancesStatus
+		wantInstances []AppInstanceStatus
+	}{
+		"no pods": {
+			instances: AppSpecInstances{Exactly: intPtr(3)},
+			want:      AppInstancesStatus{Desired: 3},
+		},
+		"mixed pods": {
+			instances: AppSpecInstances{Exactly: intPtr(3)},
+			pods:      []*corev1.Pod{terminatingPod, pendingPod, runningPod, crashingPod},
+			want: AppInstancesStatus{
+				Desired:               3,
+				Ready:                 1,
+				Restarts:              7,
+				LastTerminationReason: "OOMKilled",
+			},
+			wantInstances: []AppInstanceStatus{
+				{
+					Name:                  "app-a",
+					State:                 "CrashLoopBackOff",
+					Restarts:              5,
+					LastTerminationReason: "OOMKilled",
+					NodeName:              "node-2",
+					CreationTimestamp:     created,
+				},
+				{
+					Name:                  "app-b",
+					State:                 "Running",
+					Ready:                 true,
+					Restarts:              1,
+					LastTerminationReason: "Error",
+					NodeName:              "node-1",
+					CreationTimestamp:     created,
+				},
+				{
+					Name:  "app-c",
+					State: "Pending",
+				},
+				{
+					Name:                  "app-d",
+					State:                 "Terminating",
+					Ready:                 true,
+					Restarts:              1,
+					LastTerminationReason: "Error",
+					NodeName:              "node-1",
+					CreationTimestamp:     created,
+				},
+			},
+		},
+		"autoscaled": {
+			instances: AppSpecInstances{Max: intPtr(1)},
+			pods:      []*corev1.Pod{runningPod, terminatingPod},
+			want: AppInstancesStatus{
+				Desired:               1,
+				Ready:                 1,
+				Restarts:              2,
+				LastTerminationReason: "Error",
+			},
+			wantInstances: []AppInstanceStatus{
+				{
+					Name:                  "app-b",
+					State:                 "Running",
+					Ready:                 true,
+					Restarts:              1,
+					LastTerminationReason: "Error",
+					NodeName:              "node-1",
+					CreationTimestamp:     created,
+				},
+				{
+					Name:                  "app-d",
+					State:                 "Terminating",
+					Ready:                 true,
+					Restarts:              1,
+					LastTerminationReason: "Error",
+					NodeName:              "node-1",
+					CreationTimestamp:     created,
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &AppStatus{}
+
+			status.PropagateInstanceStatus(tc.instances, tc.pods)
+
+			testutil.AssertEqual(t, "instances", tc.want, status.Instances)
+
+			var pods []corev1.Pod
+			for _, pod := range tc.pods {
+				pods = append(pods, *pod)
+			}
+			testutil.AssertEqual(t, "described instances", tc.wantInstances, DescribeInstances(pods))
+		})
+	}
+}
+
+func TestDiagnoseInstances(t *testing.T) {
+	t.Parallel()
+
+	earlier := metav1.NewTime(time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Minute))
+
+	healthyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-a", UID: "uid-a"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "user-container", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+
+	crashingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-b", UID: "uid-b"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "user-container",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+					},
+				},
+			},
+		},
+	}
+
+	exitedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-c", UID: "uid-c"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "user-container",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+					},
+				},
+			},
+		},
+	}
+
+	imagePullPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-d", UID: "uid-d"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "user-container",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+					},
+				},
+			},
+		},
+	}
+
+	unschedulablePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-e", UID: "uid-e"},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	terminatingPod := crashingPod.DeepCopy()
+	terminatingPod.DeletionTimestamp = &later
+
+	events := []corev1.Event{
+		{
+			InvolvedObject: corev1.ObjectReference{UID: "uid-e"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "FailedMount",
+			Message:        "volume not found",
+			LastTimestamp:  earlier,
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{UID: "uid-e"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "FailedScheduling",
+			Message:        "0/3 nodes are available: 3 Insufficient memory.",
+			LastTimestamp:  later,
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{UID: "uid-e"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "TriggeredScaleUp",
+			LastTimestamp:  later,
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{UID: "uid-a"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Unhealthy",
+			LastTimestamp:  later,
+		},
+	}
+
+	cases := map[string]struct {
+		pods   []*corev1.Pod
+		events []corev1.Event
+
+		want *InstanceFailure
+	}{
+		"no pods": {
+			want: nil,
+		},
+		"healthy": {
+			pods:   []*corev1.Pod{healthyPod},
+			events: events,
+			want:   nil,
+		},
+		"crash loop": {
+			pods: []*corev1.Pod{healthyPod, crashingPod},
+			want: &InstanceFailure{
+				PodName:       "app-b",
+				ContainerName: "user-container",
+				Reason:        "CrashLoopBackOff",
+				Message:       "container user-container is crashing, it last exited with OOMKilled (exit code 137)",
+				Crashed:       true,
+			},
+		},
+		"exited": {
+			pods: []*corev1.Pod{exitedPod},
+			want: &InstanceFailure{
+				PodName:       "app-c",
+				ContainerName: "user-container",
+				Reason:        "Error",
+				Message:       "container user-container exited with Error (exit code 1)",
+				Crashed:       true,
+			},
+		},
+		"image pull": {
+			pods: []*corev1.Pod{imagePullPod},
+			want: &InstanceFailure{
+				PodName:       "app-d",
+				ContainerName: "user-container",
+				Reason:        "ImagePullBackOff",
+				Message:       "container user-container is waiting: Back-off pulling image",
+			},
+		},
+		"pending with events": {
+			pods:   []*corev1.Pod{unschedulablePod},
+			events: events,
+			want: &InstanceFailure{
+				PodName: "app-e",
+				Reason:  "FailedScheduling",
+				Message: "0/3 nodes are available: 3 Insufficient memory.",
+			},
+		},
+		"pending without events": {
+			pods: []*corev1.Pod{unschedulablePod},
+			want: nil,
+		},
+		"first by name": {
+			pods: []*corev1.Pod{exitedPod, crashingPod},
+			want: &InstanceFailure{
+				PodName:       "app-b",
+				ContainerName: "user-container",
+				Reason:        "CrashLoopBackOff",
+				Message:       "container user-container is crashing, it last exited with OOMKilled (exit code 137)",
+				Crashed:       true,
+			},
+		},
+		"terminating pods are skipped": {
+			pods: []*corev1.Pod{terminatingPod},
+			want: nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := DiagnoseInstances(tc.pods, tc.events)
+
+			testutil.AssertEqual(t, "failure", tc.want, got)
+		})
+	}
+}
+
+func TestAppStatus_PropagateInstancesHealth(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		failure *InstanceFailure
+
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		"healthy": {
+			wantStatus: corev1.ConditionTrue,
+		},
+		"pending": {
+			failure: &InstanceFailure{
+				PodName: "app-a",
+				Reason:  "FailedScheduling",
+				Message: "0/3 nodes are available",
+			},
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  "FailedScheduling",
+			wantMessage: "instance app-a failed: 0/3 nodes are available",
+		},
+		"crashed": {
+			failure: &InstanceFailure{
+				PodName: "app-a",
+				Reason:  "CrashLoopBackOff",
+				Message: "container user-container is crashing, it last exited with Error (exit code 1)",
+				Crashed: true,
+			},
+			wantStatus:  corev1.ConditionFalse,
+			wantReason:  "CrashLoopBackOff",
+			wantMessage: "instance app-a failed: container user-container is crashing, it last exited with Error (exit code 1), run kf logs to see its output",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &AppStatus{}
+
+			status.PropagateInstancesHealth(tc.failure)
+
+			cond := status.GetCondition(AppConditionInstancesHealthy)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.Status)
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+			testutil.AssertEqual(t, "message", tc.wantMessage, cond.Message)
+		})
+	}
+}
+
+func TestAppStatus_MarkInstancesHealthUnknown(t *testing.T) {
+	t.Parallel()
+
+	status := &AppStatus{}
+	status.PropagateInstancesHealth(&InstanceFailure{
+		PodName: "app-a",
+		Reason:  "CrashLoopBackOff",
+		Message: "container user-container is crashing",
+	})
+
+	// A new push replaces the crashing revision.
+	status.MarkInstancesHealthUnknown()
+
+	cond := status.GetCondition(AppConditionInstancesHealthy)
+	testutil.AssertEqual(t, "status", corev1.ConditionUnknown, cond.Status)
+	testutil.AssertEqual(t, "reason", "WaitingForRevision", cond.Reason)
+}
+
+func TestFreeInstanceIndex(t *testing.T) {
+	t.Parallel()
+
+	pod := func(name, index string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if index != "" {
+			p.Annotations = map[string]string{InstanceIndexAnnotation: index}
+		}
+
+		return p
+	}
+
+	terminating := pod("app-old", "0")
+	now := metav1.Now()
+	terminating.DeletionTimestamp = &now
+
+	cases := map[string]struct {
+		pods     []*corev1.Pod
+		reserved map[int]bool
+
+		want int
+	}{
+		"no pods": {
+			want: 0,
+		},
+		"next index": {
+			pods: []*corev1.Pod{pod("app-a", "0"), pod("app-b", "1")},
+			want: 2,
+		},
+		"lowest free index": {
+			pods: []*corev1.Pod{pod("app-a", "0"), pod("app-b", "2")},
+			want: 1,
+		},
+		"unassigned and invalid indexes are ignored": {
+			pods: []*corev1.Pod{pod("app-a", UnassignedInstanceIndex), pod("app-b", "zero"), pod("app-c", "")},
+			want: 0,
+		},
+		"replacement takes terminating index": {
+			pods: []*corev1.Pod{terminating, pod("app-a", "1")},
+			want: 0,
+		},
+		"reserved indexes are skipped": {
+			pods:     []*corev1.Pod{pod("app-a", "0")},
+			reserved: map[int]bool{1: true},
+			want:     2,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := FreeInstanceIndex(tc.pods, tc.reserved)
+
+			testutil.AssertEqual(t, "index", tc.want, got)
+		})
+	}
+}
+
+func TestAppStatus_PropagateServiceCredentialsHash(t *testing.T) {
+	t.Parallel()
+
+	status := &AppStatus{}
+
+	status.PropagateServiceCredentialsHash("abc")
+	testutil.AssertEqual(t, "first hash", "abc", status.ServiceCredentials.Hash)
+	testutil.AssertEqual(t, "first hash changed", true, status.ServiceCredentials.LastChanged == nil)
+
+	status.PropagateServiceCredentialsHash("abc")
+	testutil.AssertEqual(t, "same hash changed", true, status.ServiceCredentials.LastChanged == nil)
+
+	status.PropagateServiceCredentialsHash("def")
+	testutil.AssertEqual(t, "new hash", "def", status.ServiceCredentials.Hash)
+	testutil.AssertEqual(t, "new hash changed", true, status.ServiceCredentials.LastChanged != nil)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/knative/serving/pkg/apis/autoscaling"
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 
@@ -34,6 +35,37 @@ const (
 	// ComponentLabel holds the standard label key for Kubernetes app component
 	// identifiers.
 	ComponentLabel = "app.kubernetes.io/component"
+	// ProcessTypeLabel holds the label key for the type of process a resource
+	// runs for an App.
+	ProcessTypeLabel = "kf.dev/process-type"
+	// InstanceIndexAnnotation holds the annotation key for the ordinal of the
+	// instance a Pod runs, like Cloud Foundry's instance index. Kf's webhook
+	// assigns it when the Pod is created, Pods it couldn't assign one to keep
+	// the -1 from their template.
+	InstanceIndexAnnotation = "kf.dev/instance-index"
+	// ServiceCredentialsHashAnnotation holds the annotation key for the hash
+	// of the service credentials an App's Pods were started with. Changing it
+	// rolls the App's instances.
+	ServiceCredentialsHashAnnotation = "kf.dev/service-credentials-hash"
+	// ServiceCredentialsChangedReason is the reason of the Event recorded on
+	// an App when its service credentials change and its instances are
+	// rolled.
+	ServiceCredentialsChangedReason = "ServiceCredentialsChanged"
+	// UnassignedInstanceIndex is the instance index of Pods that haven't been
+	// assigned one yet.
+	UnassignedInstanceIndex = "-1"
+
+	// WebProcessType is the process type that serves the App's routes. It's
+	// described by the App's template and instances rather than a process.
+	WebProcessType = "web"
+
+	// RollingStrategy gradually moves traffic to a new revision once it's
+	// healthy.
+	RollingStrategy = "rolling"
+
+	// BlueGreenStrategy moves all traffic to a new revision at once after it's
+	// healthy.
+	BlueGreenStrategy = "blue-green"
 )
 
 // +genclient
@@ -90,6 +122,22 @@ type AppSpec struct {
 	// +optional
 	// +patchStrategy=merge
 	ServiceBindings []AppSpecServiceBinding `json:"serviceBindings,omitempty"`
+
+	// Processes defines additional process types, such as workers, that run
+	// from the same source as the App but don't receive traffic.
+	// +optional
+	// +patchStrategy=merge
+	Processes []AppSpecProcess `json:"processes,omitempty"`
+
+	// Strategy defines how traffic is moved to new revisions of the App.
+	// +optional
+	Strategy AppSpecStrategy `json:"strategy,omitempty"`
+
+	// RevisionHistoryLimit is the number of old revisions of the App to keep
+	// in its history, along with the sources that built them. Revisions in
+	// the history can be rolled back to.
+	// +optional
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
 }
 
 // AppSpecTemplate defines an app's runtime configuration.
@@ -140,6 +188,57 @@ type AppSpecServiceBinding struct {
 	BindingName string `json:"bindingName,omitempty"`
 }
 
+// AppSpecProcess is an additional process type that runs from the App's
+// image without routes or a port based health check.
+type AppSpecProcess struct {
+
+	// Type is the name of the process e.g. worker.
+	Type string `json:"type"`
+
+	// Command overrides the command the process runs.
+	// +optional
+	Command string `json:"command,omitempty"`
+
+	// Instances is the number of instances of the process to run, it
+	// defaults to 1.
+	// +optional
+	Instances *int `json:"instances,omitempty"`
+
+	// Memory is the memory requested by each instance of the process.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// HealthCheck determines if an instance of the process is ready. If unset
+	// the process is considered healthy as long as it's running.
+	// +optional
+	HealthCheck *core.Probe `json:"healthCheck,omitempty"`
+}
+
+// AppSpecStrategy defines how traffic is moved to a new revision of the App
+// and when the new revision is given up on.
+type AppSpecStrategy struct {
+
+	// Type is the strategy used to move traffic, either rolling or blue-green.
+	// If blank, all traffic is sent to a new revision as soon as it's ready.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// TimeoutSeconds is how long a new revision has to become healthy before
+	// traffic is rolled back to the previous revision.
+	// +optional
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+// HistoryLimit returns the number of old revisions to keep in the App's
+// history.
+func (spec *AppSpec) HistoryLimit() int {
+	if spec.RevisionHistoryLimit == nil {
+		return DefaultRevisionHistoryLimit
+	}
+
+	return *spec.RevisionHistoryLimit
+}
+
 // MinAnnotationValue returns the value autoscaling.knative.dev/minScale should
 // be set to.
 func (instances *AppSpecInstances) MinAnnotationValue() string {
@@ -170,6 +269,24 @@ func (instances *AppSpecInstances) MaxAnnotationValue() string {
 	}
 }
 
+// DesiredInstances returns how many instances should be running given the
+// number that are running now. Autoscaled Apps run whatever the autoscaler
+// picked, within Min and Max.
+func (instances *AppSpecInstances) DesiredInstances(running int) int {
+	switch {
+	case instances.Stopped:
+		return 0
+	case instances.Exactly != nil:
+		return *instances.Exactly
+	case instances.Min != nil && running < *instances.Min:
+		return *instances.Min
+	case instances.Max != nil && running > *instances.Max:
+		return *instances.Max
+	default:
+		return running
+	}
+}
+
 // ScalingAnnotations returns the annotations to put on the underling Serving
 // to set scaling bounds.
 func (instances *AppSpecInstances) ScalingAnnotations() map[string]string {
@@ -214,6 +331,141 @@ type AppStatus struct {
 
 	// ServiceBindingConditions are the conditions of the service bindings.
 	ServiceBindingConditions duckv1beta1.Conditions `json:"serviceBindingConditions"`
+
+	// StableRevisionName is the revision that keeps receiving traffic while a
+	// new revision is rolled out.
+	StableRevisionName string `json:"stableRevision,omitempty"`
+
+	// RolloutPercent is the percent of traffic sent to the latest ready
+	// revision while it's being rolled out.
+	RolloutPercent int `json:"rolloutPercent,omitempty"`
+
+	// RolloutRevisionName is the revision RolloutPercent applies to, the
+	// rollout starts over when a newer revision is created.
+	RolloutRevisionName string `json:"rolloutRevision,omitempty"`
+
+	// History holds the revisions of the App that were deployed, oldest
+	// first. The latest revision and up to RevisionHistoryLimit old ones are
+	// kept.
+	// +optional
+	History []AppRevision `json:"history,omitempty"`
+
+	// Instances summarizes the Pods running the App's web process.
+	// +optional
+	Instances AppInstancesStatus `json:"instances,omitempty"`
+
+	// ServiceCredentials tracks the credentials of the App's service bindings
+	// so the App's instances are rolled when they change.
+	// +optional
+	ServiceCredentials AppServiceCredentialsStatus `json:"serviceCredentials,omitempty"`
+}
+
+// AppServiceCredentialsStatus tracks the service credentials injected into
+// the App through VCAP_SERVICES.
+type AppServiceCredentialsStatus struct {
+	// Hash is the hash of the injected VCAP_SERVICES.
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// LastChanged is when the credentials last changed, the App's instances
+	// were rolled to pick up the change.
+	// +optional
+	LastChanged *metav1.Time `json:"lastChanged,omitempty"`
+}
+
+// TemplateAnnotations returns the annotations that roll the App's instances
+// when the credentials change. Nothing is returned until the credentials
+// first change so Apps aren't rolled when their credentials start being
+// tracked.
+func (credentials *AppServiceCredentialsStatus) TemplateAnnotations() map[string]string {
+	if credentials.LastChanged == nil {
+		return nil
+	}
+
+	return map[string]string{
+		ServiceCredentialsHashAnnotation: credentials.Hash,
+	}
+}
+
+// AppInstancesStatus summarizes the Pods running the App's web process.
+type AppInstancesStatus struct {
+	// Desired is the number of instances the App should be running. For
+	// autoscaled Apps it's the number the autoscaler is running, within the
+	// App's min and max.
+	Desired int `json:"desired"`
+
+	// Ready is the number of instances that can receive traffic.
+	Ready int `json:"ready"`
+
+	// Restarts is how many times the containers of the instances were
+	// restarted.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+
+	// LastTerminationReason is why a container of the instances last
+	// terminated, e.g. OOMKilled or Error.
+	// +optional
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+}
+
+// AppInstanceStatus is the state of a single instance of the App. It isn't
+// part of the App's status, which only holds the totals so it doesn't change
+// every time a Pod does; clients build it from the Pods with
+// DescribeInstances.
+type AppInstanceStatus struct {
+	// Name is the name of the Pod running the instance.
+	Name string `json:"name"`
+
+	// State is a short description of the instance's state, e.g. Running,
+	// Pending, Terminating or the reason a container is waiting like
+	// CrashLoopBackOff.
+	State string `json:"state"`
+
+	// Ready is true if the instance can receive traffic.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+
+	// Restarts is how many times the instance's containers were restarted.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+
+	// LastTerminationReason is why a container of the instance last
+	// terminated, e.g. OOMKilled or Error.
+	// +optional
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+
+	// NodeName is the node the instance is scheduled on.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// CreationTimestamp is when the instance was created.
+	// +optional
+	CreationTimestamp metav1.Time `json:"creationTimestamp,omitempty"`
+}
+
+// AppRevision is a revision of an App that was deployed. It holds everything
+// needed to deploy the revision again without rebuilding it.
+type AppRevision struct {
+
+	// Revision is the sequence number of the revision, starting at 1.
+	Revision int `json:"revision"`
+
+	// Name is the name of the Knative revision that was deployed.
+	Name string `json:"name"`
+
+	// Image is the image that was deployed, pinned to its digest if it's
+	// known.
+	Image string `json:"image"`
+
+	// SourceName is the name of the Source that produced the image.
+	// +optional
+	SourceName string `json:"sourceName,omitempty"`
+
+	// Template is the App's runtime configuration when it was deployed.
+	Template AppSpecTemplate `json:"template"`
+
+	// DeployedAt is the time the revision was deployed.
+	DeployedAt metav1.Time `json:"deployedAt"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
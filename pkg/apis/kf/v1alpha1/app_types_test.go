@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/kf/pkg/kf/testutil"
 	"github.com/knative/serving/pkg/apis/autoscaling"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func intPtr(val int) *int {
@@ -90,6 +91,48 @@ func TestAppSpecInstances_MaxAnnotationValue(t *testing.T) {
 	}
 }
 
+func TestAppSpecInstances_DesiredInstances(t *testing.T) {
+	cases := map[string]struct {
+		instances AppSpecInstances
+		running   int
+		expected  int
+	}{
+		"stopped": {
+			instances: AppSpecInstances{Stopped: true, Exactly: intPtr(3)},
+			running:   1,
+			expected:  0,
+		},
+		"exactly defined": {
+			instances: AppSpecInstances{Exactly: intPtr(3)},
+			running:   1,
+			expected:  3,
+		},
+		"below min": {
+			instances: AppSpecInstances{Min: intPtr(2), Max: intPtr(5)},
+			running:   1,
+			expected:  2,
+		},
+		"above max": {
+			instances: AppSpecInstances{Min: intPtr(2), Max: intPtr(5)},
+			running:   7,
+			expected:  5,
+		},
+		"autoscaled": {
+			instances: AppSpecInstances{Min: intPtr(2), Max: intPtr(5)},
+			running:   4,
+			expected:  4,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := tc.instances.DesiredInstances(tc.running)
+
+			testutil.AssertEqual(t, "desired", tc.expected, actual)
+		})
+	}
+}
+
 func TestAppSpecInstances_ScalingAnnotations(t *testing.T) {
 	cases := map[string]struct {
 		instances AppSpecInstances
@@ -168,3 +211,31 @@ func ExampleApp_ComponentLabels() {
 	// managed-by: kf
 	// component: database
 }
+
+func TestAppServiceCredentialsStatus_TemplateAnnotations(t *testing.T) {
+	changed := metav1.Now()
+
+	cases := map[string]struct {
+		credentials AppServiceCredentialsStatus
+		expected    map[string]string
+	}{
+		"never changed": {
+			credentials: AppServiceCredentialsStatus{Hash: "abc"},
+			expected:    nil,
+		},
+		"changed": {
+			credentials: AppServiceCredentialsStatus{Hash: "def", LastChanged: &changed},
+			expected: map[string]string{
+				ServiceCredentialsHashAnnotation: "def",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := tc.credentials.TemplateAnnotations()
+
+			testutil.AssertEqual(t, "annotations", tc.expected, actual)
+		})
+	}
+}
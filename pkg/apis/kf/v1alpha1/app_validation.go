@@ -21,6 +21,8 @@ import (
 
 	"github.com/knative/serving/pkg/apis/serving"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -43,6 +45,13 @@ func (spec *AppSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(spec.Instances.Validate(ctx).ViaField("instances"))
 	errs = errs.Also(spec.ValidateSourceSpec(ctx).ViaField("source"))
 	errs = errs.Also(spec.ValidateServiceBindings(ctx).ViaField("serviceBindings"))
+	errs = errs.Also(spec.ValidateRoutes(ctx).ViaField("routes"))
+	errs = errs.Also(spec.ValidateProcesses(ctx).ViaField("processes"))
+	errs = errs.Also(spec.Strategy.Validate(ctx).ViaField("strategy"))
+
+	if spec.RevisionHistoryLimit != nil && *spec.RevisionHistoryLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*spec.RevisionHistoryLimit, "revisionHistoryLimit"))
+	}
 
 	return errs
 }
@@ -107,26 +116,35 @@ func (instances *AppSpecInstances) Validate(ctx context.Context) (errs *apis.Fie
 // ValidatePodSpec proxies Knative Serving's checks on PodSpec, except for
 // one condition. We don't allow setting the container image directly on the
 // PodSpec because it'll be set by the source instead.
+//
+// Sidecars are rejected because the version of Knative Serving Kf runs on
+// only supports a single container per Service.
 func ValidatePodSpec(podSpec v1.PodSpec) (errs *apis.FieldError) {
 	// copy because we need to edit the PodSpec
 	ps := podSpec.DeepCopy()
 
-	switch len(ps.Containers) {
-	case 0:
-		errs = errs.Also(apis.ErrMissingField("containers"))
-	case 1:
-		if ps.Containers[0].Image != "" {
-			errs = errs.Also(apis.ErrDisallowedFields("image"))
-		}
+	if len(ps.Containers) == 0 {
+		return errs.Also(apis.ErrMissingField("containers"))
+	}
 
-		// Use a valid dummy image so we can re-use the validation from Knative
-		// serving.
-		ps.Containers[0].Image = "gcr.io/dummy/image:latest"
-		errs = errs.Also(serving.ValidatePodSpec(*ps))
-	default:
-		errs = errs.Also(apis.ErrMultipleOneOf("containers"))
+	if len(ps.Containers) > 1 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "sidecars are not supported",
+			Paths:   []string{"containers"},
+			Details: "Knative Serving only runs one container per App, remove the additional containers",
+		})
+	}
+
+	if ps.Containers[0].Image != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("image"))
 	}
 
+	// Use a valid dummy image so we can re-use the validation from Knative
+	// serving.
+	ps.Containers = ps.Containers[:1]
+	ps.Containers[0].Image = "gcr.io/dummy/image:latest"
+	errs = errs.Also(serving.ValidatePodSpec(*ps))
+
 	return errs
 }
 
@@ -154,3 +172,65 @@ func (binding *AppSpecServiceBinding) Validate(ctx context.Context) (errs *apis.
 
 	return errs
 }
+
+// ValidateRoutes validates each route bound to an App.
+func (spec *AppSpec) ValidateRoutes(ctx context.Context) (errs *apis.FieldError) {
+	for i, route := range spec.Routes {
+		errs = errs.Also(route.ValidateWeight(ctx).ViaIndex(i))
+		errs = errs.Also(route.ValidatePort(ctx).ViaIndex(i))
+		errs = errs.Also(route.ValidateMatch(ctx).ViaIndex(i))
+	}
+
+	return errs
+}
+
+// ValidateProcesses validates each AppSpecProcess for an App.
+func (spec *AppSpec) ValidateProcesses(ctx context.Context) (errs *apis.FieldError) {
+	types := sets.NewString()
+	for i, process := range spec.Processes {
+		errs = errs.Also(process.Validate(ctx).ViaIndex(i))
+
+		if process.Type != "" && types.Has(process.Type) {
+			errs = errs.Also((&apis.FieldError{Message: "duplicate process type", Paths: []string{"type"}}).ViaIndex(i))
+		}
+		types.Insert(process.Type)
+	}
+
+	return errs
+}
+
+// Validate checks that the process can be run next to the App's web process.
+func (process *AppSpecProcess) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case process.Type == "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+	case process.Type == WebProcessType:
+		errs = errs.Also(&apis.FieldError{
+			Message: "the web process is configured by the App's template and instances",
+			Paths:   []string{"type"},
+		})
+	case len(validation.IsDNS1123Label(process.Type)) > 0:
+		errs = errs.Also(apis.ErrInvalidValue(process.Type, "type"))
+	}
+
+	if process.Instances != nil && *process.Instances < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*process.Instances, "instances"))
+	}
+
+	return errs
+}
+
+// Validate checks that the strategy type is known and the timeout is usable.
+func (strategy *AppSpecStrategy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch strategy.Type {
+	case "", RollingStrategy, BlueGreenStrategy:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(strategy.Type, "type"))
+	}
+
+	if strategy.TimeoutSeconds != nil && *strategy.TimeoutSeconds <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*strategy.TimeoutSeconds, "timeoutSeconds"))
+	}
+
+	return errs
+}
@@ -96,6 +96,20 @@ func TestApp_Validate(t *testing.T) {
 			},
 			want: apis.ErrDisallowedFields("spec.source.serviceAccount"),
 		},
+		"invalid revision history limit": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template:             goodTemplate,
+					Instances:            goodInstances,
+					Source:               goodSource,
+					RevisionHistoryLimit: intPtr(-1),
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.revisionHistoryLimit"),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -234,11 +248,18 @@ func TestValidatePodSpec(t *testing.T) {
 			},
 			want: apis.ErrMissingField("containers"),
 		},
-		"too many containers": {
+		"sidecars": {
 			spec: corev1.PodSpec{
-				Containers: []corev1.Container{{}, {}},
+				Containers: []corev1.Container{
+					{},
+					{Name: "proxy", Args: []string{"./proxy"}},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "sidecars are not supported",
+				Paths:   []string{"containers"},
+				Details: "Knative Serving only runs one container per App, remove the additional containers",
 			},
-			want: apis.ErrMultipleOneOf("containers"),
 		},
 		"container has image": {
 			spec: corev1.PodSpec{
@@ -315,3 +336,194 @@ func TestAppSpecServiceBinding_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSpec_ValidateProcesses(t *testing.T) {
+	negative := -1
+	three := 3
+
+	cases := map[string]struct {
+		processes []AppSpecProcess
+		want      *apis.FieldError
+	}{
+		"valid": {
+			processes: []AppSpecProcess{
+				{Type: "worker", Command: "bundle exec sidekiq", Instances: &three},
+				{Type: "clock"},
+			},
+		},
+		"missing type": {
+			processes: []AppSpecProcess{{}},
+			want:      apis.ErrMissingField("[0].type"),
+		},
+		"web type": {
+			processes: []AppSpecProcess{{Type: "web"}},
+			want: &apis.FieldError{
+				Message: "the web process is configured by the App's template and instances",
+				Paths:   []string{"[0].type"},
+			},
+		},
+		"invalid type": {
+			processes: []AppSpecProcess{{Type: "Worker_1"}},
+			want:      apis.ErrInvalidValue("Worker_1", "[0].type"),
+		},
+		"duplicate type": {
+			processes: []AppSpecProcess{{Type: "worker"}, {Type: "worker"}},
+			want:      &apis.FieldError{Message: "duplicate process type", Paths: []string{"[1].type"}},
+		},
+		"negative instances": {
+			processes: []AppSpecProcess{{Type: "worker", Instances: &negative}},
+			want:      apis.ErrInvalidValue(-1, "[0].instances"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spec := &AppSpec{Processes: tc.processes}
+
+			got := spec.ValidateProcesses(context.Background())
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestAppSpec_ValidateRoutes(t *testing.T) {
+	cases := map[string]struct {
+		routes []RouteSpecFields
+		want   *apis.FieldError
+	}{
+		"no weight": {
+			routes: []RouteSpecFields{{Domain: "example.com"}},
+		},
+		"valid weights": {
+			routes: []RouteSpecFields{
+				{Domain: "example.com", Weight: intPtr(0)},
+				{Domain: "example.com", Path: "/foo", Weight: intPtr(100)},
+			},
+		},
+		"negative weight": {
+			routes: []RouteSpecFields{{Domain: "example.com", Weight: intPtr(-1)}},
+			want:   apis.ErrInvalidValue(-1, "[0].weight"),
+		},
+		"weight over 100": {
+			routes: []RouteSpecFields{
+				{Domain: "example.com"},
+				{Domain: "example.com", Path: "/foo", Weight: intPtr(101)},
+			},
+			want: apis.ErrInvalidValue(101, "[1].weight"),
+		},
+		"valid port": {
+			routes: []RouteSpecFields{{Domain: "tcp.example.com", Path: "/", Port: 1234}},
+		},
+		"port out of range": {
+			routes: []RouteSpecFields{{Domain: "tcp.example.com", Port: 80}},
+			want:   apis.ErrInvalidValue(80, "[0].port"),
+		},
+		"port with path": {
+			routes: []RouteSpecFields{{Domain: "tcp.example.com", Path: "/bar", Port: 1234}},
+			want:   apis.ErrDisallowedFields("[0].path"),
+		},
+		"header match": {
+			routes: []RouteSpecFields{{
+				Domain: "example.com",
+				Match:  &RouteMatch{Headers: map[string]string{"x-canary": "true"}},
+			}},
+		},
+		"cookie match": {
+			routes: []RouteSpecFields{{
+				Domain: "example.com",
+				Match:  &RouteMatch{Cookies: map[string]string{"preview": "v2"}},
+			}},
+		},
+		"empty match": {
+			routes: []RouteSpecFields{{Domain: "example.com", Match: &RouteMatch{}}},
+			want:   apis.ErrMissingOneOf("[0].match.headers", "[0].match.cookies"),
+		},
+		"invalid header name": {
+			routes: []RouteSpecFields{{
+				Domain: "example.com",
+				Match:  &RouteMatch{Headers: map[string]string{"x canary": "true"}},
+			}},
+			want: apis.ErrInvalidValue("x canary", "[0].match.headers"),
+		},
+		"too many cookies": {
+			routes: []RouteSpecFields{{
+				Domain: "example.com",
+				Match:  &RouteMatch{Cookies: map[string]string{"a": "1", "b": "2"}},
+			}},
+			want: &apis.FieldError{
+				Message: "too many cookies",
+				Paths:   []string{"[0].match.cookies"},
+				Details: "Only one cookie can be matched.",
+			},
+		},
+		"invalid cookie value": {
+			routes: []RouteSpecFields{{
+				Domain: "example.com",
+				Match:  &RouteMatch{Cookies: map[string]string{"preview": "v2; other=1"}},
+			}},
+			want: apis.ErrInvalidValue("v2; other=1", "[0].match.cookies.preview"),
+		},
+		"cookie header and cookies": {
+			routes: []RouteSpecFields{{
+				Domain: "example.com",
+				Match: &RouteMatch{
+					Headers: map[string]string{"Cookie": "preview=v2"},
+					Cookies: map[string]string{"preview": "v2"},
+				},
+			}},
+			want: apis.ErrMultipleOneOf("[0].match.headers.cookie", "[0].match.cookies"),
+		},
+		"match on TCP route": {
+			routes: []RouteSpecFields{{
+				Domain: "tcp.example.com",
+				Port:   1234,
+				Match:  &RouteMatch{Headers: map[string]string{"x-canary": "true"}},
+			}},
+			want: apis.ErrDisallowedFields("[0].match"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spec := &AppSpec{Routes: tc.routes}
+
+			got := spec.ValidateRoutes(context.Background())
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestAppSpecStrategy_Validate(t *testing.T) {
+	goodTimeout := 60
+	badTimeout := 0
+
+	cases := map[string]struct {
+		strategy AppSpecStrategy
+		want     *apis.FieldError
+	}{
+		"blank": {
+			strategy: AppSpecStrategy{},
+		},
+		"rolling": {
+			strategy: AppSpecStrategy{Type: RollingStrategy, TimeoutSeconds: &goodTimeout},
+		},
+		"blue-green": {
+			strategy: AppSpecStrategy{Type: BlueGreenStrategy, TimeoutSeconds: &goodTimeout},
+		},
+		"unknown type": {
+			strategy: AppSpecStrategy{Type: "canary"},
+			want:     apis.ErrInvalidValue("canary", "type"),
+		},
+		"non-positive timeout": {
+			strategy: AppSpecStrategy{Type: RollingStrategy, TimeoutSeconds: &badTimeout},
+			want:     apis.ErrInvalidValue(0, "timeoutSeconds"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.strategy.Validate(context.Background())
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Domain is a domain routes can be created on. It's managed once for the
+// whole cluster and is either shared with every Space or private to a single
+// Space. The Domain's name is the domain itself, e.g. example.com.
+type Domain struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec DomainSpec `json:"spec,omitempty"`
+}
+
+// DomainSpec contains the specification for a Domain.
+type DomainSpec struct {
+	// Space is the Space the Domain is private to. Domains without a Space
+	// are shared with every Space.
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// Internal implies that routes on the Domain are only reachable from
+	// inside the cluster.
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+
+	// TCP implies that routes on the Domain carry raw TCP traffic to a port
+	// instead of HTTP traffic to a hostname and path.
+	// +optional
+	TCP bool `json:"tcp,omitempty"`
+
+	// TLS configures HTTPS for routes on the Domain. TCP and internal Domains
+	// can't use TLS.
+	// +optional
+	TLS *DomainTLS `json:"tls,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainList is a list of Domain resources.
+type DomainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Domain `json:"items"`
+}
+
+// IsShared returns true if the Domain can be used by every Space.
+func (d *Domain) IsShared() bool {
+	return d.Spec.Space == ""
+}
+
+// AvailableTo returns true if routes in the Space can use the Domain.
+func (d *Domain) AvailableTo(space string) bool {
+	return d.IsShared() || d.Spec.Space == space
+}
+
+// SpaceDomain converts the Domain into the form Spaces list their domains
+// in.
+func (d *Domain) SpaceDomain() SpaceDomain {
+	return SpaceDomain{
+		Domain:   d.Name,
+		Internal: d.Spec.Internal,
+		TCP:      d.Spec.TCP,
+		TLS:      d.Spec.TLS.DeepCopy(),
+	}
+}
+
+// WithDomains returns a copy of the Space with the Domains available to it
+// added to its list of domains. Domains the Space already lists take
+// precedence over cluster Domains with the same name.
+func (k *Space) WithDomains(domains []*Domain) *Space {
+	out := k.DeepCopy()
+
+	existing := make(map[string]bool)
+	for _, d := range out.Spec.Execution.Domains {
+		existing[d.Domain] = true
+	}
+
+	for _, d := range domains {
+		if !d.AvailableTo(k.Name) || existing[d.Name] {
+			continue
+		}
+
+		out.Spec.Execution.Domains = append(out.Spec.Execution.Domains, d.SpaceDomain())
+		existing[d.Name] = true
+	}
+
+	return out
+}
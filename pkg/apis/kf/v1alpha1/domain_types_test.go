@@ -0,0 +1,65 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleDomain_AvailableTo() {
+	shared := Domain{ObjectMeta: metav1.ObjectMeta{Name: "example.com"}}
+	private := Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev.example.com"},
+		Spec:       DomainSpec{Space: "dev"},
+	}
+
+	fmt.Println("shared in dev:", shared.AvailableTo("dev"))
+	fmt.Println("private in dev:", private.AvailableTo("dev"))
+	fmt.Println("private in prod:", private.AvailableTo("prod"))
+
+	// Output: shared in dev: true
+	// private in dev: true
+	// private in prod: false
+}
+
+func ExampleSpace_WithDomains() {
+	space := &Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev"},
+		Spec: SpaceSpec{
+			Execution: SpaceSpecExecution{
+				Domains: []SpaceDomain{
+					{Domain: "example.com", Default: true},
+				},
+			},
+		},
+	}
+
+	merged := space.WithDomains([]*Domain{
+		{ObjectMeta: metav1.ObjectMeta{Name: "example.com"}, Spec: DomainSpec{Internal: true}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tcp.example.com"}, Spec: DomainSpec{TCP: true}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "prod.example.com"}, Spec: DomainSpec{Space: "prod"}},
+	})
+
+	for _, d := range merged.Spec.Execution.Domains {
+		fmt.Printf("%s default: %v internal: %v tcp: %v\n", d.Domain, d.Default, d.Internal, d.TCP)
+	}
+	fmt.Println("original domains:", len(space.Spec.Execution.Domains))
+
+	// Output: example.com default: true internal: false tcp: false
+	// tcp.example.com default: false internal: false tcp: true
+	// original domains: 1
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate makes sure that Domain is properly configured.
+func (domain *Domain) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if domain.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if len(validation.IsDNS1123Subdomain(domain.Name)) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(domain.Name, "name"))
+	}
+
+	errs = errs.Also(domain.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	return errs
+}
+
+// Validate makes sure that DomainSpec is properly configured.
+func (spec *DomainSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if spec.Space != "" && len(validation.IsDNS1123Label(spec.Space)) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(spec.Space, "space"))
+	}
+
+	if spec.TCP && spec.Internal {
+		errs = errs.Also(&apis.FieldError{
+			Paths:   []string{"internal", "tcp"},
+			Message: "internal TCP domain",
+			Details: "TCP domains can't be internal",
+		})
+	}
+
+	if spec.TLS != nil && (spec.TCP || spec.Internal) {
+		errs = errs.Also(&apis.FieldError{
+			Paths:   []string{"tls"},
+			Message: "TLS on non-HTTP domain",
+			Details: "TLS can only be used with external HTTP domains",
+		})
+	}
+	errs = errs.Also(spec.TLS.Validate(ctx).ViaField("tls"))
+
+	// Moving a Domain between Spaces would strand the routes already on it.
+	if base := apis.GetBaseline(ctx); base != nil {
+		if old, ok := base.(*Domain); ok && old.Spec.Space != spec.Space {
+			errs = errs.Also(&apis.FieldError{Message: "Immutable field changed", Paths: []string{"space"}})
+		}
+	}
+
+	return errs
+}
+
+// Validate makes sure exactly one source of the certificate is set. A nil
+// DomainTLS is valid.
+func (tls *DomainTLS) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if tls == nil {
+		return nil
+	}
+
+	switch {
+	case tls.SecretName == "" && !tls.SelfSigned:
+		errs = errs.Also(apis.ErrMissingOneOf("secretName", "selfSigned"))
+	case tls.SecretName != "" && tls.SelfSigned:
+		errs = errs.Also(apis.ErrMultipleOneOf("secretName", "selfSigned"))
+	case tls.SecretName != "" && len(validation.IsDNS1123Subdomain(tls.SecretName)) > 0:
+		errs = errs.Also(apis.ErrInvalidValue(tls.SecretName, "secretName"))
+	}
+
+	return errs
+}
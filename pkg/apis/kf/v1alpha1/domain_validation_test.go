@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestDomain_Validate(t *testing.T) {
+	goodMeta := metav1.ObjectMeta{Name: "example.com"}
+
+	cases := map[string]struct {
+		old    *Domain
+		domain Domain
+		want   *apis.FieldError
+	}{
+		"valid shared": {
+			domain: Domain{ObjectMeta: goodMeta},
+		},
+		"valid private": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{Space: "my-space"}},
+		},
+		"missing name": {
+			domain: Domain{},
+			want:   apis.ErrMissingField("name"),
+		},
+		"invalid name": {
+			domain: Domain{ObjectMeta: metav1.ObjectMeta{Name: "Example_com"}},
+			want:   apis.ErrInvalidValue("Example_com", "name"),
+		},
+		"invalid space": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{Space: "my.space"}},
+			want:   apis.ErrInvalidValue("my.space", "spec.space"),
+		},
+		"internal TCP": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{Internal: true, TCP: true}},
+			want: &apis.FieldError{
+				Paths:   []string{"spec.internal", "spec.tcp"},
+				Message: "internal TCP domain",
+				Details: "TCP domains can't be internal",
+			},
+		},
+		"valid TLS secret": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{TLS: &DomainTLS{SecretName: "example-com-tls"}}},
+		},
+		"valid self-signed TLS": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{TLS: &DomainTLS{SelfSigned: true}}},
+		},
+		"empty TLS": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{TLS: &DomainTLS{}}},
+			want:   apis.ErrMissingOneOf("spec.tls.secretName", "spec.tls.selfSigned"),
+		},
+		"secret and self-signed TLS": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{TLS: &DomainTLS{SecretName: "example-com-tls", SelfSigned: true}}},
+			want:   apis.ErrMultipleOneOf("spec.tls.secretName", "spec.tls.selfSigned"),
+		},
+		"TCP TLS": {
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{TCP: true, TLS: &DomainTLS{SelfSigned: true}}},
+			want: &apis.FieldError{
+				Paths:   []string{"spec.tls"},
+				Message: "TLS on non-HTTP domain",
+				Details: "TLS can only be used with external HTTP domains",
+			},
+		},
+		"space changed": {
+			old:    &Domain{ObjectMeta: goodMeta, Spec: DomainSpec{Space: "my-space"}},
+			domain: Domain{ObjectMeta: goodMeta},
+			want:   &apis.FieldError{Message: "Immutable field changed", Paths: []string{"spec.space"}},
+		},
+		"flags changed": {
+			old:    &Domain{ObjectMeta: goodMeta},
+			domain: Domain{ObjectMeta: goodMeta, Spec: DomainSpec{Internal: true}},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.old != nil {
+				ctx = apis.WithinUpdate(ctx, tc.old)
+			}
+
+			got := tc.domain.Validate(ctx)
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
@@ -56,6 +56,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RouteList{},
 		&RouteClaim{},
 		&RouteClaimList{},
+		&Task{},
+		&TaskList{},
+		&Domain{},
+		&DomainList{},
 		&metav1.Status{},
 	)
 
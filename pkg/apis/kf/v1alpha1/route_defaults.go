@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"context"
 	"path"
+	"strconv"
 )
 
 const (
@@ -26,6 +27,8 @@ const (
 	RouteDomain = "route.kf.dev/domain"
 	// RoutePath is the URL path of a route.
 	RoutePath = "route.kf.dev/path"
+	// RoutePort is the port of a TCP route.
+	RoutePort = "route.kf.dev/port"
 	// RouteAppName is the App's name that owns the Route.
 	RouteAppName = "route.kf.dev/appname"
 )
@@ -40,9 +43,35 @@ func GenerateRouteName(hostname, domain, urlPath, appName string) string {
 	return GenerateName(hostname, domain, path.Join("/", urlPath), appName)
 }
 
+// GenerateTCPRouteClaimName creates the deterministic name for a TCP Route
+// claim.
+func GenerateTCPRouteClaimName(domain string, port int32) string {
+	return GenerateTCPRouteName(domain, port, "")
+}
+
+// GenerateTCPRouteName creates the deterministic name for a TCP Route.
+func GenerateTCPRouteName(domain string, port int32, appName string) string {
+	return GenerateName("tcp", domain, strconv.Itoa(int(port)), appName)
+}
+
 // GenerateRouteNameFromSpec creates the deterministic name for a Route.
 func GenerateRouteNameFromSpec(spec RouteSpecFields, appName string) string {
-	return GenerateName(spec.Hostname, spec.Domain, spec.Path, appName)
+	if spec.Port != 0 {
+		return GenerateTCPRouteName(spec.Domain, spec.Port, appName)
+	}
+
+	return GenerateRouteName(spec.Hostname, spec.Domain, spec.Path, appName)
+}
+
+// GenerateVirtualServiceName creates the deterministic name for the
+// VirtualService shared by every Route with the same hostname and domain, or
+// domain and port for TCP routes.
+func GenerateVirtualServiceName(spec RouteSpecFields) string {
+	if spec.Port != 0 {
+		return GenerateName("tcp", spec.Domain, strconv.Itoa(int(spec.Port)))
+	}
+
+	return GenerateName(spec.Hostname, spec.Domain)
 }
 
 // SetDefaults implements apis.Defaultable
@@ -50,6 +79,13 @@ func (k *Route) SetDefaults(ctx context.Context) {
 	k.Spec.SetDefaults(ctx)
 }
 
+// SetDefaults implements apis.Defaultable. RouteClaims are left as written
+// because the App reconciler compares them field by field with the claims it
+// wants.
+func (k *RouteClaim) SetDefaults(ctx context.Context) {
+	// XXX: no defaults
+}
+
 // SetDefaults implements apis.Defaultable
 func (k *RouteSpec) SetDefaults(ctx context.Context) {
 	k.RouteSpecFields.SetDefaults(ctx)
@@ -28,3 +28,21 @@ func ExampleRoute_SetDefaults_prefixRoutes() {
 
 	// Output: Route: /some-path
 }
+
+func ExampleGenerateVirtualServiceName() {
+	http := GenerateVirtualServiceName(RouteSpecFields{
+		Hostname: "foo",
+		Domain:   "example.com",
+		Path:     "/bar",
+	})
+	tcp := GenerateVirtualServiceName(RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   1234,
+	})
+
+	fmt.Println("HTTP shares hostname:", http == GenerateName("foo", "example.com"))
+	fmt.Println("TCP differs:", http != tcp)
+
+	// Output: HTTP shares hostname: true
+	// TCP differs: true
+}
@@ -15,10 +15,152 @@
 package v1alpha1
 
 import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
 )
 
 // GetGroupVersionKind returns the GroupVersionKind.
 func (r *Route) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Route")
 }
+
+const (
+	// RouteConditionReady is set when the Route is claimed by its space and
+	// its traffic is being served.
+	RouteConditionReady = apis.ConditionReady
+	// RouteConditionClaimed is set when no other space holds the Route's
+	// hostname and domain.
+	RouteConditionClaimed apis.ConditionType = "Claimed"
+	// RouteConditionVirtualServiceReady is set when the VirtualService
+	// carrying the Route's traffic is up to date.
+	RouteConditionVirtualServiceReady apis.ConditionType = "VirtualServiceReady"
+	// RouteConditionConflictsWithSpace is True while another space holds the
+	// Route's hostname and domain. It's informational and doesn't count
+	// towards RouteConditionReady, Claimed is False at the same time.
+	RouteConditionConflictsWithSpace apis.ConditionType = "ConflictsWithSpace"
+	// RouteConditionNoAppBound is True while no App is mapped to the Route's
+	// path without a match, so some of its requests are served by the
+	// default backend. It's informational and doesn't count towards
+	// RouteConditionReady.
+	RouteConditionNoAppBound apis.ConditionType = "NoAppBound"
+)
+
+// RouteFinalizer is set on Routes and RouteClaims so they can be removed from
+// the VirtualService of their host before they're deleted. VirtualServices
+// live in the kf namespace and Kubernetes doesn't garbage collect objects
+// owned by objects in other namespaces.
+const RouteFinalizer = "routes.kf.dev"
+
+func (status *RouteStatus) manage() apis.ConditionManager {
+	return apis.NewLivingConditionSet(
+		RouteConditionClaimed,
+		RouteConditionVirtualServiceReady,
+	).Manage(status)
+}
+
+// IsReady returns if the Route is claimed and serving traffic.
+func (status *RouteStatus) IsReady() bool {
+	return status.manage().IsHappy()
+}
+
+// GetCondition returns the condition by name.
+func (status *RouteStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return status.manage().GetCondition(t)
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *RouteStatus) InitializeConditions() {
+	status.manage().InitializeConditions()
+}
+
+// VirtualServiceCondition gets a manager for the state of the VirtualService.
+func (status *RouteStatus) VirtualServiceCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), RouteConditionVirtualServiceReady, "VirtualService")
+}
+
+// MarkClaimed notes that the Route's space holds its hostname and domain.
+func (status *RouteStatus) MarkClaimed() {
+	status.ConflictingSpace = ""
+	status.ConflictingDomain = ""
+	status.manage().SetCondition(apis.Condition{
+		Type:   RouteConditionConflictsWithSpace,
+		Status: corev1.ConditionFalse,
+	})
+	status.manage().MarkTrue(RouteConditionClaimed)
+}
+
+// MarkConflictsWithSpace notes that another space already holds the Route's
+// hostname and domain, so the Route can't be served.
+func (status *RouteStatus) MarkConflictsWithSpace(space string) {
+	msg := fmt.Sprintf("The route is already claimed by space %q.", space)
+
+	status.ConflictingSpace = space
+	status.manage().SetCondition(apis.Condition{
+		Type:    RouteConditionConflictsWithSpace,
+		Status:  corev1.ConditionTrue,
+		Reason:  "ConflictsWithSpace",
+		Message: msg,
+	})
+	status.manage().MarkFalse(RouteConditionClaimed, "ConflictsWithSpace", msg)
+	status.manage().MarkUnknown(RouteConditionVirtualServiceReady, "NotClaimed",
+		"The VirtualService isn't updated until the route is claimed.")
+}
+
+// MarkPortConflict notes that the port of the TCP Route is already reserved
+// for another domain or by another space, so the Route can't be served.
+func (status *RouteStatus) MarkPortConflict(port int32, claim *RouteClaim) {
+	msg := fmt.Sprintf("Port %d is already reserved for domain %s by space %q.", port, claim.Spec.Domain, claim.Namespace)
+
+	status.ConflictingSpace = claim.Namespace
+	status.ConflictingDomain = claim.Spec.Domain
+	status.manage().SetCondition(apis.Condition{
+		Type:    RouteConditionConflictsWithSpace,
+		Status:  corev1.ConditionTrue,
+		Reason:  "PortConflict",
+		Message: msg,
+	})
+	status.manage().MarkFalse(RouteConditionClaimed, "PortConflict", msg)
+	status.manage().MarkUnknown(RouteConditionVirtualServiceReady, "NotClaimed",
+		"The VirtualService isn't updated until the route is claimed.")
+}
+
+// MarkAppBound notes that an App receives the requests to the Route that
+// don't match any other App.
+func (status *RouteStatus) MarkAppBound() {
+	status.manage().SetCondition(apis.Condition{
+		Type:   RouteConditionNoAppBound,
+		Status: corev1.ConditionFalse,
+	})
+}
+
+// MarkNoAppBound notes that no App receives the requests to the Route that
+// don't match any other App, they're served by the default backend instead.
+func (status *RouteStatus) MarkNoAppBound(backend RouteDefaultBackendType) {
+	status.manage().SetCondition(apis.Condition{
+		Type:    RouteConditionNoAppBound,
+		Status:  corev1.ConditionTrue,
+		Reason:  "NoAppBound",
+		Message: fmt.Sprintf("No App is mapped to the route without a match, other requests get the %q default backend.", backend),
+	})
+}
+
+// PropagateVirtualServiceStatus copies fields from the VirtualService to the
+// Route and updates the readiness. VirtualServices don't have a status so
+// they're ready once they exist.
+func (status *RouteStatus) PropagateVirtualServiceStatus(vs *networking.VirtualService) {
+	if vs == nil {
+		return
+	}
+
+	status.VirtualServiceName = vs.Name
+	status.manage().MarkTrue(RouteConditionVirtualServiceReady)
+}
+
+func (status *RouteStatus) duck() *duckv1beta1.Status {
+	return &status.Status
+}
@@ -15,9 +15,17 @@
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+	apitesting "knative.dev/pkg/apis/testing"
 )
 
 func TestRouteGeneration(t *testing.T) {
@@ -28,3 +36,182 @@ func TestRouteGeneration(t *testing.T) {
 	route.SetGeneration(answer)
 	testutil.AssertEqual(t, "GetGeneration", answer, route.GetGeneration())
 }
+
+func TestRouteDuckTypes(t *testing.T) {
+	err := duck.VerifyType(&Route{}, &duckv1beta1.Conditions{})
+	if err != nil {
+		t.Errorf("VerifyType(Route, Conditions) = %v", err)
+	}
+}
+
+func initTestRouteStatus(t *testing.T) *RouteStatus {
+	t.Helper()
+	status := &RouteStatus{}
+	status.InitializeConditions()
+
+	// sanity check exclusions
+	testutil.AssertEqual(t, "conflict condition", (*apis.Condition)(nil), status.GetCondition(RouteConditionConflictsWithSpace))
+	testutil.AssertEqual(t, "no app condition", (*apis.Condition)(nil), status.GetCondition(RouteConditionNoAppBound))
+
+	// sanity check conditions get initialized as unknown
+	for _, c := range []apis.ConditionType{
+		RouteConditionReady,
+		RouteConditionClaimed,
+		RouteConditionVirtualServiceReady,
+	} {
+		apitesting.CheckConditionOngoing(status.duck(), c, t)
+	}
+
+	return status
+}
+
+func TestRouteStatus_lifecycle(t *testing.T) {
+	vs := &networking.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "some-vs"}}
+
+	cases := map[string]struct {
+		Init func(*RouteStatus)
+
+		ExpectSucceeded []apis.ConditionType
+		ExpectFailed    []apis.ConditionType
+		ExpectOngoing   []apis.ConditionType
+
+		AssertStatus func(t *testing.T, status *RouteStatus)
+	}{
+		"happy path": {
+			Init: func(status *RouteStatus) {
+				status.MarkClaimed()
+				status.PropagateVirtualServiceStatus(vs)
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionClaimed,
+				RouteConditionVirtualServiceReady,
+			},
+			AssertStatus: func(t *testing.T, status *RouteStatus) {
+				testutil.AssertEqual(t, "VirtualServiceName", "some-vs", status.VirtualServiceName)
+				testutil.AssertEqual(t, "conflict", corev1.ConditionFalse, status.GetCondition(RouteConditionConflictsWithSpace).Status)
+			},
+		},
+		"conflicts with space": {
+			Init: func(status *RouteStatus) {
+				status.MarkConflictsWithSpace("other-space")
+			},
+			ExpectFailed: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionClaimed,
+			},
+			ExpectOngoing: []apis.ConditionType{
+				RouteConditionVirtualServiceReady,
+			},
+			AssertStatus: func(t *testing.T, status *RouteStatus) {
+				testutil.AssertEqual(t, "ConflictingSpace", "other-space", status.ConflictingSpace)
+				testutil.AssertEqual(t, "conflict", corev1.ConditionTrue, status.GetCondition(RouteConditionConflictsWithSpace).Status)
+			},
+		},
+		"port conflict": {
+			Init: func(status *RouteStatus) {
+				status.MarkPortConflict(1024, &RouteClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other-space"},
+					Spec: RouteClaimSpec{
+						RouteSpecFields: RouteSpecFields{Domain: "tcp.example.com", Port: 1024},
+					},
+				})
+			},
+			ExpectFailed: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionClaimed,
+			},
+			ExpectOngoing: []apis.ConditionType{
+				RouteConditionVirtualServiceReady,
+			},
+			AssertStatus: func(t *testing.T, status *RouteStatus) {
+				testutil.AssertEqual(t, "ConflictingSpace", "other-space", status.ConflictingSpace)
+				testutil.AssertEqual(t, "ConflictingDomain", "tcp.example.com", status.ConflictingDomain)
+				testutil.AssertEqual(t, "conflict reason", "PortConflict", status.GetCondition(RouteConditionConflictsWithSpace).Reason)
+			},
+		},
+		"conflict resolved": {
+			Init: func(status *RouteStatus) {
+				status.MarkConflictsWithSpace("other-space")
+				status.MarkClaimed()
+				status.PropagateVirtualServiceStatus(vs)
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionClaimed,
+				RouteConditionVirtualServiceReady,
+			},
+			AssertStatus: func(t *testing.T, status *RouteStatus) {
+				testutil.AssertEqual(t, "ConflictingSpace", "", status.ConflictingSpace)
+			},
+		},
+		"no app bound": {
+			Init: func(status *RouteStatus) {
+				status.MarkClaimed()
+				status.MarkNoAppBound(RouteDefaultBackendPage)
+				status.PropagateVirtualServiceStatus(vs)
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionClaimed,
+				RouteConditionVirtualServiceReady,
+			},
+			AssertStatus: func(t *testing.T, status *RouteStatus) {
+				condition := status.GetCondition(RouteConditionNoAppBound)
+				testutil.AssertEqual(t, "no app", corev1.ConditionTrue, condition.Status)
+				testutil.AssertContainsAll(t, condition.Message, []string{`"page" default backend`})
+			},
+		},
+		"app bound": {
+			Init: func(status *RouteStatus) {
+				status.MarkClaimed()
+				status.MarkNoAppBound(RouteDefaultBackendPage)
+				status.MarkAppBound()
+				status.PropagateVirtualServiceStatus(vs)
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionReady,
+			},
+			AssertStatus: func(t *testing.T, status *RouteStatus) {
+				testutil.AssertEqual(t, "no app", corev1.ConditionFalse, status.GetCondition(RouteConditionNoAppBound).Status)
+			},
+		},
+		"VirtualService reconciliation error": {
+			Init: func(status *RouteStatus) {
+				status.MarkClaimed()
+				status.VirtualServiceCondition().MarkReconciliationError("updating", errors.New("some-error"))
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				RouteConditionClaimed,
+			},
+			ExpectFailed: []apis.ConditionType{
+				RouteConditionReady,
+				RouteConditionVirtualServiceReady,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initTestRouteStatus(t)
+
+			tc.Init(status)
+
+			for _, exp := range tc.ExpectFailed {
+				apitesting.CheckConditionFailed(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectOngoing {
+				apitesting.CheckConditionOngoing(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectSucceeded {
+				apitesting.CheckConditionSucceeded(status.duck(), exp, t)
+			}
+
+			if tc.AssertStatus != nil {
+				tc.AssertStatus(t, status)
+			}
+		})
+	}
+}
@@ -16,13 +16,16 @@ package v1alpha1
 
 import (
 	"path"
+	"sort"
+	"strconv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
-// +genclient:noStatus
 
 // Route is a high level structure that encompasses an Istio VirtualService
 // and configuration applied to it.
@@ -33,6 +36,9 @@ type Route struct {
 
 	// +optional
 	Spec RouteSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status RouteStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -54,6 +60,29 @@ type RouteSpec struct {
 	RouteSpecFields `json:",inline"`
 }
 
+// RouteStatus is the current state of a Route.
+type RouteStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+
+	// VirtualServiceName is the name of the VirtualService in the kf
+	// namespace that carries the route's traffic.
+	// +optional
+	VirtualServiceName string `json:"virtualServiceName,omitempty"`
+
+	// ConflictingSpace is the space that already holds the route's hostname
+	// and domain, or its port for TCP routes. It's only set while the route
+	// conflicts with that space.
+	// +optional
+	ConflictingSpace string `json:"conflictingSpace,omitempty"`
+
+	// ConflictingDomain is the domain that already holds the port of a TCP
+	// route. It's only set while the route conflicts with it, the space of
+	// the domain is in ConflictingSpace.
+	// +optional
+	ConflictingDomain string `json:"conflictingDomain,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +genclient:noStatus
@@ -94,10 +123,44 @@ type RouteSpecFields struct {
 	// Path is the URL path of the route.
 	// +optional
 	Path string `json:"path,omitempty"`
+
+	// Port is the port reserved for the route on a TCP domain. Routes with a
+	// port carry raw TCP traffic so they have no hostname or path.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Weight is the relative share of the route's traffic the App receives
+	// when multiple Apps are bound to the same route. Apps without a weight
+	// split whatever is left over evenly.
+	// +optional
+	Weight *int `json:"weight,omitempty"`
+
+	// Match limits the requests the App receives from the route to the ones
+	// with the given headers and cookies. Requests that don't match go to
+	// the Apps bound to the route without a match.
+	// +optional
+	Match *RouteMatch `json:"match,omitempty"`
+}
+
+// RouteMatch selects requests by their headers and cookies. Every header and
+// cookie has to match exactly.
+type RouteMatch struct {
+	// Headers maps header names to the values they must have.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Cookies maps cookie names to the values they must have. Only one
+	// cookie can be matched.
+	// +optional
+	Cookies map[string]string `json:"cookies,omitempty"`
 }
 
 // String returns a RouteSpecFields converted into an address.
 func (route RouteSpecFields) String() string {
+	if route.Port != 0 {
+		return route.Domain + ":" + strconv.Itoa(int(route.Port))
+	}
+
 	var hostnamePrefix string
 	if route.Hostname != "" {
 		hostnamePrefix = route.Hostname + "."
@@ -105,8 +168,221 @@ func (route RouteSpecFields) String() string {
 	return hostnamePrefix + route.Domain + path.Join("/", route.Path)
 }
 
+// SharesHost returns true if the routes are served by the same
+// VirtualService. HTTP routes share a host if they have the same hostname
+// and domain, TCP routes share a host if they have the same port.
+func (route RouteSpecFields) SharesHost(other RouteSpecFields) bool {
+	if route.Port != 0 || other.Port != 0 {
+		return route.Port == other.Port
+	}
+
+	return route.Hostname == other.Hostname && route.Domain == other.Domain
+}
+
+// ClaimingSpace returns the space that holds the route's host. A host
+// belongs to the space with the oldest RouteClaim on it, ties go to the
+// space that sorts first. An empty string is returned if nothing claims the
+// host.
+func ClaimingSpace(route RouteSpecFields, claims []*RouteClaim) string {
+	if claim := HostClaim(route, claims); claim != nil {
+		return claim.Namespace
+	}
+
+	return ""
+}
+
+// HostClaim returns the RouteClaim that holds the route's host, see
+// ClaimingSpace. nil is returned if nothing claims the host.
+func HostClaim(route RouteSpecFields, claims []*RouteClaim) *RouteClaim {
+	var hostClaims []*RouteClaim
+	for _, claim := range claims {
+		if claim.Spec.SharesHost(route) {
+			hostClaims = append(hostClaims, claim)
+		}
+	}
+
+	if len(hostClaims) == 0 {
+		return nil
+	}
+
+	sort.Slice(hostClaims, func(i, j int) bool {
+		a, b := hostClaims[i], hostClaims[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+
+		return a.Spec.Domain < b.Spec.Domain
+	})
+
+	return hostClaims[0]
+}
+
+// PortConflict returns the RouteClaim holding the port of a TCP route in
+// the namespace if it was reserved by another space or for another domain.
+// Every TCP route is served by the same gateway so a port can only be used
+// by one domain in the cluster. nil is returned for HTTP routes and ports
+// that are free or held by the route's own domain and space.
+func PortConflict(namespace string, route RouteSpecFields, claims []*RouteClaim) *RouteClaim {
+	if route.Port == 0 {
+		return nil
+	}
+
+	claim := HostClaim(route, claims)
+	if claim == nil || (claim.Namespace == namespace && claim.Spec.Domain == route.Domain) {
+		return nil
+	}
+
+	return claim
+}
+
 // RouteClaimSpec contains the specification for a RouteClaim.
 type RouteClaimSpec struct {
 	// RouteSpecFields contains the fields of a route.
 	RouteSpecFields `json:",inline"`
+
+	// RouteService is the service the route's traffic is forwarded through
+	// before it reaches the Apps bound to the route. It's kept on the claim
+	// rather than the Routes so it outlives Apps being mapped and unmapped.
+	// +optional
+	RouteService *RouteServiceBinding `json:"routeService,omitempty"`
+
+	// HTTPSRedirect redirects plain HTTP requests for the route to HTTPS.
+	// The route's domain must have TLS configured. Redirects are served by
+	// the ingress gateway so they apply to every path on the route's host.
+	// +optional
+	HTTPSRedirect bool `json:"httpsRedirect,omitempty"`
+
+	// TrafficPolicy configures timeouts, retries, CORS and headers for the
+	// route's requests.
+	// +optional
+	TrafficPolicy *RouteTrafficPolicy `json:"trafficPolicy,omitempty"`
+}
+
+// RouteServiceBinding binds a route to a service instance that proxies its
+// traffic, see
+// https://docs.cloudfoundry.org/services/route-services.html
+type RouteServiceBinding struct {
+	// InstanceName is the name of the service instance bound to the route.
+	InstanceName string `json:"instanceName"`
+
+	// URL is the route_service_url of the service instance at the time it
+	// was bound.
+	URL string `json:"url"`
+}
+
+// RouteTrafficPolicy configures how requests to an HTTP route are handled on
+// their way to the Apps.
+type RouteTrafficPolicy struct {
+	// TimeoutSeconds is how long a request may take, including retries,
+	// before it fails.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Retries is how many times a failed request, e.g. one that got a 503,
+	// is retried.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// PerTryTimeoutSeconds is how long each attempt may take when Retries is
+	// set. It defaults to TimeoutSeconds.
+	// +optional
+	PerTryTimeoutSeconds *int32 `json:"perTryTimeoutSeconds,omitempty"`
+
+	// CORS lets browsers call the route from pages on other origins.
+	// +optional
+	CORS *RouteCORSPolicy `json:"cors,omitempty"`
+
+	// RequestHeaders are changed before requests reach the Apps.
+	// +optional
+	RequestHeaders *RouteHeaderOperations `json:"requestHeaders,omitempty"`
+
+	// ResponseHeaders are changed before responses reach the client.
+	// +optional
+	ResponseHeaders *RouteHeaderOperations `json:"responseHeaders,omitempty"`
+}
+
+// RouteCORSPolicy is the Cross-Origin Resource Sharing policy of a route.
+type RouteCORSPolicy struct {
+	// AllowOrigins are the origins allowed to make requests, * allows all.
+	AllowOrigins []string `json:"allowOrigins"`
+
+	// AllowMethods are the HTTP methods allowed in requests.
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+
+	// AllowHeaders are the HTTP headers allowed in requests.
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+
+	// ExposeHeaders are the HTTP headers browsers may read in responses.
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAgeSeconds is how long browsers may cache the result of a
+	// preflight request.
+	// +optional
+	MaxAgeSeconds *int32 `json:"maxAgeSeconds,omitempty"`
+}
+
+// RouteHeaderOperations adds and removes HTTP headers.
+type RouteHeaderOperations struct {
+	// Add appends values to the headers, creating them if they don't exist.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+
+	// Remove removes the headers.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// RouteDefaultBackendType is the kind of response served for requests to a
+// route that no App receives.
+type RouteDefaultBackendType string
+
+const (
+	// RouteDefaultBackendUnavailable responds with a bare 503.
+	RouteDefaultBackendUnavailable RouteDefaultBackendType = "unavailable"
+	// RouteDefaultBackendPage responds with kf's "no app mapped" page, which
+	// names the route and its space.
+	RouteDefaultBackendPage RouteDefaultBackendType = "page"
+	// RouteDefaultBackendRedirect redirects requests to another URL.
+	RouteDefaultBackendRedirect RouteDefaultBackendType = "redirect"
+)
+
+// RouteDefaultBackend configures the response for requests to a route that
+// no App receives, either because no App is mapped to the route or because
+// the mapped Apps only receive requests that match their headers and
+// cookies.
+type RouteDefaultBackend struct {
+	// Type is the kind of response served.
+	Type RouteDefaultBackendType `json:"type"`
+
+	// RedirectURL is the absolute URL requests are redirected to by the
+	// redirect type. The request's scheme is kept, only the host and path of
+	// the URL are used.
+	// +optional
+	RedirectURL string `json:"redirectURL,omitempty"`
+}
+
+// LiveRouteReferences returns the OwnerReferences that point to one of the
+// Routes. Objects shared by Routes, like VirtualServices, are orphaned once
+// none of their references are live.
+func LiveRouteReferences(refs []metav1.OwnerReference, routes []*Route) []metav1.OwnerReference {
+	uids := make(map[types.UID]bool)
+	for _, route := range routes {
+		uids[route.UID] = true
+	}
+
+	var live []metav1.OwnerReference
+	for _, ref := range refs {
+		if ref.Kind == "Route" && uids[ref.UID] {
+			live = append(live, ref)
+		}
+	}
+
+	return live
 }
@@ -14,7 +14,12 @@
 
 package v1alpha1
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func ExampleRouteSpecFields_String() {
 	r := RouteSpecFields{
@@ -49,3 +54,105 @@ func ExampleRouteSpecFields_String_without_path() {
 
 	// Output: foo.example.com/
 }
+
+func ExampleRouteSpecFields_String_with_port() {
+	r := RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   1234,
+	}
+
+	fmt.Println(r.String())
+
+	// Output: tcp.example.com:1234
+}
+
+func ExampleRouteSpecFields_SharesHost() {
+	r := RouteSpecFields{Hostname: "foo", Domain: "example.com", Path: "/bar"}
+
+	fmt.Println("Other path:", r.SharesHost(RouteSpecFields{Hostname: "foo", Domain: "example.com", Path: "/baz"}))
+	fmt.Println("Other hostname:", r.SharesHost(RouteSpecFields{Hostname: "bar", Domain: "example.com", Path: "/bar"}))
+	fmt.Println("Same port:", RouteSpecFields{Domain: "tcp.example.com", Port: 1234}.SharesHost(RouteSpecFields{Domain: "tcp2.example.com", Port: 1234}))
+
+	// Output: Other path: true
+	// Other hostname: false
+	// Same port: true
+}
+
+func ExampleClaimingSpace() {
+	older := metav1.NewTime(time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+	claim := func(space string, created metav1.Time, hostname string) *RouteClaim {
+		return &RouteClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: space, CreationTimestamp: created},
+			Spec: RouteClaimSpec{
+				RouteSpecFields: RouteSpecFields{Hostname: hostname, Domain: "example.com"},
+			},
+		}
+	}
+
+	route := RouteSpecFields{Hostname: "foo", Domain: "example.com"}
+	claims := []*RouteClaim{
+		claim("space-c", newer, "foo"),
+		claim("space-b", older, "foo"),
+		claim("space-a", older, "bar"),
+	}
+
+	fmt.Println("Claimed by:", ClaimingSpace(route, claims))
+	fmt.Println("Unclaimed:", ClaimingSpace(RouteSpecFields{Hostname: "baz", Domain: "example.com"}, claims) == "")
+
+	// Output: Claimed by: space-b
+	// Unclaimed: true
+}
+
+func ExamplePortConflict() {
+	older := metav1.NewTime(time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+	claim := func(space string, created metav1.Time, domain string, port int32) *RouteClaim {
+		return &RouteClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: space, CreationTimestamp: created},
+			Spec: RouteClaimSpec{
+				RouteSpecFields: RouteSpecFields{Domain: domain, Port: port},
+			},
+		}
+	}
+
+	claims := []*RouteClaim{
+		claim("space-a", older, "tcp.example.com", 1234),
+		claim("space-a", newer, "other-tcp.example.com", 1234),
+		claim("space-b", newer, "tcp.example.com", 5678),
+	}
+
+	owner := PortConflict("space-a", RouteSpecFields{Domain: "other-tcp.example.com", Port: 1234}, claims)
+	fmt.Println("Other domain:", owner.Spec.Domain)
+
+	owner = PortConflict("space-c", RouteSpecFields{Domain: "tcp.example.com", Port: 5678}, claims)
+	fmt.Println("Other space:", owner.Namespace)
+
+	fmt.Println("Own port:", PortConflict("space-a", RouteSpecFields{Domain: "tcp.example.com", Port: 1234}, claims) == nil)
+	fmt.Println("Free port:", PortConflict("space-a", RouteSpecFields{Domain: "tcp.example.com", Port: 4321}, claims) == nil)
+
+	// Output: Other domain: tcp.example.com
+	// Other space: space-b
+	// Own port: true
+	// Free port: true
+}
+
+func ExampleLiveRouteReferences() {
+	route := &Route{}
+	route.UID = "some-uid"
+
+	refs := []metav1.OwnerReference{
+		{Kind: "Route", Name: "live", UID: "some-uid"},
+		{Kind: "Route", Name: "deleted", UID: "other-uid"},
+		{Kind: "App", Name: "not-a-route", UID: "some-uid"},
+	}
+
+	for _, ref := range LiveRouteReferences(refs, []*Route{route}) {
+		fmt.Println("Live:", ref.Name)
+	}
+
+	fmt.Println("Orphaned:", len(LiveRouteReferences(refs, nil)) == 0)
+
+	// Output: Live: live
+	// Orphaned: true
+}
@@ -17,14 +17,23 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
+	"strings"
 
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
 const (
 	KfNamespace = "kf"
+
+	// MinTCPRoutePort and MaxTCPRoutePort bound the ports TCP routes can
+	// reserve. Ports below MinTCPRoutePort are left to the cluster.
+	MinTCPRoutePort = 1024
+	MaxTCPRoutePort = 65535
 )
 
 // Validate makes sure that Route is properly configured.
@@ -46,12 +55,17 @@ func (r *Route) Validate(ctx context.Context) (errs *apis.FieldError) {
 		return errs
 	}
 
+	errs = errs.Also(r.validateDomain(ctx))
+	if errs.Error() != "" {
+		return errs
+	}
+
 	// XXX: We probably shouldn't be fetching VirtualServices in a webhook,
 	// however we need to ensure the resulting VirtualService doesn't
 	// conflict.
 	vs, err := IstioClientFromContext(ctx).
 		VirtualServices(KfNamespace).
-		Get(GenerateName(r.Spec.Hostname, r.Spec.Domain), metav1.GetOptions{})
+		Get(GenerateVirtualServiceName(r.Spec.RouteSpecFields), metav1.GetOptions{})
 
 	if apierrs.IsNotFound(err) {
 		vs = nil
@@ -70,9 +84,147 @@ func (r *Route) Validate(ctx context.Context) (errs *apis.FieldError) {
 		})
 	}
 
+	// VirtualServices are named after the host and domain, so TCP ports
+	// shared across domains are checked against the claims instead.
+	if r.Spec.Port != 0 {
+		claims, listErr := listRouteClaims(ctx)
+		if listErr != nil {
+			return errs.Also(listErr)
+		}
+
+		errs = errs.Also(validatePortConflict(r.GetNamespace(), r.Spec.RouteSpecFields, claims))
+	}
+
 	return errs
 }
 
+// validateDomain checks the route against the cluster Domain it's on, if
+// there is one. Domains only listed on the Space aren't checked here.
+func (r *Route) validateDomain(ctx context.Context) (errs *apis.FieldError) {
+	client := DomainClientFromContext(ctx)
+	if client == nil {
+		return nil
+	}
+
+	domain, err := client.Get(r.Spec.Domain, metav1.GetOptions{})
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil
+	case err != nil:
+		return errs.Also(&apis.FieldError{
+			Message: "failed to validate domain",
+			Details: fmt.Sprintf("failed to fetch Domain: %s", err),
+		})
+	}
+
+	if !domain.AvailableTo(r.GetNamespace()) {
+		errs = errs.Also(&apis.FieldError{
+			Message: "domain unavailable",
+			Paths:   []string{"spec.domain"},
+			Details: fmt.Sprintf("The domain %s is private to another space.", domain.Name),
+		})
+	}
+
+	switch {
+	case domain.Spec.TCP && r.Spec.Port == 0:
+		errs = errs.Also(&apis.FieldError{
+			Message: "missing port",
+			Paths:   []string{"spec.port"},
+			Details: fmt.Sprintf("Routes on the TCP domain %s need a port.", domain.Name),
+		})
+	case !domain.Spec.TCP && r.Spec.Port != 0:
+		errs = errs.Also(&apis.FieldError{
+			Message: "unexpected port",
+			Paths:   []string{"spec.port"},
+			Details: fmt.Sprintf("The domain %s isn't a TCP domain.", domain.Name),
+		})
+	}
+
+	return errs
+}
+
+// Validate makes sure that RouteClaim is properly configured and that its
+// host isn't already claimed by another space.
+func (r *RouteClaim) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
+	if r.Spec.Domain == "" {
+		errs = errs.Also(apis.ErrMissingField("spec.domain"))
+	}
+
+	errs = errs.Also(r.Spec.ValidatePort(ctx).ViaField("spec"))
+
+	if r.Spec.Port != 0 && r.Spec.TrafficPolicy != nil {
+		errs = errs.Also(&apis.FieldError{
+			Message: "unexpected traffic policy",
+			Paths:   []string{"spec.trafficPolicy"},
+			Details: "Traffic policies can only be used with HTTP routes.",
+		})
+	}
+	errs = errs.Also(r.Spec.TrafficPolicy.Validate(ctx).ViaField("spec.trafficPolicy"))
+
+	// Only new claims are checked, existing ones already hold their host.
+	if errs.Error() != "" || apis.GetBaseline(ctx) != nil {
+		return errs
+	}
+
+	claims, listErr := listRouteClaims(ctx)
+	if listErr != nil {
+		return errs.Also(listErr)
+	}
+
+	if space := ClaimingSpace(r.Spec.RouteSpecFields, claims); space != "" && space != r.GetNamespace() {
+		errs = errs.Also(&apis.FieldError{
+			Message: "route conflicts with another space",
+			Paths:   []string{"namespace"},
+			Details: fmt.Sprintf("The route is already claimed by space %q.", space),
+		})
+	}
+
+	return errs.Also(validatePortConflict(r.GetNamespace(), r.Spec.RouteSpecFields, claims))
+}
+
+// listRouteClaims returns every RouteClaim in the cluster, or nil if the
+// context has no RouteClaimLister.
+func listRouteClaims(ctx context.Context) ([]*RouteClaim, *apis.FieldError) {
+	lister := RouteClaimListerFromContext(ctx)
+	if lister == nil {
+		return nil, nil
+	}
+
+	list, err := lister.List(metav1.ListOptions{})
+	if err != nil {
+		return nil, &apis.FieldError{
+			Message: "failed to validate hostname + domain collisions",
+			Details: fmt.Sprintf("failed to fetch RouteClaims: %s", err),
+		}
+	}
+
+	var claims []*RouteClaim
+	for i := range list.Items {
+		claims = append(claims, &list.Items[i])
+	}
+
+	return claims, nil
+}
+
+// validatePortConflict rejects TCP routes whose port is already reserved on
+// the shared TCP gateway for another domain or by another space.
+func validatePortConflict(namespace string, route RouteSpecFields, claims []*RouteClaim) *apis.FieldError {
+	owner := PortConflict(namespace, route, claims)
+	if owner == nil {
+		return nil
+	}
+
+	return &apis.FieldError{
+		Message: "port conflicts with another route",
+		Paths:   []string{"spec.port"},
+		Details: fmt.Sprintf("Port %d is already reserved for domain %s by space %q.", route.Port, owner.Spec.Domain, owner.Namespace),
+	}
+}
+
 // Validate makes sure that RouteSpec is properly configured.
 func (r *RouteSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if r.AppName == "" {
@@ -87,5 +239,180 @@ func (r *RouteSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrInvalidValue("hostname", r.Hostname))
 	}
 
+	errs = errs.Also(r.RouteSpecFields.ValidateWeight(ctx))
+	errs = errs.Also(r.RouteSpecFields.ValidatePort(ctx))
+	errs = errs.Also(r.RouteSpecFields.ValidateMatch(ctx))
+
+	return errs
+}
+
+// ValidateWeight makes sure that the weight, if set, is between 0 and 100.
+func (r *RouteSpecFields) ValidateWeight(ctx context.Context) (errs *apis.FieldError) {
+	if r.Weight != nil && (*r.Weight < 0 || *r.Weight > 100) {
+		errs = errs.Also(apis.ErrInvalidValue(*r.Weight, "weight"))
+	}
+
+	return errs
+}
+
+// ValidatePort makes sure that the port, if set, can be reserved and that
+// the route doesn't also have a hostname or path.
+func (r *RouteSpecFields) ValidatePort(ctx context.Context) (errs *apis.FieldError) {
+	if r.Port == 0 {
+		return errs
+	}
+
+	if r.Port < MinTCPRoutePort || r.Port > MaxTCPRoutePort {
+		errs = errs.Also(apis.ErrInvalidValue(r.Port, "port"))
+	}
+
+	if r.Hostname != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("hostname"))
+	}
+
+	if path.Join("/", r.Path) != "/" {
+		errs = errs.Also(apis.ErrDisallowedFields("path"))
+	}
+
+	return errs
+}
+
+// ValidateMatch makes sure that the match, if set, selects requests by valid
+// header and cookie names and that the route is an HTTP route.
+func (r *RouteSpecFields) ValidateMatch(ctx context.Context) (errs *apis.FieldError) {
+	if r.Match == nil {
+		return errs
+	}
+
+	if r.Port != 0 {
+		return errs.Also(apis.ErrDisallowedFields("match"))
+	}
+
+	return errs.Also(r.Match.Validate(ctx).ViaField("match"))
+}
+
+// Validate checks the names of the headers and cookies. Cookies are matched
+// through the Cookie header so only one can be matched and the header can't
+// be matched directly next to it.
+func (m *RouteMatch) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(m.Headers) == 0 && len(m.Cookies) == 0 {
+		return errs.Also(apis.ErrMissingOneOf("headers", "cookies"))
+	}
+
+	for name := range m.Headers {
+		switch {
+		case len(validation.IsHTTPHeaderName(name)) > 0:
+			errs = errs.Also(apis.ErrInvalidValue(name, "headers"))
+		case len(m.Cookies) > 0 && strings.EqualFold(name, "cookie"):
+			errs = errs.Also(apis.ErrMultipleOneOf("headers.cookie", "cookies"))
+		}
+	}
+
+	if len(m.Cookies) > 1 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "too many cookies",
+			Paths:   []string{"cookies"},
+			Details: "Only one cookie can be matched.",
+		})
+	}
+
+	for name, value := range m.Cookies {
+		if len(validation.IsHTTPHeaderName(name)) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(name, "cookies"))
+		}
+
+		if strings.ContainsAny(value, "; ") {
+			errs = errs.Also(apis.ErrInvalidValue(value, "cookies."+name))
+		}
+	}
+
+	return errs
+}
+
+// Validate checks that the timeouts and retries of the RouteTrafficPolicy
+// are usable and that its headers are valid.
+func (policy *RouteTrafficPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if policy == nil {
+		return nil
+	}
+
+	if policy.TimeoutSeconds != nil && *policy.TimeoutSeconds <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*policy.TimeoutSeconds, "timeoutSeconds"))
+	}
+
+	if policy.Retries != nil && *policy.Retries < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*policy.Retries, "retries"))
+	}
+
+	if policy.PerTryTimeoutSeconds != nil && *policy.PerTryTimeoutSeconds <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*policy.PerTryTimeoutSeconds, "perTryTimeoutSeconds"))
+	}
+
+	if cors := policy.CORS; cors != nil {
+		if len(cors.AllowOrigins) == 0 {
+			errs = errs.Also(apis.ErrMissingField("cors.allowOrigins"))
+		}
+
+		if cors.MaxAgeSeconds != nil && *cors.MaxAgeSeconds < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*cors.MaxAgeSeconds, "cors.maxAgeSeconds"))
+		}
+	}
+
+	errs = errs.Also(policy.RequestHeaders.Validate(ctx).ViaField("requestHeaders"))
+	errs = errs.Also(policy.ResponseHeaders.Validate(ctx).ViaField("responseHeaders"))
+
+	return errs
+}
+
+// Validate checks that the names of the headers are valid.
+func (ops *RouteHeaderOperations) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ops == nil {
+		return nil
+	}
+
+	for name := range ops.Add {
+		if len(validation.IsHTTPHeaderName(name)) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(name, "add"))
+		}
+	}
+
+	for i, name := range ops.Remove {
+		if len(validation.IsHTTPHeaderName(name)) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(name, fmt.Sprintf("remove[%d]", i)))
+		}
+	}
+
+	return errs
+}
+
+// Validate checks that the type of the RouteDefaultBackend is known and that
+// only redirects have a URL.
+func (backend *RouteDefaultBackend) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if backend == nil {
+		return nil
+	}
+
+	switch backend.Type {
+	case RouteDefaultBackendUnavailable, RouteDefaultBackendPage:
+		if backend.RedirectURL != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("redirectURL"))
+		}
+
+	case RouteDefaultBackendRedirect:
+		if backend.RedirectURL == "" {
+			errs = errs.Also(apis.ErrMissingField("redirectURL"))
+		} else if u, err := url.Parse(backend.RedirectURL); err != nil ||
+			(u.Scheme != "http" && u.Scheme != "https") ||
+			u.Host == "" {
+			errs = errs.Also(apis.ErrInvalidValue(backend.RedirectURL, "redirectURL"))
+		}
+
+	case "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(backend.Type, "type"))
+	}
+
 	return errs
 }
@@ -112,6 +112,58 @@ func TestRouteValidation(t *testing.T) {
 				Paths:   []string{"spec.www"},
 			},
 		},
+		"invalid weight": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppName: "some-app",
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+						Weight: intPtr(200),
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(200, "spec.weight"),
+		},
+		"valid port": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppName: "some-app",
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1234,
+					},
+				},
+			},
+		},
+		"invalid port": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppName: "some-app",
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   70000,
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(70000, "spec.port"),
+		},
+		"port with hostname": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppName: "some-app",
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "foo",
+						Domain:   "tcp.example.com",
+						Port:     1234,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.hostname"),
+		},
 		"fetching VirtualServices returns an error": {
 			setup: func(t *testing.T, fake *fake.FakeNetworkingV1alpha3) {
 				fake.AddReactor("get", "virtualservices", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
@@ -163,6 +215,35 @@ func TestRouteValidation(t *testing.T) {
 				Details: fmt.Sprintf("The route is invalid: Routes for this host and domain have been reserved for another space."),
 			},
 		},
+		"TCP port reserved by another space": {
+			setupContext: func(ctx context.Context) context.Context {
+				return SetupRouteClaimLister(ctx, fakeRouteClaimLister(func() (*RouteClaimList, error) {
+					return &RouteClaimList{Items: []RouteClaim{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-space"},
+							Spec: RouteClaimSpec{
+								RouteSpecFields: RouteSpecFields{Domain: "tcp2.example.com", Port: 2000},
+							},
+						},
+					}}, nil
+				}))
+			},
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppName: "some-app",
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   2000,
+					},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "port conflicts with another route",
+				Paths:   []string{"spec.port"},
+				Details: `Port 2000 is already reserved for domain tcp2.example.com by space "other-space".`,
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -186,15 +267,12 @@ func TestRouteValidation(t *testing.T) {
 				}
 			}
 
-			ctx := context.Background()
-			if tc.setupContext == nil {
-				tc.setupContext = func(ctx context.Context) context.Context {
-					return SetupIstioClient(ctx, f)
-				}
+			ctx := SetupIstioClient(context.Background(), f)
+			if tc.setupContext != nil {
+				ctx = tc.setupContext(ctx)
 			}
 
 			tc.setup(t, f)
-			ctx = tc.setupContext(ctx)
 
 			got := tc.route.Validate(ctx)
 
@@ -202,3 +280,312 @@ func TestRouteValidation(t *testing.T) {
 		})
 	}
 }
+
+type fakeDomainGetter func(name string) (*Domain, error)
+
+func (f fakeDomainGetter) Get(name string, options metav1.GetOptions) (*Domain, error) {
+	return f(name)
+}
+
+func TestRouteValidation_domains(t *testing.T) {
+	goodObjMeta := metav1.ObjectMeta{
+		Name:      "valid",
+		Namespace: "valid",
+	}
+	httpSpec := RouteSpec{
+		AppName: "some-app",
+		RouteSpecFields: RouteSpecFields{
+			Hostname: "some-host",
+			Domain:   "example.com",
+		},
+	}
+	tcpSpec := RouteSpec{
+		AppName: "some-app",
+		RouteSpecFields: RouteSpecFields{
+			Domain: "example.com",
+			Port:   1234,
+		},
+	}
+
+	cases := map[string]struct {
+		spec   RouteSpec
+		domain *Domain
+		err    error
+		want   *apis.FieldError
+	}{
+		"domain not found": {
+			spec: httpSpec,
+			err:  apierrs.NewNotFound(schema.GroupResource{}, "example.com"),
+		},
+		"fetching domain returns an error": {
+			spec: httpSpec,
+			err:  errors.New("some-error"),
+			want: &apis.FieldError{
+				Message: "failed to validate domain",
+				Details: "failed to fetch Domain: some-error",
+			},
+		},
+		"shared domain": {
+			spec:   httpSpec,
+			domain: &Domain{},
+		},
+		"domain private to the route's space": {
+			spec:   httpSpec,
+			domain: &Domain{Spec: DomainSpec{Space: "valid"}},
+		},
+		"domain private to another space": {
+			spec:   httpSpec,
+			domain: &Domain{Spec: DomainSpec{Space: "other"}},
+			want: &apis.FieldError{
+				Message: "domain unavailable",
+				Paths:   []string{"spec.domain"},
+				Details: "The domain example.com is private to another space.",
+			},
+		},
+		"TCP domain with port": {
+			spec:   tcpSpec,
+			domain: &Domain{Spec: DomainSpec{TCP: true}},
+		},
+		"TCP domain without port": {
+			spec:   httpSpec,
+			domain: &Domain{Spec: DomainSpec{TCP: true}},
+			want: &apis.FieldError{
+				Message: "missing port",
+				Paths:   []string{"spec.port"},
+				Details: "Routes on the TCP domain example.com need a port.",
+			},
+		},
+		"HTTP domain with port": {
+			spec:   tcpSpec,
+			domain: &Domain{},
+			want: &apis.FieldError{
+				Message: "unexpected port",
+				Paths:   []string{"spec.port"},
+				Details: "The domain example.com isn't a TCP domain.",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			f := &fake.FakeNetworkingV1alpha3{
+				Fake: &ktesting.Fake{},
+			}
+			f.AddReactor("get", "virtualservices", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+				return true, nil, apierrs.NewNotFound(schema.GroupResource{}, "some-name")
+			})
+
+			ctx := SetupIstioClient(context.Background(), f)
+			ctx = SetupDomainClient(ctx, fakeDomainGetter(func(name string) (*Domain, error) {
+				testutil.AssertEqual(t, "domain name", "example.com", name)
+				if tc.domain != nil {
+					tc.domain.Name = name
+				}
+				return tc.domain, tc.err
+			}))
+
+			route := &Route{ObjectMeta: goodObjMeta, Spec: tc.spec}
+			got := route.Validate(ctx)
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+type fakeRouteClaimLister func() (*RouteClaimList, error)
+
+func (f fakeRouteClaimLister) List(opts metav1.ListOptions) (*RouteClaimList, error) {
+	return f()
+}
+
+func TestRouteClaimValidation(t *testing.T) {
+	goodObjMeta := metav1.ObjectMeta{
+		Name:      "valid",
+		Namespace: "valid",
+	}
+	goodSpec := RouteClaimSpec{
+		RouteSpecFields: RouteSpecFields{
+			Hostname: "some-host",
+			Domain:   "example.com",
+		},
+	}
+	otherSpaceClaim := RouteClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: "other-space",
+		},
+		Spec: goodSpec,
+	}
+	zero := int32(0)
+	negative := int32(-1)
+
+	cases := map[string]struct {
+		claim        *RouteClaim
+		claims       []RouteClaim
+		listErr      error
+		noLister     bool
+		setupContext func(ctx context.Context) context.Context
+		want         *apis.FieldError
+	}{
+		"good": {
+			claim: &RouteClaim{ObjectMeta: goodObjMeta, Spec: goodSpec},
+		},
+		"missing name and domain": {
+			claim: &RouteClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "valid"},
+			},
+			want: apis.ErrMissingField("name").Also(apis.ErrMissingField("spec.domain")),
+		},
+		"claimed by the same space": {
+			claim: &RouteClaim{ObjectMeta: goodObjMeta, Spec: goodSpec},
+			claims: []RouteClaim{
+				{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "valid"}, Spec: goodSpec},
+			},
+		},
+		"claimed by another space": {
+			claim:  &RouteClaim{ObjectMeta: goodObjMeta, Spec: goodSpec},
+			claims: []RouteClaim{otherSpaceClaim},
+			want: &apis.FieldError{
+				Message: "route conflicts with another space",
+				Paths:   []string{"namespace"},
+				Details: `The route is already claimed by space "other-space".`,
+			},
+		},
+		"different hostname in another space": {
+			claim: &RouteClaim{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteClaimSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "other-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			claims: []RouteClaim{otherSpaceClaim},
+		},
+		"updates aren't checked": {
+			claim:  &RouteClaim{ObjectMeta: goodObjMeta, Spec: goodSpec},
+			claims: []RouteClaim{otherSpaceClaim},
+			setupContext: func(ctx context.Context) context.Context {
+				return apis.WithinUpdate(ctx, &RouteClaim{ObjectMeta: goodObjMeta, Spec: goodSpec})
+			},
+		},
+		"no lister": {
+			claim:    &RouteClaim{ObjectMeta: goodObjMeta, Spec: goodSpec},
+			noLister: true,
+		},
+		"traffic policy on TCP route": {
+			claim: &RouteClaim{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteClaimSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   MinTCPRoutePort,
+					},
+					TrafficPolicy: &RouteTrafficPolicy{},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "unexpected traffic policy",
+				Paths:   []string{"spec.trafficPolicy"},
+				Details: "Traffic policies can only be used with HTTP routes.",
+			},
+		},
+		"invalid traffic policy": {
+			claim: &RouteClaim{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteClaimSpec{
+					RouteSpecFields: goodSpec.RouteSpecFields,
+					TrafficPolicy: &RouteTrafficPolicy{
+						TimeoutSeconds: &zero,
+						Retries:        &negative,
+						CORS:           &RouteCORSPolicy{},
+						RequestHeaders: &RouteHeaderOperations{
+							Remove: []string{"x-good", "bad header"},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(0, "spec.trafficPolicy.timeoutSeconds").
+				Also(apis.ErrInvalidValue(-1, "spec.trafficPolicy.retries")).
+				Also(apis.ErrMissingField("spec.trafficPolicy.cors.allowOrigins")).
+				Also(apis.ErrInvalidValue("bad header", "spec.trafficPolicy.requestHeaders.remove[1]")),
+		},
+		"port reserved for another domain": {
+			claim: &RouteClaim{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteClaimSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   MinTCPRoutePort,
+					},
+				},
+			},
+			claims: []RouteClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "valid"},
+					Spec: RouteClaimSpec{
+						RouteSpecFields: RouteSpecFields{
+							Domain: "tcp2.example.com",
+							Port:   MinTCPRoutePort,
+						},
+					},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "port conflicts with another route",
+				Paths:   []string{"spec.port"},
+				Details: `Port 1024 is already reserved for domain tcp2.example.com by space "valid".`,
+			},
+		},
+		"port reserved by the same space and domain": {
+			claim: &RouteClaim{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteClaimSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   MinTCPRoutePort,
+					},
+				},
+			},
+			claims: []RouteClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "valid"},
+					Spec: RouteClaimSpec{
+						RouteSpecFields: RouteSpecFields{
+							Domain: "tcp.example.com",
+							Port:   MinTCPRoutePort,
+						},
+					},
+				},
+			},
+		},
+		"listing fails": {
+			claim:   &RouteClaim{ObjectMeta: goodObjMeta, Spec: goodSpec},
+			listErr: errors.New("some-error"),
+			want: &apis.FieldError{
+				Message: "failed to validate hostname + domain collisions",
+				Details: "failed to fetch RouteClaims: some-error",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := context.Background()
+			if !tc.noLister {
+				ctx = SetupRouteClaimLister(ctx, fakeRouteClaimLister(func() (*RouteClaimList, error) {
+					return &RouteClaimList{Items: tc.claims}, tc.listErr
+				}))
+			}
+
+			if tc.setupContext != nil {
+				ctx = tc.setupContext(ctx)
+			}
+
+			got := tc.claim.Validate(ctx)
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
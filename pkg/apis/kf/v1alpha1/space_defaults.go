@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/google/kf/pkg/kf/algorithms"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // TODO(#396): We should pull these from a ConfigMap
@@ -29,6 +30,10 @@ const (
 	// DefaultDomainTemplate contains the default domain template. It should
 	// be used with `fmt.Sprintf(DefaultDomainTemplate, namespace)`
 	DefaultDomainTemplate = "%s.kf.cluster.local"
+
+	// DefaultEgressPolicy is the egress policy for traffic that doesn't match
+	// any rules in new spaces.
+	DefaultEgressPolicy = EgressPolicyAllow
 )
 
 // SetDefaults implements apis.Defaultable
@@ -42,6 +47,7 @@ func (k *SpaceSpec) SetDefaults(ctx context.Context, name string) {
 	k.BuildpackBuild.SetDefaults(ctx)
 	k.Execution.SetDefaults(ctx, name)
 	k.ResourceLimits.SetDefaults(ctx)
+	k.Network.SetDefaults(ctx)
 }
 
 // SetDefaults implements apis.Defaultable
@@ -78,3 +84,18 @@ func (k *SpaceSpecExecution) SetDefaults(ctx context.Context, name string) {
 func (k *SpaceSpecResourceLimits) SetDefaults(ctx context.Context) {
 	// XXX: currently no defaults to set
 }
+
+// SetDefaults implements apis.Defaultable
+func (k *SpaceSpecNetwork) SetDefaults(ctx context.Context) {
+	if k.DefaultEgressPolicy == "" {
+		k.DefaultEgressPolicy = DefaultEgressPolicy
+	}
+
+	for i := range k.Egress {
+		for j := range k.Egress[i].Ports {
+			if k.Egress[i].Ports[j].Protocol == "" {
+				k.Egress[i].Ports[j].Protocol = corev1.ProtocolTCP
+			}
+		}
+	}
+}
@@ -78,3 +78,19 @@ func ExampleSpaceSpecSecurity_SetDefaults_dedupe() {
 
 	// Output: EnableDeveloperLogsAccess: true
 }
+
+func ExampleSpaceSpecNetwork_SetDefaults() {
+	space := Space{}
+	space.Spec.Network = SpaceSpecNetwork{
+		Egress: []SpaceEgressRule{
+			{CIDR: "10.0.0.0/8", Policy: EgressPolicyAllow, Ports: []SpaceEgressPort{{Port: 443}}},
+		},
+	}
+	space.SetDefaults(context.Background())
+
+	fmt.Println("Default egress policy:", space.Spec.Network.DefaultEgressPolicy)
+	fmt.Println("Port protocol:", space.Spec.Network.Egress[0].Ports[0].Protocol)
+
+	// Output: Default egress policy: Allow
+	// Port protocol: TCP
+}
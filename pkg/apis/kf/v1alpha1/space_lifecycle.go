@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
@@ -48,6 +49,9 @@ const (
 	// SpaceConditionLimitRangeReady is set when the limit range is
 	// ready.
 	SpaceConditionLimitRangeReady apis.ConditionType = "LimitRangeReady"
+	// SpaceConditionNetworkPolicyReady is set when the egress network policy
+	// is ready.
+	SpaceConditionNetworkPolicyReady apis.ConditionType = "NetworkPolicyReady"
 )
 
 func (status *SpaceStatus) manage() apis.ConditionManager {
@@ -57,6 +61,7 @@ func (status *SpaceStatus) manage() apis.ConditionManager {
 		SpaceConditionAuditorRoleReady,
 		SpaceConditionResourceQuotaReady,
 		SpaceConditionLimitRangeReady,
+		SpaceConditionNetworkPolicyReady,
 	).Manage(status)
 }
 
@@ -105,6 +110,12 @@ func (status *SpaceStatus) MarkLimitRangeNotOwned(name string) {
 		fmt.Sprintf("There is an existing limitrange %q that we do not own.", name))
 }
 
+// MarkNetworkPolicyNotOwned marks the NetworkPolicy as not being owned by the Space.
+func (status *SpaceStatus) MarkNetworkPolicyNotOwned(name string) {
+	status.manage().MarkFalse(SpaceConditionNetworkPolicyReady, "NotOwned",
+		fmt.Sprintf("There is an existing networkpolicy %q that we do not own.", name))
+}
+
 // PropagateNamespaceStatus copies fields from the Namespace status to Space
 // and updates the readiness based on the current phase.
 func (status *SpaceStatus) PropagateNamespaceStatus(ns *v1.Namespace) {
@@ -147,6 +158,14 @@ func (status *SpaceStatus) PropagateLimitRangeStatus(limitRange *v1.LimitRange)
 	status.manage().MarkTrue(SpaceConditionLimitRangeReady)
 }
 
+// PropagateNetworkPolicyStatus updates the readiness of the space
+// based on if a NetworkPolicy exists.
+func (status *SpaceStatus) PropagateNetworkPolicyStatus(*networkingv1.NetworkPolicy) {
+	// NetworkPolicies don't have a status field so they just need to exist to
+	// be ready.
+	status.manage().MarkTrue(SpaceConditionNetworkPolicyReady)
+}
+
 func (status *SpaceStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
@@ -170,6 +170,7 @@ func initTestStatus(t *testing.T) *SpaceStatus {
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionDeveloperRoleReady, t)
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionResourceQuotaReady, t)
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionLimitRangeReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionNetworkPolicyReady, t)
 
 	return status
 }
@@ -184,6 +185,7 @@ func TestSpaceHappyPath(t *testing.T) {
 		Status: corev1.ResourceQuotaStatus{},
 	})
 	status.PropagateLimitRangeStatus(nil)
+	status.PropagateNetworkPolicyStatus(nil)
 
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionNamespaceReady, t)
@@ -191,6 +193,7 @@ func TestSpaceHappyPath(t *testing.T) {
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionDeveloperRoleReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionResourceQuotaReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionLimitRangeReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionNetworkPolicyReady, t)
 }
 
 func TestPropagateNamespaceStatus_terminating(t *testing.T) {
@@ -248,6 +251,7 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 					Status: corev1.ResourceQuotaStatus{},
 				})
 				status.PropagateLimitRangeStatus(nil)
+				status.PropagateNetworkPolicyStatus(nil)
 			},
 			ExpectSucceeded: []apis.ConditionType{
 				SpaceConditionReady,
@@ -256,6 +260,7 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionDeveloperRoleReady,
 				SpaceConditionResourceQuotaReady,
 				SpaceConditionLimitRangeReady,
+				SpaceConditionNetworkPolicyReady,
 			},
 		},
 		"terminating namespace": {
@@ -345,6 +350,18 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionLimitRangeReady,
 			},
 		},
+		"network policy not owned": {
+			Init: func(status *SpaceStatus) {
+				status.MarkNetworkPolicyNotOwned("space-egress")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionNetworkPolicyReady,
+			},
+		},
 	}
 
 	// XXX: if we start copying state from subresources back to the parent,
@@ -57,6 +57,10 @@ type SpaceSpec struct {
 	// SpaceSpecResourceLimits contains definitions for resource usage limits.
 	// +optional
 	ResourceLimits SpaceSpecResourceLimits `json:"resourceLimits,omitempty"`
+
+	// Network contains the egress rules for Apps in the space.
+	// +optional
+	Network SpaceSpecNetwork `json:"network,omitempty"`
 }
 
 // SpaceSpecSecurity holds fields for creating RBAC in the space.
@@ -106,6 +110,47 @@ type SpaceSpecExecution struct {
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	Domains []SpaceDomain `json:"domains,omitempty" patchStrategy:"merge" patchMergeKey:"domain"`
+
+	// DefaultBackend sets the response for requests to the space's routes
+	// that no App receives. If unset, the cluster-wide setting from the
+	// config-defaults ConfigMap is used.
+	// +optional
+	DefaultBackend *RouteDefaultBackend `json:"defaultBackend,omitempty"`
+}
+
+// IsInternalDomain returns true if the domain is one of the space's internal
+// domains.
+func (k *SpaceSpecExecution) IsInternalDomain(domain string) bool {
+	for _, d := range k.Domains {
+		if d.Domain == domain {
+			return d.Internal
+		}
+	}
+
+	return false
+}
+
+// IsTCPDomain returns true if the domain is one of the space's TCP domains.
+func (k *SpaceSpecExecution) IsTCPDomain(domain string) bool {
+	for _, d := range k.Domains {
+		if d.Domain == domain {
+			return d.TCP
+		}
+	}
+
+	return false
+}
+
+// LookupDomain returns the space's settings for the domain and whether the
+// domain is one of the space's domains.
+func (k *SpaceSpecExecution) LookupDomain(domain string) (SpaceDomain, bool) {
+	for _, d := range k.Domains {
+		if d.Domain == domain {
+			return d, true
+		}
+	}
+
+	return SpaceDomain{Domain: domain}, false
 }
 
 // SpaceSpecResourceLimits contains definitions for resource usage limits.
@@ -122,6 +167,57 @@ type SpaceSpecResourceLimits struct {
 	ResourceDefaults []corev1.LimitRangeItem `json:"resourceDefaults,omitempty"`
 }
 
+// EgressPolicy is the action taken on outbound traffic.
+type EgressPolicy string
+
+const (
+	// EgressPolicyAllow allows matching traffic to leave the space.
+	EgressPolicyAllow EgressPolicy = "Allow"
+	// EgressPolicyDeny blocks matching traffic from leaving the space.
+	EgressPolicyDeny EgressPolicy = "Deny"
+)
+
+// SpaceSpecNetwork contains the egress rules for a space, similar to Cloud
+// Foundry's application security groups. The rules only govern traffic leaving
+// the cluster, DNS and traffic between Pods in the cluster is always allowed.
+type SpaceSpecNetwork struct {
+	// DefaultEgressPolicy is applied to traffic that doesn't match any of the
+	// Egress rules.
+	// +optional
+	DefaultEgressPolicy EgressPolicy `json:"defaultEgressPolicy,omitempty"`
+
+	// Egress holds rules for outbound traffic. Deny rules take precedence
+	// over Allow rules.
+	// +optional
+	// +patchMergeKey=cidr
+	// +patchStrategy=merge
+	Egress []SpaceEgressRule `json:"egress,omitempty" patchStrategy:"merge" patchMergeKey:"cidr"`
+}
+
+// SpaceEgressRule allows or denies outbound traffic to a range of IPs.
+type SpaceEgressRule struct {
+	// CIDR is the range of destination IPs the rule matches.
+	CIDR string `json:"cidr"`
+
+	// Policy is the action taken on matching traffic.
+	Policy EgressPolicy `json:"policy"`
+
+	// Ports limits an Allow rule to the given destination ports. If empty,
+	// all ports are matched. Deny rules always match all ports.
+	// +optional
+	Ports []SpaceEgressPort `json:"ports,omitempty"`
+}
+
+// SpaceEgressPort is a destination port for an egress rule.
+type SpaceEgressPort struct {
+	// Protocol is the network protocol, defaults to TCP.
+	// +optional
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+
+	// Port is the destination port number.
+	Port int32 `json:"port"`
+}
+
 // SpaceDomain stores information about a domain available in a space.
 type SpaceDomain struct {
 	// Domain is the valid domain that can be used in conjunction with a
@@ -132,6 +228,36 @@ type SpaceDomain struct {
 	// specified. There can only be a single default set to true per space.
 	// NOTE: This may change in the future.
 	Default bool `json:"default,omitempty"`
+
+	// Internal implies that routes on this SpaceDomain are only reachable from
+	// inside the cluster, similar to Cloud Foundry's apps.internal domain.
+	// Internal domains can't be the default.
+	Internal bool `json:"internal,omitempty"`
+
+	// TCP implies that routes on this SpaceDomain carry raw TCP traffic to a
+	// port instead of HTTP traffic to a hostname and path. TCP domains can't
+	// be the default or internal.
+	TCP bool `json:"tcp,omitempty"`
+
+	// TLS configures HTTPS for routes on this SpaceDomain. TCP and internal
+	// domains can't use TLS.
+	// +optional
+	TLS *DomainTLS `json:"tls,omitempty"`
+}
+
+// DomainTLS configures the certificate used to serve HTTPS for a domain and
+// its subdomains. Exactly one of SecretName or SelfSigned must be set.
+type DomainTLS struct {
+	// SecretName is the name of a kubernetes.io/tls Secret holding the
+	// certificate. The Secret must be in the same namespace as the Istio
+	// ingress gateway, usually istio-system.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// SelfSigned generates a self-signed certificate for the domain. It's
+	// meant for development domains, clients won't trust the certificate.
+	// +optional
+	SelfSigned bool `json:"selfSigned,omitempty"`
 }
 
 // SpaceStatus represents information about the status of a Space.
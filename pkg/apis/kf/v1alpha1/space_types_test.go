@@ -0,0 +1,51 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "fmt"
+
+func ExampleSpaceSpecExecution_IsInternalDomain() {
+	execution := SpaceSpecExecution{
+		Domains: []SpaceDomain{
+			{Domain: "example.com", Default: true},
+			{Domain: "apps.internal", Internal: true},
+		},
+	}
+
+	fmt.Println("example.com:", execution.IsInternalDomain("example.com"))
+	fmt.Println("apps.internal:", execution.IsInternalDomain("apps.internal"))
+	fmt.Println("unknown.com:", execution.IsInternalDomain("unknown.com"))
+
+	// Output: example.com: false
+	// apps.internal: true
+	// unknown.com: false
+}
+
+func ExampleSpaceSpecExecution_IsTCPDomain() {
+	execution := SpaceSpecExecution{
+		Domains: []SpaceDomain{
+			{Domain: "example.com", Default: true},
+			{Domain: "tcp.example.com", TCP: true},
+		},
+	}
+
+	fmt.Println("example.com:", execution.IsTCPDomain("example.com"))
+	fmt.Println("tcp.example.com:", execution.IsTCPDomain("tcp.example.com"))
+	fmt.Println("unknown.com:", execution.IsTCPDomain("unknown.com"))
+
+	// Output: example.com: false
+	// tcp.example.com: true
+	// unknown.com: false
+}
@@ -16,7 +16,10 @@ package v1alpha1
 
 import (
 	"context"
+	"net"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -48,6 +51,7 @@ func (s *SpaceSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(s.BuildpackBuild.Validate(ctx).ViaField("buildpackBuild"))
 	errs = errs.Also(s.Execution.Validate(ctx).ViaField("execution"))
 	errs = errs.Also(s.ResourceLimits.Validate(ctx).ViaField("resourceLimits"))
+	errs = errs.Also(s.Network.Validate(ctx).ViaField("network"))
 
 	return errs
 }
@@ -73,16 +77,59 @@ func (s *SpaceSpecBuildpackBuild) Validate(ctx context.Context) (errs *apis.Fiel
 
 // Validate makes sure that SpaceSpecExecution is properly configured.
 func (s *SpaceSpecExecution) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(s.DefaultBackend.Validate(ctx).ViaField("defaultBackend"))
+
 	if len(s.Domains) == 0 {
 		return errs.Also(apis.ErrMissingField("domains"))
 	}
 
 	lastDefault := -1
 	for i, d := range s.Domains {
+		if d.TCP && d.Internal {
+			errs = errs.Also(
+				&apis.FieldError{
+					Paths:   []string{"domains"},
+					Message: "internal TCP domain",
+					Details: "TCP domains can't be internal",
+				},
+			)
+		}
+
+		if d.TLS != nil && (d.TCP || d.Internal) {
+			errs = errs.Also(
+				&apis.FieldError{
+					Paths:   []string{"domains"},
+					Message: "TLS on non-HTTP domain",
+					Details: "TLS can only be used with external HTTP domains",
+				},
+			)
+		}
+		errs = errs.Also(d.TLS.Validate(ctx).ViaField("tls").ViaFieldIndex("domains", i))
+
 		if !d.Default {
 			continue
 		}
 
+		if d.Internal {
+			errs = errs.Also(
+				&apis.FieldError{
+					Paths:   []string{"domains"},
+					Message: "internal default",
+					Details: "internal domains can't be the default",
+				},
+			)
+		}
+
+		if d.TCP {
+			errs = errs.Also(
+				&apis.FieldError{
+					Paths:   []string{"domains"},
+					Message: "TCP default",
+					Details: "TCP domains can't be the default",
+				},
+			)
+		}
+
 		if lastDefault >= 0 {
 			errs = errs.Also(
 				&apis.FieldError{
@@ -113,3 +160,64 @@ func (s *SpaceSpecResourceLimits) Validate(ctx context.Context) (errs *apis.Fiel
 	// XXX: no validation
 	return errs
 }
+
+// Validate makes sure that SpaceSpecNetwork is properly configured.
+func (s *SpaceSpecNetwork) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch s.DefaultEgressPolicy {
+	case "", EgressPolicyAllow, EgressPolicyDeny:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(s.DefaultEgressPolicy, "defaultEgressPolicy"))
+	}
+
+	cidrs := sets.NewString()
+	for i, rule := range s.Egress {
+		errs = errs.Also(rule.Validate(ctx).ViaFieldIndex("egress", i))
+
+		if cidrs.Has(rule.CIDR) {
+			errs = errs.Also((&apis.FieldError{Message: "duplicate CIDR", Paths: []string{"cidr"}}).ViaFieldIndex("egress", i))
+		}
+		cidrs.Insert(rule.CIDR)
+	}
+
+	return errs
+}
+
+// Validate makes sure that SpaceEgressRule is properly configured.
+func (r *SpaceEgressRule) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.CIDR == "" {
+		errs = errs.Also(apis.ErrMissingField("cidr"))
+	} else if _, _, err := net.ParseCIDR(r.CIDR); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(r.CIDR, "cidr"))
+	}
+
+	switch r.Policy {
+	case EgressPolicyAllow:
+	case EgressPolicyDeny:
+		// NetworkPolicies can only exclude whole IP ranges from an allow rule
+		// so there's no way to deny individual ports.
+		if len(r.Ports) > 0 {
+			errs = errs.Also(&apis.FieldError{
+				Message: "ports can't be set on Deny rules",
+				Paths:   []string{"ports"},
+			})
+		}
+	case "":
+		errs = errs.Also(apis.ErrMissingField("policy"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(r.Policy, "policy"))
+	}
+
+	for i, port := range r.Ports {
+		switch port.Protocol {
+		case "", corev1.ProtocolTCP, corev1.ProtocolUDP:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(port.Protocol, "protocol").ViaFieldIndex("ports", i))
+		}
+
+		if port.Port < 1 || port.Port > 65535 {
+			errs = errs.Also(apis.ErrInvalidValue(port.Port, "port").ViaFieldIndex("ports", i))
+		}
+	}
+
+	return errs
+}
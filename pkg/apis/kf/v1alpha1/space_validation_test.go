@@ -147,6 +147,220 @@ func TestSpaceValidation(t *testing.T) {
 				Details: "one domain must be set to default",
 			},
 		},
+		"internal default domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "apps.internal", Default: true, Internal: true},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: &apis.FieldError{
+				Paths:   []string{"spec.execution.domains"},
+				Message: "internal default",
+				Details: "internal domains can't be the default",
+			},
+		},
+		"TCP default domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "tcp.example.com", Default: true, TCP: true},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: &apis.FieldError{
+				Paths:   []string{"spec.execution.domains"},
+				Message: "TCP default",
+				Details: "TCP domains can't be the default",
+			},
+		},
+		"internal TCP domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true},
+							{Domain: "tcp.internal", TCP: true, Internal: true},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: &apis.FieldError{
+				Paths:   []string{"spec.execution.domains"},
+				Message: "internal TCP domain",
+				Details: "TCP domains can't be internal",
+			},
+		},
+		"internal TLS domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true, TLS: &DomainTLS{SelfSigned: true}},
+							{Domain: "apps.internal", Internal: true, TLS: &DomainTLS{SelfSigned: true}},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: &apis.FieldError{
+				Paths:   []string{"spec.execution.domains"},
+				Message: "TLS on non-HTTP domain",
+				Details: "TLS can only be used with external HTTP domains",
+			},
+		},
+		"empty TLS": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true, TLS: &DomainTLS{}},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrMissingOneOf(
+				"spec.execution.domains[0].tls.secretName",
+				"spec.execution.domains[0].tls.selfSigned",
+			),
+		},
+		"good default backend": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: goodExecuton.Domains,
+						DefaultBackend: &RouteDefaultBackend{
+							Type:        RouteDefaultBackendRedirect,
+							RedirectURL: "https://example.com/not-found",
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+		},
+		"bad default backend type": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains:        goodExecuton.Domains,
+						DefaultBackend: &RouteDefaultBackend{Type: "teapot"},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrInvalidValue("teapot", "spec.execution.defaultBackend.type"),
+		},
+		"default backend redirect without URL": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains:        goodExecuton.Domains,
+						DefaultBackend: &RouteDefaultBackend{Type: RouteDefaultBackendRedirect},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrMissingField("spec.execution.defaultBackend.redirectURL"),
+		},
+		"default backend redirect to relative URL": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: goodExecuton.Domains,
+						DefaultBackend: &RouteDefaultBackend{
+							Type:        RouteDefaultBackendRedirect,
+							RedirectURL: "/not-found",
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrInvalidValue("/not-found", "spec.execution.defaultBackend.redirectURL"),
+		},
+		"default backend page with URL": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: goodExecuton.Domains,
+						DefaultBackend: &RouteDefaultBackend{
+							Type:        RouteDefaultBackendPage,
+							RedirectURL: "https://example.com",
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.execution.defaultBackend.redirectURL"),
+		},
+		"good network": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Execution:      goodExecuton,
+					Network: SpaceSpecNetwork{
+						DefaultEgressPolicy: EgressPolicyDeny,
+						Egress: []SpaceEgressRule{
+							{CIDR: "10.0.0.0/8", Policy: EgressPolicyAllow, Ports: []SpaceEgressPort{{Port: 443}, {Protocol: "UDP", Port: 53}}},
+							{CIDR: "10.1.0.0/16", Policy: EgressPolicyDeny},
+						},
+					},
+				},
+			},
+		},
+		"bad default egress policy": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Execution:      goodExecuton,
+					Network:        SpaceSpecNetwork{DefaultEgressPolicy: "Block"},
+				},
+			},
+			want: apis.ErrInvalidValue("Block", "spec.network.defaultEgressPolicy"),
+		},
+		"bad egress rules": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Execution:      goodExecuton,
+					Network: SpaceSpecNetwork{
+						Egress: []SpaceEgressRule{
+							{CIDR: "10.0.0.0", Policy: EgressPolicyAllow},
+							{CIDR: "10.0.0.0/8", Policy: EgressPolicyDeny, Ports: []SpaceEgressPort{{Port: 25}}},
+							{CIDR: "10.0.0.0/8", Policy: EgressPolicyAllow, Ports: []SpaceEgressPort{{Protocol: "SCTP", Port: 0}}},
+							{},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("10.0.0.0", "spec.network.egress[0].cidr").
+				Also(&apis.FieldError{Message: "ports can't be set on Deny rules", Paths: []string{"spec.network.egress[1].ports"}}).
+				Also(apis.ErrInvalidValue("SCTP", "spec.network.egress[2].ports[0].protocol")).
+				Also(apis.ErrInvalidValue(0, "spec.network.egress[2].ports[0].port")).
+				Also(&apis.FieldError{Message: "duplicate CIDR", Paths: []string{"spec.network.egress[2].cidr"}}).
+				Also(apis.ErrMissingField("spec.network.egress[3].cidr", "spec.network.egress[3].policy")),
+		},
 	}
 
 	for tn, tc := range cases {
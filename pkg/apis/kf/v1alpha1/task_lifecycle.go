@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (r *Task) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Task")
+}
+
+const (
+	// TaskConditionSucceeded is set when the Task has run to completion.
+	TaskConditionSucceeded = apis.ConditionSucceeded
+	// TaskConditionAppReady is set when the App the Task runs against has a
+	// built image that can be used.
+	TaskConditionAppReady apis.ConditionType = "AppReady"
+	// TaskConditionJobSucceeded is set when the Job running the Task has
+	// completed.
+	TaskConditionJobSucceeded apis.ConditionType = "JobSucceeded"
+)
+
+func (status *TaskStatus) manage() apis.ConditionManager {
+	return apis.NewBatchConditionSet(
+		TaskConditionAppReady,
+		TaskConditionJobSucceeded,
+	).Manage(status)
+}
+
+// Succeeded returns if the Task completed successfully.
+func (status *TaskStatus) Succeeded() bool {
+	return status.manage().IsHappy()
+}
+
+// GetCondition returns the condition by name.
+func (status *TaskStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return status.manage().GetCondition(t)
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *TaskStatus) InitializeConditions() {
+	status.manage().InitializeConditions()
+}
+
+// JobCondition gets a manager for the state of the Job.
+func (status *TaskStatus) JobCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), TaskConditionJobSucceeded, "Job")
+}
+
+// MarkAppNotFound notes that the App the Task references doesn't exist.
+func (status *TaskStatus) MarkAppNotFound(appName string) {
+	status.manage().MarkFalse(TaskConditionAppReady, "AppNotFound",
+		fmt.Sprintf("The App %q doesn't exist.", appName))
+}
+
+// MarkAppImageNotReady notes that the App doesn't have a built image yet.
+func (status *TaskStatus) MarkAppImageNotReady(appName string) {
+	status.manage().MarkUnknown(TaskConditionAppReady, "ImageNotReady",
+		fmt.Sprintf("Waiting for App %q to have a built image.", appName))
+}
+
+// MarkAppReady notes that the App has an image the Task can run with.
+func (status *TaskStatus) MarkAppReady() {
+	status.manage().MarkTrue(TaskConditionAppReady)
+}
+
+// MarkTerminated notes that the Task was cancelled before it completed.
+func (status *TaskStatus) MarkTerminated() {
+	status.manage().MarkFalse(TaskConditionJobSucceeded, "Terminated",
+		"The Task was terminated.")
+}
+
+// PropagateJobStatus copies fields from the Job status to the Task
+// and updates the readiness based on the current state.
+func (status *TaskStatus) PropagateJobStatus(job *batchv1.Job) {
+	if job == nil {
+		return
+	}
+
+	status.JobName = job.Name
+	status.StartTime = job.Status.StartTime
+	status.CompletionTime = job.Status.CompletionTime
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			status.manage().MarkTrue(TaskConditionJobSucceeded)
+			return
+		case batchv1.JobFailed:
+			status.manage().MarkFalse(TaskConditionJobSucceeded, condition.Reason, "Job failed: %s", condition.Message)
+			return
+		}
+	}
+
+	status.manage().MarkUnknown(TaskConditionJobSucceeded, "Running", "Task is running")
+}
+
+func (status *TaskStatus) duck() *duckv1beta1.Status {
+	return &status.Status
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+const (
+	// TaskNameLabel is the label put on the Jobs and Pods that run a Task to
+	// link them back to the Task.
+	TaskNameLabel = "kf.dev/task"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Task is a one-off command run to completion against an App's image and
+// environment.
+type Task struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec TaskSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status TaskStatus `json:"status,omitempty"`
+}
+
+// TaskSpec is the desired configuration for a Task.
+type TaskSpec struct {
+
+	// AppName is the name of the App the Task runs against. The Task uses
+	// the App's latest built image, environment and service bindings.
+	AppName string `json:"appName"`
+
+	// Command is the command the Task runs. It's passed as a single argument
+	// to the image's entrypoint so buildpack built images run it in the same
+	// environment as the App.
+	Command string `json:"command"`
+
+	// Terminated is set when the Task should be cancelled. Any running
+	// instance of the Task is stopped.
+	// +optional
+	Terminated bool `json:"terminated,omitempty"`
+}
+
+// TaskStatus is the current state of a Task.
+type TaskStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+
+	// JobName is the name of the Job running the Task.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// StartTime is the time the Task's Job started running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the Task finished running.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskList is a list of Task resources.
+type TaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Task `json:"items"`
+}
@@ -0,0 +1,59 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate checks for errors in the Task's spec or status fields.
+func (task *Task) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// If we're specifically updating status, don't reject the change because
+	// of a spec issue.
+	if !apis.IsInStatusUpdate(ctx) {
+		errs = errs.Also(task.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	}
+
+	return errs
+}
+
+// Validate makes sure that a TaskSpec is properly configured.
+func (spec *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+
+	if spec.AppName == "" {
+		errs = errs.Also(apis.ErrMissingField("appName"))
+	}
+
+	if spec.Command == "" {
+		errs = errs.Also(apis.ErrMissingField("command"))
+	}
+
+	// The command and App can't be changed once a Task has been created.
+	if base := apis.GetBaseline(ctx); base != nil {
+		if old, ok := base.(*Task); ok {
+			if old.Spec.AppName != spec.AppName {
+				errs = errs.Also(&apis.FieldError{Message: "Immutable field changed", Paths: []string{"appName"}})
+			}
+
+			if old.Spec.Command != spec.Command {
+				errs = errs.Also(&apis.FieldError{Message: "Immutable field changed", Paths: []string{"command"}})
+			}
+		}
+	}
+
+	return errs
+}
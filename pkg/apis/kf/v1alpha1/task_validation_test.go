@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	"knative.dev/pkg/apis"
+)
+
+func TestTask_Validate(t *testing.T) {
+	goodSpec := TaskSpec{
+		AppName: "my-app",
+		Command: "rake db:migrate",
+	}
+
+	cases := map[string]struct {
+		old  *Task
+		task Task
+		want *apis.FieldError
+	}{
+		"valid": {
+			task: Task{Spec: goodSpec},
+		},
+		"missing app name": {
+			task: Task{Spec: TaskSpec{Command: "rake db:migrate"}},
+			want: apis.ErrMissingField("spec.appName"),
+		},
+		"missing command": {
+			task: Task{Spec: TaskSpec{AppName: "my-app"}},
+			want: apis.ErrMissingField("spec.command"),
+		},
+		"terminated": {
+			old: &Task{Spec: goodSpec},
+			task: Task{Spec: TaskSpec{
+				AppName:    "my-app",
+				Command:    "rake db:migrate",
+				Terminated: true,
+			}},
+		},
+		"command changed": {
+			old: &Task{Spec: goodSpec},
+			task: Task{Spec: TaskSpec{
+				AppName: "my-app",
+				Command: "rake db:seed",
+			}},
+			want: &apis.FieldError{Message: "Immutable field changed", Paths: []string{"spec.command"}},
+		},
+		"app changed": {
+			old: &Task{Spec: goodSpec},
+			task: Task{Spec: TaskSpec{
+				AppName: "other-app",
+				Command: "rake db:migrate",
+			}},
+			want: &apis.FieldError{Message: "Immutable field changed", Paths: []string{"spec.appName"}},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.old != nil {
+				ctx = apis.WithinUpdate(ctx, tc.old)
+			}
+
+			got := tc.task.Validate(ctx)
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	cv1alpha3 "knative.dev/pkg/client/clientset/versioned/typed/istio/v1alpha3"
@@ -170,6 +171,54 @@ func IstioClientFromContext(ctx context.Context) cv1alpha3.VirtualServicesGetter
 	return ctx.Value(istioClientKey{}).(cv1alpha3.VirtualServicesGetter)
 }
 
+// DomainGetter fetches cluster-scoped Domains by name. It's satisfied by the
+// typed Domains client.
+type DomainGetter interface {
+	Get(name string, options metav1.GetOptions) (*Domain, error)
+}
+
+type domainClientKey struct{}
+
+// SetupDomainClient adds a DomainGetter to the context so the webhook can
+// check routes against the cluster's Domains.
+func SetupDomainClient(ctx context.Context, domainClient DomainGetter) context.Context {
+	return context.WithValue(ctx, domainClientKey{}, domainClient)
+}
+
+// DomainClientFromContext returns the DomainGetter from the context or nil if
+// one wasn't set up.
+func DomainClientFromContext(ctx context.Context) DomainGetter {
+	if client, ok := ctx.Value(domainClientKey{}).(DomainGetter); ok {
+		return client
+	}
+
+	return nil
+}
+
+// RouteClaimLister lists RouteClaims. It's satisfied by the typed
+// RouteClaims client for all namespaces.
+type RouteClaimLister interface {
+	List(opts metav1.ListOptions) (*RouteClaimList, error)
+}
+
+type routeClaimListerKey struct{}
+
+// SetupRouteClaimLister adds a RouteClaimLister to the context so the webhook
+// can check RouteClaims for conflicts with other spaces.
+func SetupRouteClaimLister(ctx context.Context, lister RouteClaimLister) context.Context {
+	return context.WithValue(ctx, routeClaimListerKey{}, lister)
+}
+
+// RouteClaimListerFromContext returns the RouteClaimLister from the context
+// or nil if one wasn't set up.
+func RouteClaimListerFromContext(ctx context.Context) RouteClaimLister {
+	if lister, ok := ctx.Value(routeClaimListerKey{}).(RouteClaimLister); ok {
+		return lister
+	}
+
+	return nil
+}
+
 // IsStatusFinal returns true if the Ready or Succeeded conditions are True or
 // False for a Status.
 func IsStatusFinal(duck duckv1beta1.Status) bool {
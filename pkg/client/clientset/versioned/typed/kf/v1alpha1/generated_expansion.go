@@ -18,6 +18,8 @@ package v1alpha1
 
 type AppExpansion interface{}
 
+type DomainExpansion interface{}
+
 type RouteExpansion interface{}
 
 type RouteClaimExpansion interface{}
@@ -25,3 +27,5 @@ type RouteClaimExpansion interface{}
 type SourceExpansion interface{}
 
 type SpaceExpansion interface{}
+
+type TaskExpansion interface{}
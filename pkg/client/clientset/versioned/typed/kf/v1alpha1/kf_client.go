@@ -26,10 +26,12 @@ import (
 type KfV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AppsGetter
+	DomainsGetter
 	RoutesGetter
 	RouteClaimsGetter
 	SourcesGetter
 	SpacesGetter
+	TasksGetter
 }
 
 // KfV1alpha1Client is used to interact with features provided by the kf.dev group.
@@ -41,6 +43,10 @@ func (c *KfV1alpha1Client) Apps(namespace string) AppInterface {
 	return newApps(c, namespace)
 }
 
+func (c *KfV1alpha1Client) Domains() DomainInterface {
+	return newDomains(c)
+}
+
 func (c *KfV1alpha1Client) Routes(namespace string) RouteInterface {
 	return newRoutes(c, namespace)
 }
@@ -57,6 +63,10 @@ func (c *KfV1alpha1Client) Spaces() SpaceInterface {
 	return newSpaces(c)
 }
 
+func (c *KfV1alpha1Client) Tasks(namespace string) TaskInterface {
+	return newTasks(c, namespace)
+}
+
 // NewForConfig creates a new KfV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*KfV1alpha1Client, error) {
 	config := *c
@@ -24,6 +24,8 @@ import (
 type Interface interface {
 	// Apps returns a AppInformer.
 	Apps() AppInformer
+	// Domains returns a DomainInformer.
+	Domains() DomainInformer
 	// Routes returns a RouteInformer.
 	Routes() RouteInformer
 	// RouteClaims returns a RouteClaimInformer.
@@ -32,6 +34,8 @@ type Interface interface {
 	Sources() SourceInformer
 	// Spaces returns a SpaceInformer.
 	Spaces() SpaceInformer
+	// Tasks returns a TaskInformer.
+	Tasks() TaskInformer
 }
 
 type version struct {
@@ -50,6 +54,11 @@ func (v *version) Apps() AppInformer {
 	return &appInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Domains returns a DomainInformer.
+func (v *version) Domains() DomainInformer {
+	return &domainInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Routes returns a RouteInformer.
 func (v *version) Routes() RouteInformer {
 	return &routeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -69,3 +78,8 @@ func (v *version) Sources() SourceInformer {
 func (v *version) Spaces() SpaceInformer {
 	return &spaceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// Tasks returns a TaskInformer.
+func (v *version) Tasks() TaskInformer {
+	return &taskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Knative Authors
+ Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+     http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedpod
+
+import (
+	"context"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	corev1 "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/clients/kubeclient"
+	"knative.dev/pkg/logging"
+)
+
+func init() {
+	injection.Default.RegisterInformer(withInformer)
+}
+
+// Key is used as the key for associating information
+// with a context.Context.
+type Key struct{}
+
+func withInformer(ctx context.Context) (context.Context, controller.Informer) {
+	inf := NewInformer(ctx, kubeclient.Get(ctx))
+	return context.WithValue(ctx, Key{}, inf), inf.Informer()
+}
+
+// NewInformer creates a Pod informer that only watches the Pods managed by
+// Kf rather than every Pod in the cluster.
+func NewInformer(ctx context.Context, client kubernetes.Interface) corev1.PodInformer {
+	f := informers.NewSharedInformerFactoryWithOptions(
+		client,
+		controller.GetResyncPeriod(ctx),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = v1alpha1.ManagedByLabel + "=kf"
+		}),
+	)
+	return f.Core().V1().Pods()
+}
+
+// Get extracts the Kubernetes Pod informer of Kf managed Pods from the
+// context.
+func Get(ctx context.Context) corev1.PodInformer {
+	untyped := ctx.Value(Key{})
+	if untyped == nil {
+		logging.FromContext(ctx).Panicf(
+			"Unable to fetch %T from context.", (corev1.PodInformer)(nil))
+	}
+	return untyped.(corev1.PodInformer)
+}
@@ -15,12 +15,14 @@
 package apps
 
 import (
+	"fmt"
 	"io"
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	cv1alpha1 "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/algorithms"
 	"github.com/google/kf/pkg/kf/sources"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 // ClientExtension holds additional functions that should be exposed by client.
@@ -32,17 +34,25 @@ type ClientExtension interface {
 	DeployLogs(out io.Writer, appName, resourceVersion, namespace string, noStart bool) error
 	Restart(namespace, name string) error
 	Restage(namespace, name string) error
+
+	// Rollback deploys the image and template of a revision in the App's
+	// history again without rebuilding it. If revision is 0, the App is
+	// rolled back to the revision before the latest one.
+	Rollback(namespace, name string, revision int) error
 }
 
 type appsClient struct {
 	sourcesClient sources.Client
+	pods          corev1.PodsGetter
 	coreClient
 }
 
-// NewClient creates a new application client.
+// NewClient creates a new application client. The pods are used to show the
+// output of crashed instances when a push fails.
 func NewClient(
 	kclient cv1alpha1.AppsGetter,
-	sourcesClient sources.Client) Client {
+	sourcesClient sources.Client,
+	pods corev1.PodsGetter) Client {
 	return &appsClient{
 		coreClient: coreClient{
 			kclient: kclient,
@@ -65,6 +75,7 @@ func NewClient(
 			membershipValidator: AllPredicate(), // all apps can be managed by Kf
 		},
 		sourcesClient: sourcesClient,
+		pods:          pods,
 	}
 }
 
@@ -91,3 +102,48 @@ func (ac *appsClient) Restage(namespace, name string) error {
 		return nil
 	})
 }
+
+// Rollback deploys a revision in the App's history again. The image is pinned
+// as a container image source so it isn't rebuilt.
+func (ac *appsClient) Rollback(namespace, name string, revision int) error {
+	return ac.coreClient.Transform(namespace, name, func(a *v1alpha1.App) error {
+		target, err := findRollbackRevision(a.Status.History, revision)
+		if err != nil {
+			return err
+		}
+
+		// The built image is deployed directly, keeping the buildpack source
+		// would rebuild it rather than run what the revision ran.
+		a.Spec.Source = v1alpha1.SourceSpec{
+			UpdateRequests: a.Spec.Source.UpdateRequests + 1,
+			ContainerImage: v1alpha1.SourceSpecContainerImage{
+				Image: target.Image,
+			},
+		}
+
+		a.Spec.Template.Spec = *target.Template.Spec.DeepCopy()
+		a.Spec.Template.UpdateRequests++
+
+		return nil
+	})
+}
+
+// findRollbackRevision finds the revision in the history to roll back to. If
+// revision is 0, the one before the latest is used.
+func findRollbackRevision(history []v1alpha1.AppRevision, revision int) (*v1alpha1.AppRevision, error) {
+	if revision == 0 {
+		if len(history) < 2 {
+			return nil, fmt.Errorf("there's no previous revision to roll back to")
+		}
+
+		return &history[len(history)-2], nil
+	}
+
+	for i := range history {
+		if history[i].Revision == revision {
+			return &history[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("revision %d isn't in the App's history", revision)
+}
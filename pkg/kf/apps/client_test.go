@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps_test
+
+import (
+	"errors"
+	"testing"
+
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kffake "github.com/google/kf/pkg/client/clientset/versioned/fake"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClient_Rollback(t *testing.T) {
+	t.Parallel()
+
+	template := func(value string) v1alpha1.AppSpecTemplate {
+		return v1alpha1.AppSpecTemplate{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Env: []corev1.EnvVar{{Name: "VERSION", Value: value}},
+				}},
+			},
+		}
+	}
+
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.AppSpec{
+			Source: v1alpha1.SourceSpec{
+				UpdateRequests: 3,
+				BuildpackBuild: v1alpha1.SourceSpecBuildpackBuild{
+					Source: "gcr.io/source:3",
+				},
+			},
+			Template: template("3"),
+		},
+		Status: v1alpha1.AppStatus{
+			History: []v1alpha1.AppRevision{
+				{Revision: 1, Image: "gcr.io/app@sha256:1", Template: template("1")},
+				{Revision: 2, Image: "gcr.io/app@sha256:2", Template: template("2")},
+				{Revision: 3, Image: "gcr.io/app@sha256:3", Template: template("3")},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		revision  int
+		wantErr   error
+		wantImage string
+		wantEnv   string
+	}{
+		"previous revision": {
+			revision:  0,
+			wantImage: "gcr.io/app@sha256:2",
+			wantEnv:   "2",
+		},
+		"specific revision": {
+			revision:  1,
+			wantImage: "gcr.io/app@sha256:1",
+			wantEnv:   "1",
+		},
+		"missing revision": {
+			revision: 7,
+			wantErr:  errors.New("revision 7 isn't in the App's history"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			cs := kffake.NewSimpleClientset(app.DeepCopy())
+			client := apps.NewClient(cs.KfV1alpha1(), nil, nil)
+
+			err := client.Rollback("my-ns", "my-app", tc.revision)
+			if tc.wantErr != nil || err != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, err)
+				return
+			}
+
+			actual, err := cs.KfV1alpha1().Apps("my-ns").Get("my-app", metav1.GetOptions{})
+			testutil.AssertNil(t, "get err", err)
+			testutil.AssertEqual(t, "source", v1alpha1.SourceSpec{
+				UpdateRequests: 4,
+				ContainerImage: v1alpha1.SourceSpecContainerImage{Image: tc.wantImage},
+			}, actual.Spec.Source)
+			testutil.AssertEqual(t, "env", tc.wantEnv, actual.Spec.Template.Spec.Containers[0].Env[0].Value)
+			testutil.AssertEqual(t, "template update requests", 1, actual.Spec.Template.UpdateRequests)
+		})
+	}
+}
+
+func TestClient_Rollback_noPreviousRevision(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Namespace = "my-ns"
+	app.Status.History = []v1alpha1.AppRevision{{Revision: 1}}
+
+	cs := kffake.NewSimpleClientset(app)
+	client := apps.NewClient(cs.KfV1alpha1(), nil, nil)
+
+	err := client.Rollback("my-ns", "my-app", 0)
+	testutil.AssertErrorsEqual(t, errors.New("there's no previous revision to roll back to"), err)
+}
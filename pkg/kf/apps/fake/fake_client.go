@@ -187,6 +187,20 @@ func (mr *FakeClientMockRecorder) Restart(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restart", reflect.TypeOf((*FakeClient)(nil).Restart), arg0, arg1)
 }
 
+// Rollback mocks base method
+func (m *FakeClient) Rollback(arg0, arg1 string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *FakeClientMockRecorder) Rollback(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*FakeClient)(nil).Rollback), arg0, arg1, arg2)
+}
+
 // Transform mocks base method
 func (m *FakeClient) Transform(arg0, arg1 string, arg2 apps.Mutator) error {
 	m.ctrl.T.Helper()
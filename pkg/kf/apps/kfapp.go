@@ -239,6 +239,21 @@ func (k *KfApp) SetHealthCheck(probe *corev1.Probe) {
 	container.ReadinessProbe = probe
 }
 
+// GetArgs gets the arguments the App's container is started with.
+func (k *KfApp) GetArgs() []string {
+	if cont := k.getContainerOrNil(); cont != nil {
+		return cont.Args
+	}
+
+	return nil
+}
+
+// SetArgs sets the arguments the App's container is started with.
+func (k *KfApp) SetArgs(args []string) {
+	container := k.getOrCreateContainer()
+	container.Args = args
+}
+
 func (k *KfApp) GetServiceBindings() []v1alpha1.AppSpecServiceBinding {
 	return k.Spec.ServiceBindings
 }
@@ -227,3 +227,12 @@ func ExampleKfApp_GetCPU() {
 
 	// Output: 100m
 }
+
+func ExampleKfApp_GetArgs() {
+	myApp := NewKfApp()
+	myApp.SetArgs([]string{"bundle", "exec", "rackup"})
+
+	fmt.Println(myApp.GetArgs())
+
+	// Output: [bundle exec rackup]
+}
@@ -18,17 +18,24 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// crashLogLines is how many lines of a crashed instance's output are shown
+// when a push fails.
+const crashLogLines = 20
+
 type pushLogTailer struct {
 	client               *appsClient
 	out                  io.Writer
@@ -170,6 +177,31 @@ func (t *pushLogTailer) handleUpdate(
 		return true, nil
 	}
 
+	// Rollout conditions from before the App was last reconciled may describe
+	// an older push.
+	if app.Status.ObservedGeneration >= app.Generation {
+		// Crashing instances won't become ready, fail rather than waiting for
+		// Knative to time out.
+		if instances := app.Status.GetCondition(v1alpha1.AppConditionInstancesHealthy); instances != nil && instances.IsFalse() {
+			t.logger.Printf("Instances failed (%s): %s\n", instances.Reason, instances.Message)
+			t.printCrashLogs(app)
+			return true, fmt.Errorf("deployment failed: %s", instances.Message)
+		}
+
+		if rollout := app.Status.GetCondition(v1alpha1.AppConditionRolloutReady); rollout != nil {
+			switch rollout.Status {
+			case corev1.ConditionFalse:
+				t.logger.Printf("Rolled back: %s\n", rollout.Message)
+				return true, fmt.Errorf("deployment rolled back: %s", rollout.Message)
+			case corev1.ConditionUnknown:
+				if rollout.Message != "" {
+					t.logger.Printf("Updated state to: %s\n", rollout.Message)
+				}
+				return false, nil
+			}
+		}
+	}
+
 	appReady := app.Status.GetCondition(v1alpha1.AppConditionReady)
 	if appReady == nil {
 		return false, nil
@@ -193,3 +225,53 @@ func (t *pushLogTailer) handleUpdate(
 
 	return false, nil
 }
+
+// printCrashLogs writes the last lines a crashed instance of the App wrote
+// before it exited. Failures are only logged because the output just helps
+// explain why the push failed.
+func (t *pushLogTailer) printCrashLogs(app *v1alpha1.App) {
+	if t.client.pods == nil {
+		return
+	}
+
+	pods, err := t.client.pods.Pods(t.namespace).List(k8smeta.ListOptions{
+		LabelSelector: labels.SelectorFromSet(app.ComponentLabels("app-server")).String(),
+	})
+	if err != nil {
+		t.logger.Printf("Couldn't list instances: %s\n", err)
+		return
+	}
+
+	var instances []*corev1.Pod
+	for i := range pods.Items {
+		instances = append(instances, &pods.Items[i])
+	}
+
+	failure := v1alpha1.DiagnoseInstances(instances, nil)
+	if failure == nil || !failure.Crashed {
+		return
+	}
+
+	tailLines := int64(crashLogLines)
+	stream, err := t.client.pods.Pods(t.namespace).GetLogs(failure.PodName, &corev1.PodLogOptions{
+		Container: failure.ContainerName,
+		Previous:  true,
+		TailLines: &tailLines,
+	}).Stream()
+	if err != nil {
+		t.logger.Printf("Couldn't get the output of instance %s: %s\n", failure.PodName, err)
+		return
+	}
+	defer stream.Close()
+
+	output, err := ioutil.ReadAll(stream)
+	if err != nil {
+		t.logger.Printf("Couldn't read the output of instance %s: %s\n", failure.PodName, err)
+		return
+	}
+
+	t.logger.Printf("Last output of instance %s before it exited:\n", failure.PodName)
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		t.logger.Printf("  %s\n", line)
+	}
+}
@@ -17,6 +17,8 @@ package apps_test
 import (
 	"bytes"
 	"errors"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -27,8 +29,14 @@ import (
 	sourcesfake "github.com/google/kf/pkg/kf/sources/fake"
 	"github.com/google/kf/pkg/kf/testutil"
 	build "github.com/knative/build/pkg/apis/build/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
 	ktesting "k8s.io/client-go/testing"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
@@ -113,6 +121,51 @@ func TestLogTailer_DeployLogs_ServiceLogs(t *testing.T) {
 			}),
 			wantErr: errors.New("deployment failed: some-error"),
 		},
+		"instances crashing, return error": {
+			appName:         "some-app",
+			namespace:       "default",
+			resourceVersion: "some-version",
+			events: createMsgEvents("some-app", duckv1beta1.Conditions{
+				{
+					Type:   "SourceReady",
+					Status: "True",
+				},
+				{
+					Type:    "Ready",
+					Status:  "Unknown",
+					Message: "waiting for revision",
+				},
+				{
+					Type:    "InstancesHealthy",
+					Status:  "False",
+					Reason:  "CrashLoopBackOff",
+					Message: "instance some-app-abc failed: container is crashing",
+				},
+			}),
+			wantErr: errors.New("deployment failed: instance some-app-abc failed: container is crashing"),
+		},
+		"rollout rolled back, return error": {
+			appName:         "some-app",
+			namespace:       "default",
+			resourceVersion: "some-version",
+			events: createMsgEvents("some-app", duckv1beta1.Conditions{
+				{
+					Type:   "SourceReady",
+					Status: "True",
+				},
+				{
+					Type:   "Ready",
+					Status: "True",
+				},
+				{
+					Type:    "RolloutReady",
+					Status:  "False",
+					Reason:  "RolledBack",
+					Message: "some-error",
+				},
+			}),
+			wantErr: errors.New("deployment rolled back: some-error"),
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl, fakeApps := buildLogWatchFakes(
@@ -127,7 +180,7 @@ func TestLogTailer_DeployLogs_ServiceLogs(t *testing.T) {
 			}))
 
 			sourceClient := sourcesfake.NewFakeClient(ctrl)
-			lt := apps.NewClient(fakeApps, sourceClient)
+			lt := apps.NewClient(fakeApps, sourceClient, nil)
 
 			var buffer bytes.Buffer
 			gotErr := lt.DeployLogs(
@@ -159,6 +212,109 @@ func TestLogTailer_DeployLogs_ServiceLogs(t *testing.T) {
 	}
 }
 
+func TestLogTailer_DeployLogs_crashLogs(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "some-app"
+
+	crashing := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-app-abc",
+			Namespace: "default",
+			Labels:    app.ComponentLabels("app-server"),
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "user-container",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+					},
+				},
+			},
+		},
+	}
+
+	ctrl, fakeApps := buildLogWatchFakes(
+		t,
+		createMsgEvents("some-app", duckv1beta1.Conditions{
+			{
+				Type:   "SourceReady",
+				Status: "True",
+			},
+			{
+				Type:    "InstancesHealthy",
+				Status:  "False",
+				Reason:  "CrashLoopBackOff",
+				Message: "instance some-app-abc failed: container user-container is crashing",
+			},
+		}),
+		nil,
+		nil, nil,
+	)
+	defer ctrl.Finish()
+
+	pods := &logsPods{
+		PodInterface: k8sfake.NewSimpleClientset(crashing).CoreV1().Pods("default"),
+		output:       "starting\npanic: missing DATABASE_URL\n",
+	}
+
+	lt := apps.NewClient(fakeApps, sourcesfake.NewFakeClient(ctrl), logsPodsGetter{pods})
+
+	var buffer bytes.Buffer
+	gotErr := lt.DeployLogs(&buffer, "some-app", "some-version", "default", false)
+	testutil.AssertErrorsEqual(t, errors.New("deployment failed: instance some-app-abc failed: container user-container is crashing"), gotErr)
+
+	testutil.AssertEqual(t, "pod", "some-app-abc", pods.gotName)
+	testutil.AssertEqual(t, "container", "user-container", pods.gotOpts.Container)
+	testutil.AssertEqual(t, "previous", true, pods.gotOpts.Previous)
+	testutil.AssertEqual(t, "tail lines", int64(20), *pods.gotOpts.TailLines)
+	testutil.AssertContainsAll(t, buffer.String(), []string{
+		"Last output of instance some-app-abc before it exited:",
+		"  starting",
+		"  panic: missing DATABASE_URL",
+	})
+}
+
+// logsPods serves output as the logs of every Pod and records the last logs
+// request.
+type logsPods struct {
+	typedcorev1.PodInterface
+
+	output  string
+	gotName string
+	gotOpts *corev1.PodLogOptions
+}
+
+func (p *logsPods) GetLogs(name string, opts *corev1.PodLogOptions) *restclient.Request {
+	p.gotName = name
+	p.gotOpts = opts
+
+	client := &fakerest.RESTClient{
+		NegotiatedSerializer: scheme.Codecs,
+		GroupVersion:         corev1.SchemeGroupVersion,
+		Client: fakerest.CreateHTTPClient(func(*http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(p.output)),
+			}, nil
+		}),
+	}
+	return client.Get()
+}
+
+type logsPodsGetter struct {
+	pods *logsPods
+}
+
+func (g logsPodsGetter) Pods(namespace string) typedcorev1.PodInterface {
+	return g.pods
+}
+
 func testWatch(t *testing.T, action ktesting.Action, resource, namespace, resourceVersion string) {
 	t.Helper()
 	testutil.AssertEqual(t, "namespace", namespace, action.GetNamespace())
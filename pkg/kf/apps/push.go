@@ -78,6 +78,12 @@ func newApp(appName string, opts ...PushOption) (*v1alpha1.App, error) {
 	app.SetHealthCheck(cfg.HealthCheck)
 	app.Spec.Routes = cfg.Routes
 	app.Spec.ServiceBindings = cfg.ServiceBindings
+	app.Spec.Processes = cfg.Processes
+	app.Spec.Strategy.Type = cfg.Strategy
+
+	if len(cfg.Args) > 0 {
+		app.SetArgs(cfg.Args)
+	}
 
 	if cfg.Grpc {
 		app.SetContainerPorts([]corev1.ContainerPort{{Name: "h2c", ContainerPort: 8080}})
@@ -210,6 +216,27 @@ func mergeApps(cfg pushConfig, hasDefaultRoutes bool) func(newapp, oldapp *v1alp
 			newapp.Spec.Instances.Exactly = &singleInstance
 		}
 
+		// Processes are only replaced if the user supplied some, and keep
+		// their scale if it wasn't set explicitly.
+		if len(cfg.Processes) == 0 {
+			newapp.Spec.Processes = oldapp.Spec.Processes
+		} else {
+			for i, process := range newapp.Spec.Processes {
+				if process.Instances != nil {
+					continue
+				}
+
+				for _, old := range oldapp.Spec.Processes {
+					if old.Type == process.Type {
+						newapp.Spec.Processes[i].Instances = old.Instances
+					}
+				}
+			}
+		}
+
+		// Push doesn't configure history, keep whatever was set on the App.
+		newapp.Spec.RevisionHistoryLimit = oldapp.Spec.RevisionHistoryLimit
+
 		newapp.ResourceVersion = oldapp.ResourceVersion
 		newEnvs := envutil.GetAppEnvVars(newapp)
 		oldEnvs := envutil.GetAppEnvVars(oldapp)
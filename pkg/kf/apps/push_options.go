@@ -25,6 +25,8 @@ import (
 )
 
 type pushConfig struct {
+	// Args is the arguments to start the app's container with
+	Args []string
 	// Buildpack is skip the detect buildpack step and use the given name
 	Buildpack string
 	// CPU is app CPU request
@@ -55,6 +57,8 @@ type pushConfig struct {
 	NoStart bool
 	// Output is the io.Writer to write output such as build logs
 	Output io.Writer
+	// Processes is additional process types to run from the app's image
+	Processes []v1alpha1.AppSpecProcess
 	// RandomRouteDomain is Domain for a random route. Only used if a route doesn't already exist
 	RandomRouteDomain string
 	// Routes is routes for the app
@@ -63,6 +67,8 @@ type pushConfig struct {
 	ServiceBindings []v1alpha1.AppSpecServiceBinding
 	// SourceImage is the source code as a container image
 	SourceImage string
+	// Strategy is the strategy used to move traffic to the new revision, rolling or blue-green
+	Strategy string
 }
 
 // PushOption is a single option for configuring a pushConfig
@@ -91,6 +97,12 @@ func (opts PushOptions) Extend(other PushOptions) PushOptions {
 	return out
 }
 
+// Args returns the last set value for Args or the empty value
+// if not set.
+func (opts PushOptions) Args() []string {
+	return opts.toConfig().Args
+}
+
 // Buildpack returns the last set value for Buildpack or the empty value
 // if not set.
 func (opts PushOptions) Buildpack() string {
@@ -181,6 +193,12 @@ func (opts PushOptions) Output() io.Writer {
 	return opts.toConfig().Output
 }
 
+// Processes returns the last set value for Processes or the empty value
+// if not set.
+func (opts PushOptions) Processes() []v1alpha1.AppSpecProcess {
+	return opts.toConfig().Processes
+}
+
 // RandomRouteDomain returns the last set value for RandomRouteDomain or the empty value
 // if not set.
 func (opts PushOptions) RandomRouteDomain() string {
@@ -205,6 +223,19 @@ func (opts PushOptions) SourceImage() string {
 	return opts.toConfig().SourceImage
 }
 
+// Strategy returns the last set value for Strategy or the empty value
+// if not set.
+func (opts PushOptions) Strategy() string {
+	return opts.toConfig().Strategy
+}
+
+// WithPushArgs creates an Option that sets the arguments to start the app's container with
+func WithPushArgs(val []string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Args = val
+	}
+}
+
 // WithPushBuildpack creates an Option that sets skip the detect buildpack step and use the given name
 func WithPushBuildpack(val string) PushOption {
 	return func(cfg *pushConfig) {
@@ -310,6 +341,13 @@ func WithPushOutput(val io.Writer) PushOption {
 	}
 }
 
+// WithPushProcesses creates an Option that sets additional process types to run from the app's image
+func WithPushProcesses(val []v1alpha1.AppSpecProcess) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Processes = val
+	}
+}
+
 // WithPushRandomRouteDomain creates an Option that sets Domain for a random route. Only used if a route doesn't already exist
 func WithPushRandomRouteDomain(val string) PushOption {
 	return func(cfg *pushConfig) {
@@ -338,6 +376,13 @@ func WithPushSourceImage(val string) PushOption {
 	}
 }
 
+// WithPushStrategy creates an Option that sets the strategy used to move traffic to the new revision, rolling or blue-green
+func WithPushStrategy(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Strategy = val
+	}
+}
+
 // PushOptionDefaults gets the default values for Push.
 func PushOptionDefaults() PushOptions {
 	return PushOptions{
@@ -233,6 +233,23 @@ func TestPush(t *testing.T) {
 					Return(&v1alpha1.App{}, nil)
 			},
 		},
+		"pushes app but leaves revision history limit": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushSourceImage("some-image"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.RevisionHistoryLimit = intPtr(3)
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "revisionHistoryLimit", 3, *newApp.Spec.RevisionHistoryLimit)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
 		"pushes app with buildpack": {
 			appName:   "some-app",
 			buildpack: "some-buildpack",
@@ -498,6 +515,91 @@ func TestPush(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"pushes app with args": {
+			appName:  "some-app",
+			srcImage: "some-image",
+			opts: apps.PushOptions{
+				apps.WithPushArgs([]string{"./web"}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "args", []string{"./web"}, newApp.Spec.Template.Spec.Containers[0].Args)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with processes": {
+			appName:  "some-app",
+			srcImage: "some-image",
+			opts: apps.PushOptions{
+				apps.WithPushProcesses([]v1alpha1.AppSpecProcess{
+					{Type: "worker", Command: "./worker", Instances: intPtr(2)},
+				}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "processes", []v1alpha1.AppSpecProcess{
+							{Type: "worker", Command: "./worker", Instances: intPtr(2)},
+						}, newApp.Spec.Processes)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app but leaves processes": {
+			appName:  "some-app",
+			srcImage: "some-image",
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Processes = []v1alpha1.AppSpecProcess{
+							{Type: "worker", Command: "./worker", Instances: intPtr(4)},
+						}
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "processes", oldApp.Spec.Processes, newApp.Spec.Processes)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app but leaves process instances": {
+			appName:  "some-app",
+			srcImage: "some-image",
+			opts: apps.PushOptions{
+				apps.WithPushProcesses([]v1alpha1.AppSpecProcess{
+					{Type: "worker", Command: "./new-worker"},
+				}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Processes = []v1alpha1.AppSpecProcess{
+							{Type: "worker", Command: "./worker", Instances: intPtr(4)},
+						}
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "processes", []v1alpha1.AppSpecProcess{
+							{Type: "worker", Command: "./new-worker", Instances: intPtr(4)},
+						}, newApp.Spec.Processes)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with strategy": {
+			appName:  "some-app",
+			srcImage: "some-image",
+			opts: apps.PushOptions{
+				apps.WithPushStrategy(v1alpha1.BlueGreenStrategy),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "strategy", v1alpha1.BlueGreenStrategy, newApp.Spec.Strategy.Type)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
 		"NoStart sets stopped": {
 			appName:   "some-app",
 			srcImage:  "some-image",
@@ -52,18 +52,18 @@ func (m *FakeSystemEnvInjector) EXPECT() *FakeSystemEnvInjectorMockRecorder {
 }
 
 // ComputeSystemEnv mocks base method
-func (m *FakeSystemEnvInjector) ComputeSystemEnv(arg0 *v1alpha1.App, arg1 []v1beta1.ServiceBinding) ([]v1.EnvVar, error) {
+func (m *FakeSystemEnvInjector) ComputeSystemEnv(arg0 *v1alpha1.App, arg1 *v1alpha1.Space, arg2 []v1beta1.ServiceBinding) ([]v1.EnvVar, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ComputeSystemEnv", arg0, arg1)
+	ret := m.ctrl.Call(m, "ComputeSystemEnv", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]v1.EnvVar)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ComputeSystemEnv indicates an expected call of ComputeSystemEnv
-func (mr *FakeSystemEnvInjectorMockRecorder) ComputeSystemEnv(arg0, arg1 interface{}) *gomock.Call {
+func (mr *FakeSystemEnvInjectorMockRecorder) ComputeSystemEnv(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeSystemEnv", reflect.TypeOf((*FakeSystemEnvInjector)(nil).ComputeSystemEnv), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeSystemEnv", reflect.TypeOf((*FakeSystemEnvInjector)(nil).ComputeSystemEnv), arg0, arg1, arg2)
 }
 
 // GetVcapService mocks base method
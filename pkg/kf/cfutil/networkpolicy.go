@@ -0,0 +1,203 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// NetworkPolicyComponent is the component label put on NetworkPolicies
+	// that allow traffic between Apps.
+	NetworkPolicyComponent = "network-policy"
+
+	// NetworkPolicySourceLabel holds the name of the App traffic is allowed
+	// from.
+	NetworkPolicySourceLabel = "kf.dev/network-policy-source"
+
+	// NetworkPolicyDestinationLabel holds the name of the App traffic is
+	// allowed to.
+	NetworkPolicyDestinationLabel = "kf.dev/network-policy-destination"
+
+	// DefaultNetworkPolicyPort is the port Cloud Foundry opens between Apps if
+	// none is given, it's also the port Apps listen on by default.
+	DefaultNetworkPolicyPort = 8080
+)
+
+// routingPeers match the Pods that deliver traffic for routes, they're
+// allowed through every NetworkPolicy so the destination App can still be
+// reached by its routes.
+var routingPeers = []networkingv1.NetworkPolicyPeer{
+	{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"istio": "ingressgateway"}},
+	},
+	{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"istio": "cluster-local-gateway"}},
+	},
+	{
+		NamespaceSelector: &metav1.LabelSelector{},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "activator"}},
+	},
+}
+
+// NetworkPolicy allows an App to connect directly to the Pods of another App
+// in the same space, see
+// https://docs.cloudfoundry.org/devguide/deploy-apps/cf-networking.html
+type NetworkPolicy struct {
+	// Source is the name of the App traffic is allowed from.
+	Source string
+
+	// Destination is the name of the App traffic is allowed to.
+	Destination string
+
+	// Protocol is the protocol traffic is allowed over.
+	Protocol corev1.Protocol
+
+	// Port is the port on the destination traffic is allowed to.
+	Port int32
+}
+
+// NetworkPolicyName gets the name of the Kubernetes NetworkPolicy for the
+// policy.
+func NetworkPolicyName(policy NetworkPolicy) string {
+	return v1alpha1.GenerateName(
+		policy.Source,
+		policy.Destination,
+		strings.ToLower(string(policy.Protocol)),
+		strconv.Itoa(int(policy.Port)),
+	)
+}
+
+// NetworkPolicySelector selects the NetworkPolicies between Apps.
+func NetworkPolicySelector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		v1alpha1.ManagedByLabel: "kf",
+		v1alpha1.ComponentLabel: NetworkPolicyComponent,
+	})
+}
+
+// MakeNetworkPolicy creates a Kubernetes NetworkPolicy that allows the Pods
+// of the source App to connect to the Pods of the destination App.
+//
+// Once a NetworkPolicy selects the destination's Pods, Kubernetes drops any
+// traffic the policies don't allow. Traffic from the ingress gateways and
+// Knative's activator is always allowed so the destination's routes keep
+// working.
+func MakeNetworkPolicy(source, destination *v1alpha1.App, protocol corev1.Protocol, port int32) *networkingv1.NetworkPolicy {
+	policy := NetworkPolicy{
+		Source:      source.Name,
+		Destination: destination.Name,
+		Protocol:    protocol,
+		Port:        port,
+	}
+
+	// Owned by both Apps, so it's cleaned up if either of them is deleted.
+	var ownerRefs []metav1.OwnerReference
+	for _, app := range []*v1alpha1.App{source, destination} {
+		ownerRef := *kmeta.NewControllerRef(app)
+		ownerRef.Controller = nil
+		ownerRef.BlockOwnerDeletion = nil
+		ownerRefs = append(ownerRefs, ownerRef)
+	}
+
+	policyLabels := destination.ComponentLabels(NetworkPolicyComponent)
+	policyLabels[NetworkPolicySourceLabel] = source.Name
+	policyLabels[NetworkPolicyDestinationLabel] = destination.Name
+
+	targetPort := intstr.FromInt(int(port))
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            NetworkPolicyName(policy),
+			Namespace:       destination.Namespace,
+			Labels:          policyLabels,
+			OwnerReferences: ownerRefs,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: appPodLabels(destination),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: appPodLabels(source),
+							},
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &protocol, Port: &targetPort},
+					},
+				},
+				{
+					From: routingPeers,
+				},
+			},
+		},
+	}
+}
+
+// appPodLabels selects every Pod kf runs for the App, the instances of its
+// web process as well as its other processes.
+func appPodLabels(app *v1alpha1.App) map[string]string {
+	return map[string]string{
+		v1alpha1.NameLabel:      app.Name,
+		v1alpha1.ManagedByLabel: "kf",
+	}
+}
+
+// ParseNetworkPolicy reads the policy back out of a NetworkPolicy created by
+// MakeNetworkPolicy.
+func ParseNetworkPolicy(np *networkingv1.NetworkPolicy) (*NetworkPolicy, error) {
+	if np.Labels[v1alpha1.ComponentLabel] != NetworkPolicyComponent {
+		return nil, fmt.Errorf("NetworkPolicy %q isn't between Apps", np.Name)
+	}
+
+	if len(np.Spec.Ingress) == 0 || len(np.Spec.Ingress[0].Ports) == 0 {
+		return nil, fmt.Errorf("NetworkPolicy %q doesn't allow any ports", np.Name)
+	}
+
+	port := np.Spec.Ingress[0].Ports[0]
+
+	policy := &NetworkPolicy{
+		Source:      np.Labels[NetworkPolicySourceLabel],
+		Destination: np.Labels[NetworkPolicyDestinationLabel],
+		Protocol:    corev1.ProtocolTCP,
+	}
+
+	if port.Protocol != nil {
+		policy.Protocol = *port.Protocol
+	}
+
+	if port.Port != nil {
+		policy.Port = int32(port.Port.IntValue())
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfutil_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func ExampleMakeNetworkPolicy() {
+	frontend := &v1alpha1.App{}
+	frontend.Name = "frontend"
+	frontend.Namespace = "my-space"
+
+	backend := &v1alpha1.App{}
+	backend.Name = "backend"
+	backend.Namespace = "my-space"
+
+	np := cfutil.MakeNetworkPolicy(frontend, backend, corev1.ProtocolTCP, 8080)
+
+	fmt.Println("Namespace:", np.Namespace)
+	fmt.Println("Owners:", len(np.OwnerReferences))
+	fmt.Println("Selects:", np.Spec.PodSelector.MatchLabels[v1alpha1.NameLabel])
+	fmt.Println("From:", np.Spec.Ingress[0].From[0].PodSelector.MatchLabels[v1alpha1.NameLabel])
+	fmt.Println("Port:", np.Spec.Ingress[0].Ports[0].Port.String())
+
+	// Output: Namespace: my-space
+	// Owners: 2
+	// Selects: backend
+	// From: frontend
+	// Port: 8080
+}
+
+func TestMakeNetworkPolicy_podLabels(t *testing.T) {
+	t.Parallel()
+
+	source := &v1alpha1.App{}
+	source.Name = "frontend"
+	destination := &v1alpha1.App{}
+	destination.Name = "backend"
+
+	np := cfutil.MakeNetworkPolicy(source, destination, corev1.ProtocolUDP, 53)
+
+	testutil.AssertEqual(t, "pod selector", map[string]string{
+		v1alpha1.NameLabel:      destination.Name,
+		v1alpha1.ManagedByLabel: "kf",
+	}, np.Spec.PodSelector.MatchLabels)
+	testutil.AssertEqual(t, "source selector", map[string]string{
+		v1alpha1.NameLabel:      source.Name,
+		v1alpha1.ManagedByLabel: "kf",
+	}, np.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+
+	// Pods of other processes are selected too.
+	process := labels.Set(destination.ComponentLabels("process"))
+	selector := labels.SelectorFromSet(np.Spec.PodSelector.MatchLabels)
+	testutil.AssertEqual(t, "selects process pods", true, selector.Matches(process))
+	testutil.AssertEqual(t, "policy types", []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, np.Spec.PolicyTypes)
+	testutil.AssertEqual(t, "routing rule has no ports", 0, len(np.Spec.Ingress[1].Ports))
+	testutil.AssertEqual(t, "selected by NetworkPolicySelector", true, cfutil.NetworkPolicySelector().Matches(labels.Set(np.Labels)))
+}
+
+func TestParseNetworkPolicy(t *testing.T) {
+	t.Parallel()
+
+	source := &v1alpha1.App{}
+	source.Name = "frontend"
+	destination := &v1alpha1.App{}
+	destination.Name = "backend"
+
+	cases := map[string]struct {
+		np      *networkingv1.NetworkPolicy
+		want    *cfutil.NetworkPolicy
+		wantErr error
+	}{
+		"round trip": {
+			np: cfutil.MakeNetworkPolicy(source, destination, corev1.ProtocolUDP, 53),
+			want: &cfutil.NetworkPolicy{
+				Source:      "frontend",
+				Destination: "backend",
+				Protocol:    corev1.ProtocolUDP,
+				Port:        53,
+			},
+		},
+		"not between apps": {
+			np: &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-policy"},
+			},
+			wantErr: errors.New(`NetworkPolicy "some-policy" isn't between Apps`),
+		},
+		"no ports": {
+			np: &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "some-policy",
+					Labels: map[string]string{v1alpha1.ComponentLabel: cfutil.NetworkPolicyComponent},
+				},
+			},
+			wantErr: errors.New(`NetworkPolicy "some-policy" doesn't allow any ports`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got, err := cfutil.ParseNetworkPolicy(tc.np)
+			if tc.wantErr != nil || err != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, err)
+				return
+			}
+
+			testutil.AssertEqual(t, "policy", tc.want, got)
+		})
+	}
+}
+
+func ExampleNetworkPolicyName() {
+	fmt.Println(cfutil.NetworkPolicyName(cfutil.NetworkPolicy{
+		Source:      "frontend",
+		Destination: "backend",
+		Protocol:    corev1.ProtocolTCP,
+		Port:        8080,
+	}) != cfutil.NetworkPolicyName(cfutil.NetworkPolicy{
+		Source:      "frontend",
+		Destination: "backend",
+		Protocol:    corev1.ProtocolUDP,
+		Port:        8080,
+	}))
+
+	// Output: true
+}
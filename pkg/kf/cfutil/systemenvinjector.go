@@ -22,6 +22,7 @@ import (
 	"github.com/google/kf/pkg/internal/envutil"
 	servicecatalogv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -37,7 +38,7 @@ type SystemEnvInjector interface {
 
 	// ComputeSystemEnv computes the environment variables that should be injected
 	// on a given service.
-	ComputeSystemEnv(app *v1alpha1.App, serviceBindings []servicecatalogv1beta1.ServiceBinding) (computed []corev1.EnvVar, err error)
+	ComputeSystemEnv(app *v1alpha1.App, space *v1alpha1.Space, serviceBindings []servicecatalogv1beta1.ServiceBinding) (computed []corev1.EnvVar, err error)
 }
 
 type systemEnvInjector struct {
@@ -82,7 +83,48 @@ func (s *systemEnvInjector) GetVcapService(appName string, binding *servicecatal
 		return VcapService{}, nil
 	}
 
-	return NewVcapService(*serviceInstance, *binding, secret), nil
+	class, err := s.getServiceClass(serviceInstance)
+	if err != nil {
+		return VcapService{}, fmt.Errorf("couldn't create VCAP_SERVICES, the class of service instance %s couldn't be fetched: %v", serviceInstance.Name, err)
+	}
+
+	return NewVcapService(*serviceInstance, class, *binding, secret), nil
+}
+
+// getServiceClass gets the spec of the ServiceClass or ClusterServiceClass of
+// the instance. Nil is returned if the instance doesn't reference a class or
+// the class was removed.
+func (s *systemEnvInjector) getServiceClass(instance *servicecatalogv1beta1.ServiceInstance) (*servicecatalogv1beta1.CommonServiceClassSpec, error) {
+	switch {
+	case instance.Spec.ServiceClassRef != nil:
+		class, err := s.client.
+			ServicecatalogV1beta1().
+			ServiceClasses(instance.Namespace).
+			Get(instance.Spec.ServiceClassRef.Name, metav1.GetOptions{})
+		switch {
+		case apierrs.IsNotFound(err):
+			return nil, nil
+		case err != nil:
+			return nil, err
+		}
+		return &class.Spec.CommonServiceClassSpec, nil
+
+	case instance.Spec.ClusterServiceClassRef != nil:
+		class, err := s.client.
+			ServicecatalogV1beta1().
+			ClusterServiceClasses().
+			Get(instance.Spec.ClusterServiceClassRef.Name, metav1.GetOptions{})
+		switch {
+		case apierrs.IsNotFound(err):
+			return nil, nil
+		case err != nil:
+			return nil, err
+		}
+		return &class.Spec.CommonServiceClassSpec, nil
+
+	default:
+		return nil, nil
+	}
 }
 
 func (s *systemEnvInjector) GetVcapServices(appName string, bindings []servicecatalogv1beta1.ServiceBinding) (services []VcapService, err error) {
@@ -97,24 +139,59 @@ func (s *systemEnvInjector) GetVcapServices(appName string, bindings []serviceca
 	return services, nil
 }
 
-func (s *systemEnvInjector) ComputeSystemEnv(app *v1alpha1.App, serviceBindings []servicecatalogv1beta1.ServiceBinding) (computed []corev1.EnvVar, err error) {
-	va, err := CreateVcapApplication(app)
+// getUserProvidedVcapServices gets the VCAP_SERVICES entries for the App's
+// bindings to user-provided service instances. User-provided instances don't
+// get a service catalog binding, their secret is read directly instead.
+func (s *systemEnvInjector) getUserProvidedVcapServices(app *v1alpha1.App) (services []VcapService, err error) {
+	for _, binding := range app.Spec.ServiceBindings {
+		secret, err := s.k8sclient.
+			CoreV1().
+			Secrets(app.Namespace).
+			Get(UserProvidedServiceSecretName(binding.Instance), metav1.GetOptions{})
+		switch {
+		case apierrs.IsNotFound(err):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("couldn't create VCAP_SERVICES, the user-provided service %s couldn't be fetched: %v", binding.Instance, err)
+		case !IsUserProvidedServiceSecret(secret):
+			continue
+		}
+
+		ups, err := ParseUserProvidedServiceSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		services = append(services, NewUserProvidedVcapService(binding, *ups))
+	}
+
+	return services, nil
+}
+
+func (s *systemEnvInjector) ComputeSystemEnv(app *v1alpha1.App, space *v1alpha1.Space, serviceBindings []servicecatalogv1beta1.ServiceBinding) (computed []corev1.EnvVar, err error) {
+	va, err := CreateVcapApplication(app, space)
 	if err != nil {
 		return nil, err
 	}
-	computed = append(computed, va)
+	computed = append(computed, va, CreateVcapApplicationFields(va))
 
 	services, err := s.GetVcapServices(app.Name, serviceBindings)
 	if err != nil {
 		return nil, err
 	}
 
+	userProvided, err := s.getUserProvidedVcapServices(app)
+	if err != nil {
+		return nil, err
+	}
+	services = append(services, userProvided...)
+
 	serviceMap, err := GetVcapServicesMap(app.Name, services)
 	if err != nil {
 		return nil, err
 	}
 
-	vsVar, err := envutil.NewJSONEnvVar("VCAP_SERVICES", serviceMap)
+	vsVar, err := envutil.NewJSONEnvVar(VcapServicesEnvVarName, serviceMap)
 	if err != nil {
 		return nil, err
 	}
@@ -15,6 +15,7 @@
 package cfutil_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -24,6 +25,7 @@ import (
 	servicecatalogv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
@@ -34,6 +36,12 @@ var (
 		},
 	}
 
+	space = &v1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-space",
+		},
+	}
+
 	serviceInstance = &servicecatalogv1beta1.ServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "my-instance",
@@ -87,7 +95,7 @@ func Test_GetVcapServices(t *testing.T) {
 				testutil.AssertEqual(t, "label", "my-class", vcapService.Label)
 				testutil.AssertEqual(t, "tags", []string{}, vcapService.Tags)
 				testutil.AssertEqual(t, "plan", "my-plan", vcapService.Plan)
-				testutil.AssertEqual(t, "credentials", map[string]string{}, vcapService.Credentials)
+				testutil.AssertEqual(t, "credentials", map[string]interface{}{}, vcapService.Credentials)
 				testutil.AssertEqual(t, "binding name", "my-binding-name", vcapService.BindingName)
 			},
 		},
@@ -98,6 +106,109 @@ func Test_GetVcapServices(t *testing.T) {
 	}
 }
 
+func TestSystemEnvInjector_serviceClass(t *testing.T) {
+	t.Parallel()
+
+	class := &servicecatalogv1beta1.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-class-id",
+		},
+		Spec: servicecatalogv1beta1.ClusterServiceClassSpec{
+			CommonServiceClassSpec: servicecatalogv1beta1.CommonServiceClassSpec{
+				ExternalName: "my-class",
+				Tags:         []string{"mysql", "relational"},
+				ExternalMetadata: &runtime.RawExtension{
+					Raw: []byte(`{"providerDisplayName":"Example Corp"}`),
+				},
+			},
+		},
+	}
+
+	instance := serviceInstance.DeepCopy()
+	instance.Spec.ClusterServiceClassRef = &servicecatalogv1beta1.ClusterObjectReference{
+		Name: "my-class-id",
+	}
+
+	bindingSecret := secret.DeepCopy()
+	bindingSecret.Data = map[string][]byte{
+		"password": []byte("12345"),
+		"hosts":    []byte(`["db-0.example.com", "db-1.example.com"]`),
+		"uri":      []byte(`{"scheme": "mysql", "port": 3306}`),
+	}
+
+	binding := serviceBinding.DeepCopy()
+	binding.Spec.SecretName = bindingSecret.Name
+
+	servicecatalogClient := servicecatalogclient.NewSimpleClientset(instance, class)
+	k8sClient := k8sfake.NewSimpleClientset(bindingSecret)
+	systemEnvInjector := cfutil.NewSystemEnvInjector(servicecatalogClient, k8sClient)
+
+	vcapService, err := systemEnvInjector.GetVcapService(app.Name, binding)
+	testutil.AssertNil(t, "error", err)
+	testutil.AssertEqual(t, "tags", []string{"mysql", "relational"}, vcapService.Tags)
+	testutil.AssertNotNil(t, "provider", vcapService.Provider)
+	testutil.AssertEqual(t, "provider", "Example Corp", *vcapService.Provider)
+	testutil.AssertEqual(t, "credentials", map[string]interface{}{
+		"password": "12345",
+		"hosts":    []interface{}{"db-0.example.com", "db-1.example.com"},
+		"uri":      map[string]interface{}{"scheme": "mysql", "port": float64(3306)},
+	}, vcapService.Credentials)
+
+	encoded, err := json.Marshal(vcapService)
+	testutil.AssertNil(t, "marshal err", err)
+	testutil.AssertContainsAll(t, string(encoded), []string{
+		`"syslog_drain_url":null`,
+		`"volume_mounts":[]`,
+		`"provider":"Example Corp"`,
+	})
+}
+
+func TestSystemEnvInjector_userProvided(t *testing.T) {
+	t.Parallel()
+
+	upsSecret, err := cfutil.MakeUserProvidedServiceSecret("my-ns", cfutil.UserProvidedService{
+		Name:        "my-ups",
+		Credentials: map[string]interface{}{"uri": "https://example.com"},
+		Tags:        []string{"my-tag"},
+	})
+	testutil.AssertNil(t, "err", err)
+
+	servicecatalogClient := servicecatalogclient.NewSimpleClientset()
+	k8sClient := k8sfake.NewSimpleClientset(upsSecret)
+	systemEnvInjector := cfutil.NewSystemEnvInjector(servicecatalogClient, k8sClient)
+
+	upsApp := app.DeepCopy()
+	upsApp.Namespace = "my-ns"
+	upsApp.Spec.ServiceBindings = []v1alpha1.AppSpecServiceBinding{
+		{Instance: "my-ups", BindingName: "my-ups-binding"},
+		{Instance: "brokered-instance", BindingName: "brokered-instance"},
+	}
+
+	env, err := systemEnvInjector.ComputeSystemEnv(upsApp, space, nil)
+	testutil.AssertNil(t, "err", err)
+
+	var vcapServices cfutil.VcapServicesMap
+	for _, envVar := range env {
+		if envVar.Name == "VCAP_SERVICES" {
+			testutil.AssertNil(t, "unmarshal err", json.Unmarshal([]byte(envVar.Value), &vcapServices))
+		}
+	}
+
+	testutil.AssertEqual(t, "VCAP_SERVICES", cfutil.VcapServicesMap{
+		"user-provided": {
+			{
+				BindingName:  "my-ups-binding",
+				InstanceName: "my-ups",
+				Name:         "my-ups-binding",
+				Label:        "user-provided",
+				Tags:         []string{"my-tag"},
+				Credentials:  map[string]interface{}{"uri": "https://example.com"},
+				VolumeMounts: []cfutil.VcapVolumeMount{},
+			},
+		},
+	}, vcapServices)
+}
+
 func TestSystemEnvInjector(t *testing.T) {
 	t.Parallel()
 
@@ -112,15 +223,19 @@ func TestSystemEnvInjector(t *testing.T) {
 		"happy": {
 			Run: func(t *testing.T, systemEnvInjector cfutil.SystemEnvInjector) {
 
-				env, err := systemEnvInjector.ComputeSystemEnv(app, []servicecatalogv1beta1.ServiceBinding{*serviceBinding})
+				env, err := systemEnvInjector.ComputeSystemEnv(app, space, []servicecatalogv1beta1.ServiceBinding{*serviceBinding})
 				testutil.AssertNil(t, "error", err)
-				testutil.AssertEqual(t, "env count", 2, len(env))
+				testutil.AssertEqual(t, "env count", 3, len(env))
 				hasVcapApplication := false
+				hasVcapApplicationFields := false
 				hasVcapServices := false
 				for _, envVar := range env {
 					if envVar.Name == "VCAP_APPLICATION" {
 						hasVcapApplication = true
 					}
+					if envVar.Name == "KF_VCAP_APPLICATION_FIELDS" {
+						hasVcapApplicationFields = true
+					}
 					if envVar.Name == "VCAP_SERVICES" {
 						hasVcapServices = true
 					}
@@ -134,6 +249,10 @@ func TestSystemEnvInjector(t *testing.T) {
 				if !hasVcapApplication {
 					t.Fatal("Expected map to contain VCAP_APPLICATION")
 				}
+
+				if !hasVcapApplicationFields {
+					t.Fatal("Expected map to contain KF_VCAP_APPLICATION_FIELDS")
+				}
 			},
 		},
 	}
@@ -0,0 +1,171 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfutil
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// UserProvidedServiceLabel is the label put on secrets that back a
+	// user-provided service instance. The value is the name of the instance.
+	UserProvidedServiceLabel = "kf.dev/user-provided-service"
+
+	// UserProvidedServiceVcapLabel is the label user-provided services are
+	// listed under in VCAP_SERVICES.
+	UserProvidedServiceVcapLabel = "user-provided"
+
+	userProvidedCredentialsKey     = "credentials"
+	userProvidedTagsKey            = "tags"
+	userProvidedSyslogDrainURLKey  = "syslog_drain_url"
+	userProvidedRouteServiceURLKey = "route_service_url"
+)
+
+// UserProvidedService is a service instance that isn't backed by a broker.
+// Its credentials are supplied directly by the user, see
+// https://docs.cloudfoundry.org/devguide/services/user-provided.html
+type UserProvidedService struct {
+	// Name is the name of the service instance.
+	Name string
+
+	// Credentials are arbitrary JSON values handed to bound apps.
+	Credentials map[string]interface{}
+
+	// Tags are used by apps to identify the service instance.
+	Tags []string
+
+	// SyslogDrainURL is the URL bound apps should drain logs to.
+	SyslogDrainURL string
+
+	// RouteServiceURL is the URL of a route service for bound routes.
+	RouteServiceURL string
+}
+
+// UserProvidedServiceSecretName gets the name of the secret that backs the
+// user-provided service instance with the given name.
+func UserProvidedServiceSecretName(instanceName string) string {
+	return fmt.Sprintf("user-provided-service-%s", instanceName)
+}
+
+// MakeUserProvidedServiceSecret creates a secret in the namespace holding the
+// user-provided service.
+func MakeUserProvidedServiceSecret(namespace string, ups UserProvidedService) (*corev1.Secret, error) {
+	credentials := ups.Credentials
+	if credentials == nil {
+		credentials = map[string]interface{}{}
+	}
+
+	credentialsJSON, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode credentials: %v", err)
+	}
+
+	tags := ups.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode tags: %v", err)
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      UserProvidedServiceSecretName(ups.Name),
+			Namespace: namespace,
+			Labels: map[string]string{
+				UserProvidedServiceLabel: ups.Name,
+				v1alpha1.ManagedByLabel:  "kf",
+			},
+		},
+		Data: map[string][]byte{
+			userProvidedCredentialsKey:     credentialsJSON,
+			userProvidedTagsKey:            tagsJSON,
+			userProvidedSyslogDrainURLKey:  []byte(ups.SyslogDrainURL),
+			userProvidedRouteServiceURLKey: []byte(ups.RouteServiceURL),
+		},
+	}, nil
+}
+
+// IsUserProvidedServiceSecret returns true if the secret backs a
+// user-provided service instance.
+func IsUserProvidedServiceSecret(secret *corev1.Secret) bool {
+	_, ok := secret.Labels[UserProvidedServiceLabel]
+	return ok
+}
+
+// ParseUserProvidedServiceSecret reads a user-provided service out of the
+// secret that backs it.
+func ParseUserProvidedServiceSecret(secret *corev1.Secret) (*UserProvidedService, error) {
+	if !IsUserProvidedServiceSecret(secret) {
+		return nil, fmt.Errorf("secret %s doesn't back a user-provided service", secret.Name)
+	}
+
+	ups := &UserProvidedService{
+		Name:            secret.Labels[UserProvidedServiceLabel],
+		Credentials:     map[string]interface{}{},
+		SyslogDrainURL:  string(secret.Data[userProvidedSyslogDrainURLKey]),
+		RouteServiceURL: string(secret.Data[userProvidedRouteServiceURLKey]),
+	}
+
+	if raw := secret.Data[userProvidedCredentialsKey]; len(raw) > 0 {
+		if err := json.Unmarshal(raw, &ups.Credentials); err != nil {
+			return nil, fmt.Errorf("couldn't parse credentials of user-provided service %s: %v", ups.Name, err)
+		}
+	}
+
+	if raw := secret.Data[userProvidedTagsKey]; len(raw) > 0 {
+		if err := json.Unmarshal(raw, &ups.Tags); err != nil {
+			return nil, fmt.Errorf("couldn't parse tags of user-provided service %s: %v", ups.Name, err)
+		}
+	}
+
+	return ups, nil
+}
+
+// NewUserProvidedVcapService creates a VcapService for an App's binding to a
+// user-provided service instance.
+func NewUserProvidedVcapService(binding v1alpha1.AppSpecServiceBinding, ups UserProvidedService) VcapService {
+	vs := VcapService{
+		BindingName:    binding.BindingName,
+		Name:           binding.BindingName,
+		InstanceName:   ups.Name,
+		Label:          UserProvidedServiceVcapLabel,
+		Tags:           ups.Tags,
+		Credentials:    ups.Credentials,
+		SyslogDrainURL: optionalString(ups.SyslogDrainURL),
+		VolumeMounts:   []VcapVolumeMount{},
+	}
+
+	if vs.Tags == nil {
+		vs.Tags = []string{}
+	}
+
+	if vs.Credentials == nil {
+		vs.Credentials = map[string]interface{}{}
+	}
+
+	return vs
+}
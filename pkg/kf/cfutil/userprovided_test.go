@@ -0,0 +1,120 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfutil_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleNewUserProvidedVcapService() {
+	ups := cfutil.UserProvidedService{
+		Name: "my-db",
+		Credentials: map[string]interface{}{
+			"uri":  "postgres://db.example.com",
+			"port": 5432,
+		},
+		Tags:           []string{"postgres"},
+		SyslogDrainURL: "syslog://logs.example.com",
+	}
+
+	binding := v1alpha1.AppSpecServiceBinding{
+		Instance:    "my-db",
+		BindingName: "db",
+	}
+
+	vs := cfutil.NewUserProvidedVcapService(binding, ups)
+
+	fmt.Printf("Name: %s\n", vs.Name)
+	fmt.Printf("InstanceName: %s\n", vs.InstanceName)
+	fmt.Printf("Label: %s\n", vs.Label)
+	fmt.Printf("Tags: %v\n", vs.Tags)
+	fmt.Printf("Credentials: %v\n", vs.Credentials)
+	fmt.Printf("SyslogDrainURL: %s\n", *vs.SyslogDrainURL)
+
+	// Output: Name: db
+	// InstanceName: my-db
+	// Label: user-provided
+	// Tags: [postgres]
+	// Credentials: map[port:5432 uri:postgres://db.example.com]
+	// SyslogDrainURL: syslog://logs.example.com
+}
+
+func TestMakeUserProvidedServiceSecret(t *testing.T) {
+	t.Parallel()
+
+	ups := cfutil.UserProvidedService{
+		Name: "my-service",
+		Credentials: map[string]interface{}{
+			"username": "admin",
+			"nested":   map[string]interface{}{"key": "value"},
+		},
+		Tags:            []string{"a", "b"},
+		SyslogDrainURL:  "syslog://example.com",
+		RouteServiceURL: "https://route-service.example.com",
+	}
+
+	secret, err := cfutil.MakeUserProvidedServiceSecret("my-ns", ups)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "name", "user-provided-service-my-service", secret.Name)
+	testutil.AssertEqual(t, "namespace", "my-ns", secret.Namespace)
+	testutil.AssertEqual(t, "label", "my-service", secret.Labels[cfutil.UserProvidedServiceLabel])
+	testutil.AssertEqual(t, "is user-provided", true, cfutil.IsUserProvidedServiceSecret(secret))
+
+	parsed, err := cfutil.ParseUserProvidedServiceSecret(secret)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "round trip", ups, *parsed)
+}
+
+func TestParseUserProvidedServiceSecret(t *testing.T) {
+	t.Parallel()
+
+	upsLabels := map[string]string{cfutil.UserProvidedServiceLabel: "my-service"}
+
+	cases := map[string]struct {
+		secret      corev1.Secret
+		expectedErr error
+	}{
+		"missing label": {
+			secret:      corev1.Secret{},
+			expectedErr: fmt.Errorf("secret  doesn't back a user-provided service"),
+		},
+		"bad credentials": {
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Labels: upsLabels},
+				Data:       map[string][]byte{"credentials": []byte("not-json")},
+			},
+			expectedErr: fmt.Errorf("couldn't parse credentials of user-provided service my-service: invalid character 'o' in literal null (expecting 'u')"),
+		},
+		"empty data": {
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Labels: upsLabels},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			_, err := cfutil.ParseUserProvidedServiceSecret(&tc.secret)
+			testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+		})
+	}
+}
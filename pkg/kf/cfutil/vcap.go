@@ -15,6 +15,10 @@
 package cfutil
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
 	apiv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -24,6 +28,13 @@ const (
 	BindingNameLabel = "kf-binding-name"
 	// AppNameLabel is the label used on bindings to define which app the binding belongs to.
 	AppNameLabel = "kf-app-name"
+	// VcapServicesEnvVarName is the environment variable expected by
+	// applications looking for CF style service credentials.
+	VcapServicesEnvVarName = "VCAP_SERVICES"
+	// ServiceInstanceTagsAnnotation holds the comma separated tags a user set
+	// on a service instance. They're listed in VCAP_SERVICES after the tags of
+	// the service offering.
+	ServiceInstanceTagsAnnotation = "kf.dev/service-instance-tags"
 )
 
 // VcapServicesMap mimics CF's VCAP_SERVICES environment variable.
@@ -43,18 +54,31 @@ func (vm VcapServicesMap) Add(service VcapService) {
 // VcapService represents a single entry in a VCAP_SERVICES map.
 // It holds the credentials for a single service binding.
 type VcapService struct {
-	BindingName  string            `json:"binding_name"`  // The name assigned to the service binding by the user.
-	InstanceName string            `json:"instance_name"` // The name assigned to the service instance by the user.
-	Name         string            `json:"name"`          // The binding_name if it exists; otherwise the instance_name.
-	Label        string            `json:"label"`         // The name of the service offering.
-	Tags         []string          `json:"tags"`          // An array of strings an app can use to identify a service instance.
-	Plan         string            `json:"plan"`          // The service plan selected when the service instance was created.
-	Credentials  map[string]string `json:"credentials"`   // The service-specific credentials needed to access the service instance.
+	BindingName    string                 `json:"binding_name"`     // The name assigned to the service binding by the user.
+	InstanceName   string                 `json:"instance_name"`    // The name assigned to the service instance by the user.
+	Name           string                 `json:"name"`             // The binding_name if it exists; otherwise the instance_name.
+	Label          string                 `json:"label"`            // The name of the service offering.
+	Tags           []string               `json:"tags"`             // An array of strings an app can use to identify a service instance.
+	Plan           string                 `json:"plan"`             // The service plan selected when the service instance was created.
+	Credentials    map[string]interface{} `json:"credentials"`      // The service-specific credentials needed to access the service instance.
+	Provider       *string                `json:"provider"`         // The provider of the service offering, null if the broker doesn't name one.
+	SyslogDrainURL *string                `json:"syslog_drain_url"` // The URL logs are drained to, null if logs aren't drained.
+	VolumeMounts   []VcapVolumeMount      `json:"volume_mounts"`    // The volumes mounted for the binding.
+}
+
+// VcapVolumeMount is a volume mounted into the App for a volume service
+// binding. Kf doesn't support volume services so the list is always empty,
+// it's kept so VCAP_SERVICES has the same shape as in Cloud Foundry.
+type VcapVolumeMount struct {
+	ContainerDir string `json:"container_dir"` // The directory the volume is mounted on.
+	DeviceType   string `json:"device_type"`   // The type of the volume, always shared.
+	Mode         string `json:"mode"`          // Either r or rw.
 }
 
 // NewVcapService creates a new VcapService given a binding and associated
-// secret.
-func NewVcapService(instance apiv1beta1.ServiceInstance, binding apiv1beta1.ServiceBinding, secret *corev1.Secret) VcapService {
+// secret. The class is the (Cluster)ServiceClass of the instance, it can be
+// nil if the class couldn't be found.
+func NewVcapService(instance apiv1beta1.ServiceInstance, class *apiv1beta1.CommonServiceClassSpec, binding apiv1beta1.ServiceBinding, secret *corev1.Secret) VcapService {
 	// See the cloud-controller-ng source for how this is supposed to be built
 	// being that it doesn't seem to be formally fully documented anywhere:
 	// https://github.com/cloudfoundry/cloud_controller_ng/blob/65a75e6c97f49756df96e437e253f033415b2db1/app/presenters/system_environment/service_binding_presenter.rb#L32
@@ -64,7 +88,9 @@ func NewVcapService(instance apiv1beta1.ServiceInstance, binding apiv1beta1.Serv
 		InstanceName: binding.Spec.InstanceRef.Name,
 		Label:        instance.Spec.ClusterServiceClassExternalName,
 		Plan:         instance.Spec.ClusterServicePlanExternalName,
-		Credentials:  make(map[string]string),
+		Tags:         []string{},
+		Credentials:  make(map[string]interface{}),
+		VolumeMounts: []VcapVolumeMount{},
 	}
 
 	// Make sure we can work with both ServiceClass and ClusterServiceClass
@@ -76,15 +102,83 @@ func NewVcapService(instance apiv1beta1.ServiceInstance, binding apiv1beta1.Serv
 		vs.Plan = instance.Spec.ServicePlanExternalName
 	}
 
-	// TODO(josephlewis42) we need to get tags from the (Cluster)ServiceClass
-	// this could be aided by the BindingParentHierarchy function in the
-	// service catalog SDK.
+	if class != nil {
+		vs.Tags = append(vs.Tags, class.Tags...)
+		vs.Provider = serviceClassProvider(class)
+	}
+	vs.Tags = appendInstanceTags(vs.Tags, instance)
 
-	// Credentials are stored by the service catalog in a flat map, the data
-	// values are just strings.
+	// Credentials are stored by the service catalog in a flat map, nested
+	// values are kept as their JSON encoding.
 	for sn, sd := range secret.Data {
-		vs.Credentials[sn] = string(sd)
+		vs.Credentials[sn] = decodeCredential(sd)
 	}
 
 	return vs
 }
+
+// appendInstanceTags appends the tags the user set on the instance to tags,
+// skipping any that are already listed.
+func appendInstanceTags(tags []string, instance apiv1beta1.ServiceInstance) []string {
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		seen[tag] = true
+	}
+
+	for _, tag := range strings.Split(instance.Annotations[ServiceInstanceTagsAnnotation], ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// decodeCredential converts a value of a binding secret into its JSON value
+// if it holds a JSON object or array. Other values are kept as strings so
+// passwords that look like numbers aren't changed.
+func decodeCredential(data []byte) interface{} {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return string(data)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(trimmed, &value); err != nil {
+		return string(data)
+	}
+
+	return value
+}
+
+// serviceClassProvider gets the provider of the service offering from the
+// providerDisplayName of the class's metadata, see
+// https://github.com/openservicebrokerapi/servicebroker/blob/master/profile.md#service-metadata
+func serviceClassProvider(class *apiv1beta1.CommonServiceClassSpec) *string {
+	if class.ExternalMetadata == nil {
+		return nil
+	}
+
+	var metadata struct {
+		ProviderDisplayName string `json:"providerDisplayName"`
+	}
+	if err := json.Unmarshal(class.ExternalMetadata.Raw, &metadata); err != nil {
+		return nil
+	}
+
+	return optionalString(metadata.ProviderDisplayName)
+}
+
+// optionalString returns a pointer to the string or nil if it's empty so it's
+// encoded as null.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -42,6 +42,9 @@ func ExampleNewVcapService() {
 	instance.Name = "my-instance"
 	instance.Spec.ServiceClassExternalName = "my-service"
 	instance.Spec.ServicePlanExternalName = "my-service-plan"
+	instance.Annotations = map[string]string{
+		cfutil.ServiceInstanceTagsAnnotation: "my-tag, user-tag",
+	}
 
 	binding := apiv1beta1.ServiceBinding{}
 	binding.Spec.InstanceRef.Name = "my-instance"
@@ -50,13 +53,17 @@ func ExampleNewVcapService() {
 		cfutil.BindingNameLabel: "custom-binding-name",
 	}
 
+	class := apiv1beta1.CommonServiceClassSpec{}
+	class.Tags = []string{"my-tag"}
+
 	secret := corev1.Secret{}
 	secret.Data = map[string][]byte{
-		"key1": []byte("value1"),
-		"key2": []byte("value2"),
+		"key1":  []byte("value1"),
+		"key2":  []byte("value2"),
+		"hosts": []byte(`["a.example.com", "b.example.com"]`),
 	}
 
-	vs := cfutil.NewVcapService(instance, binding, &secret)
+	vs := cfutil.NewVcapService(instance, &class, binding, &secret)
 
 	fmt.Printf("Name: %s\n", vs.Name)
 	fmt.Printf("InstanceName: %s\n", vs.InstanceName)
@@ -64,11 +71,13 @@ func ExampleNewVcapService() {
 	fmt.Printf("Credentials: %v\n", vs.Credentials)
 	fmt.Printf("Service: %v\n", vs.Label)
 	fmt.Printf("Plan: %v\n", vs.Plan)
+	fmt.Printf("Tags: %v\n", vs.Tags)
 
 	// Output: Name: my-binding
 	// InstanceName: my-instance
 	// BindingName: custom-binding-name
-	// Credentials: map[key1:value1 key2:value2]
+	// Credentials: map[hosts:[a.example.com b.example.com] key1:value1 key2:value2]
 	// Service: my-service
 	// Plan: my-service-plan
+	// Tags: [my-tag user-tag]
 }
@@ -15,6 +15,9 @@
 package cfutil
 
 import (
+	"fmt"
+	"strings"
+
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
 	corev1 "k8s.io/api/core/v1"
@@ -24,23 +27,166 @@ const (
 	// VcapApplicationEnvVarName is the environment variable expected by
 	// applications looking for CF style app environment info.
 	VcapApplicationEnvVarName = "VCAP_APPLICATION"
+
+	// VcapApplicationFieldsEnvVarName holds the fields of VCAP_APPLICATION
+	// that are the same for every instance, without the enclosing braces, so
+	// containers can combine them with the fields of their own instance.
+	VcapApplicationFieldsEnvVarName = "KF_VCAP_APPLICATION_FIELDS"
+
+	// CFInstanceGUIDEnvVarName holds the UID of the Pod running the instance.
+	CFInstanceGUIDEnvVarName = "CF_INSTANCE_GUID"
+
+	// InstanceGUIDEnvVarName is the legacy name of CFInstanceGUIDEnvVarName.
+	InstanceGUIDEnvVarName = "INSTANCE_GUID"
+
+	// CFInstanceIPEnvVarName holds the IP of the Pod running the instance.
+	CFInstanceIPEnvVarName = "CF_INSTANCE_IP"
+
+	// CFInstanceInternalIPEnvVarName holds the IP of the Pod running the
+	// instance, Pods are reached on the same IP inside and outside the
+	// cluster network.
+	CFInstanceInternalIPEnvVarName = "CF_INSTANCE_INTERNAL_IP"
+
+	// CFInstanceIndexEnvVarName holds the ordinal of the instance, see
+	// v1alpha1.InstanceIndexAnnotation.
+	CFInstanceIndexEnvVarName = "CF_INSTANCE_INDEX"
+
+	// InstanceIndexEnvVarName is the legacy name of CFInstanceIndexEnvVarName.
+	InstanceIndexEnvVarName = "INSTANCE_INDEX"
+
+	// RevisionNameEnvVarName holds the name of the Knative revision the
+	// instance runs, it's empty for the App's other processes.
+	RevisionNameEnvVarName = "KF_REVISION_NAME"
+
+	// defaultFileDescriptorLimit is the file descriptor limit reported to
+	// Apps, it matches Cloud Foundry's default.
+	defaultFileDescriptorLimit = 16384
 )
 
 // CreateVcapApplication creates a VCAP_APPLICATION style environment variable
-// based on the values on the given service.
-func CreateVcapApplication(app *v1alpha1.App) (corev1.EnvVar, error) {
+// with the fields that are the same for every instance of the App. The
+// fields that differ between instances are added by the environment
+// variables from InstanceEnv.
+func CreateVcapApplication(app *v1alpha1.App, space *v1alpha1.Space) (corev1.EnvVar, error) {
 	// You can find a list of values here:
 	// https://docs.run.pivotal.io/devguide/deploy-apps/environment-variable.html
+	uris := vcapApplicationURIs(app)
 
-	// XXX: The values here are incomplete but are currently the best we can do.
 	values := map[string]interface{}{
+		// application_id GUID identifying the app.
+		"application_id": app.UID,
 		// application_name The name assigned to the app when it was pushed.
 		"application_name": app.Name,
+		// application_uris The URIs assigned to the app.
+		"application_uris": uris,
+		// limits The limits to disk space, number of files, and memory
+		// permitted to the app.
+		"limits": vcapApplicationLimits(app),
 		// name Identical to application_name.
 		"name": app.Name,
+		// space_id GUID identifying the app's space.
+		"space_id": space.UID,
 		// space_name	Human-readable name of the space where the app is deployed.
 		"space_name": app.Namespace,
+		// uris Identical to application_uris.
+		"uris": uris,
 	}
 
 	return envutil.NewJSONEnvVar(VcapApplicationEnvVarName, values)
 }
+
+// CreateVcapApplicationFields converts a VCAP_APPLICATION environment variable
+// into the fields that are combined with the fields of each instance by
+// InstanceEnv.
+func CreateVcapApplicationFields(vcapApplication corev1.EnvVar) corev1.EnvVar {
+	fields := strings.TrimSpace(vcapApplication.Value)
+	fields = strings.TrimPrefix(fields, "{")
+	fields = strings.TrimSuffix(fields, "}")
+
+	return corev1.EnvVar{Name: VcapApplicationFieldsEnvVarName, Value: fields}
+}
+
+// InstanceEnv returns the environment variables that describe the instance a
+// container runs in, the CF_INSTANCE_* variables and the fields of each
+// instance in VCAP_APPLICATION. The values come from the downward API and
+// are combined with the App's fields when the container starts, so the
+// variables must come after the environment from the App's injected secret
+// and in the given order.
+func InstanceEnv() []corev1.EnvVar {
+	fieldRef := func(name, path string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: path},
+			},
+		}
+	}
+
+	// Kubernetes expands $(VAR) references to variables defined earlier in
+	// the container's environment, including the ones from the secret.
+	vcapApplication := fmt.Sprintf(
+		`{"application_version":"$(%[1]s)","host":"0.0.0.0","instance_id":"$(%[2]s)","instance_index":$(%[3]s),"version":"$(%[1]s)",$(%[4]s)}`,
+		RevisionNameEnvVarName,
+		CFInstanceGUIDEnvVarName,
+		CFInstanceIndexEnvVarName,
+		VcapApplicationFieldsEnvVarName,
+	)
+
+	return []corev1.EnvVar{
+		fieldRef(CFInstanceGUIDEnvVarName, "metadata.uid"),
+		fieldRef(CFInstanceIPEnvVarName, "status.podIP"),
+		fieldRef(CFInstanceInternalIPEnvVarName, "status.podIP"),
+		fieldRef(CFInstanceIndexEnvVarName, fmt.Sprintf("metadata.annotations['%s']", v1alpha1.InstanceIndexAnnotation)),
+		fieldRef(RevisionNameEnvVarName, "metadata.labels['serving.knative.dev/revision']"),
+		{Name: InstanceGUIDEnvVarName, Value: fmt.Sprintf("$(%s)", CFInstanceGUIDEnvVarName)},
+		{Name: InstanceIndexEnvVarName, Value: fmt.Sprintf("$(%s)", CFInstanceIndexEnvVarName)},
+		{Name: VcapApplicationEnvVarName, Value: vcapApplication},
+	}
+}
+
+// vcapApplicationURIs returns the addresses of the App's routes followed by
+// its cluster address.
+func vcapApplicationURIs(app *v1alpha1.App) []string {
+	uris := []string{}
+	seen := make(map[string]bool)
+	add := func(uri string) {
+		if uri != "" && !seen[uri] {
+			seen[uri] = true
+			uris = append(uris, uri)
+		}
+	}
+
+	for _, route := range app.Spec.Routes {
+		add(strings.TrimSuffix(route.String(), "/"))
+	}
+
+	if app.Status.URL != nil {
+		add(app.Status.URL.Host)
+	}
+
+	return uris
+}
+
+// vcapApplicationLimits returns the resources requested by the App's
+// container in the units Cloud Foundry uses, memory and disk are in MB.
+func vcapApplicationLimits(app *v1alpha1.App) map[string]int64 {
+	limits := map[string]int64{
+		"fds": defaultFileDescriptorLimit,
+	}
+
+	containers := app.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return limits
+	}
+
+	requests := containers[0].Resources.Requests
+	if mem, ok := requests[corev1.ResourceMemory]; ok {
+		limits["mem"] = mem.Value() / (1024 * 1024)
+	}
+
+	if disk, ok := requests[corev1.ResourceEphemeralStorage]; ok {
+		limits["disk"] = disk.Value() / (1024 * 1024)
+	}
+
+	return limits
+}
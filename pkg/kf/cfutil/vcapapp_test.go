@@ -19,6 +19,9 @@ import (
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/cfutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/apis"
 )
 
 func ExampleCreateVcapApplication() {
@@ -26,12 +29,73 @@ func ExampleCreateVcapApplication() {
 	app.Name = "my-app"
 	app.Namespace = "my-ns"
 
-	env, err := cfutil.CreateVcapApplication(app)
+	space := &v1alpha1.Space{}
+	space.Name = "my-ns"
+
+	env, err := cfutil.CreateVcapApplication(app, space)
 	if err != nil {
 		panic(err)
 	}
 
 	fmt.Println("Name:", env.Name, "Value:", env.Value)
 
-	// Output: Name: VCAP_APPLICATION Value: {"application_name":"my-app","name":"my-app","space_name":"my-ns"}
+	// Output: Name: VCAP_APPLICATION Value: {"application_id":"","application_name":"my-app","application_uris":[],"limits":{"fds":16384},"name":"my-app","space_id":"","space_name":"my-ns","uris":[]}
+}
+
+func ExampleCreateVcapApplication_routesAndLimits() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Namespace = "my-ns"
+	app.UID = "app-uid"
+	app.Spec.Routes = []v1alpha1.RouteSpecFields{
+		{Hostname: "my-app", Domain: "example.com"},
+		{Domain: "example.com", Path: "/api"},
+	}
+	app.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("1Gi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+				},
+			},
+		},
+	}
+	app.Status.URL = &apis.URL{Scheme: "http", Host: "my-app.my-ns.example.com"}
+
+	space := &v1alpha1.Space{}
+	space.Name = "my-ns"
+	space.UID = "space-uid"
+
+	env, err := cfutil.CreateVcapApplication(app, space)
+	if err != nil {
+		panic(err)
+	}
+
+	fields := cfutil.CreateVcapApplicationFields(env)
+
+	fmt.Println("Name:", env.Name, "Value:", env.Value)
+	fmt.Println("Name:", fields.Name, "Value:", fields.Value)
+
+	// Output: Name: VCAP_APPLICATION Value: {"application_id":"app-uid","application_name":"my-app","application_uris":["my-app.example.com","example.com/api","my-app.my-ns.example.com"],"limits":{"disk":2048,"fds":16384,"mem":1024},"name":"my-app","space_id":"space-uid","space_name":"my-ns","uris":["my-app.example.com","example.com/api","my-app.my-ns.example.com"]}
+	// Name: KF_VCAP_APPLICATION_FIELDS Value: "application_id":"app-uid","application_name":"my-app","application_uris":["my-app.example.com","example.com/api","my-app.my-ns.example.com"],"limits":{"disk":2048,"fds":16384,"mem":1024},"name":"my-app","space_id":"space-uid","space_name":"my-ns","uris":["my-app.example.com","example.com/api","my-app.my-ns.example.com"]
+}
+
+func ExampleInstanceEnv() {
+	for _, env := range cfutil.InstanceEnv() {
+		if env.ValueFrom != nil {
+			fmt.Println(env.Name, "from", env.ValueFrom.FieldRef.FieldPath)
+		} else {
+			fmt.Println(env.Name, "=", env.Value)
+		}
+	}
+
+	// Output: CF_INSTANCE_GUID from metadata.uid
+	// CF_INSTANCE_IP from status.podIP
+	// CF_INSTANCE_INTERNAL_IP from status.podIP
+	// CF_INSTANCE_INDEX from metadata.annotations['kf.dev/instance-index']
+	// KF_REVISION_NAME from metadata.labels['serving.knative.dev/revision']
+	// INSTANCE_GUID = $(CF_INSTANCE_GUID)
+	// INSTANCE_INDEX = $(CF_INSTANCE_INDEX)
+	// VCAP_APPLICATION = {"application_version":"$(KF_REVISION_NAME)","host":"0.0.0.0","instance_id":"$(CF_INSTANCE_GUID)","instance_index":$(CF_INSTANCE_INDEX),"version":"$(KF_REVISION_NAME)",$(KF_VCAP_APPLICATION_FIELDS)}
 }
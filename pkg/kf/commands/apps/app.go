@@ -18,16 +18,21 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/apps"
 	"github.com/google/kf/pkg/kf/commands/completion"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/describe"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 // NewGetAppCommand creates a command to get details about a single application.
-func NewGetAppCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command {
+func NewGetAppCommand(p *config.KfParams, appsClient apps.Client, coreV1 corev1.CoreV1Interface) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:     "app APP_NAME",
 		Short:   "Print information about a deployed app",
@@ -49,6 +54,26 @@ func NewGetAppCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command
 				return err
 			}
 
+			// The App's status only summarizes its instances, the state of
+			// each one is read from its Pod.
+			pods, err := coreV1.Pods(p.Namespace).List(metav1.ListOptions{
+				LabelSelector: labels.SelectorFromSet(app.ComponentLabels("app-server")).String(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list instances: %s", err)
+			}
+
+			events, err := coreV1.Events(p.Namespace).List(metav1.ListOptions{
+				FieldSelector: fields.SelectorFromSet(fields.Set{
+					"involvedObject.kind": "App",
+					"involvedObject.name": app.Name,
+					"involvedObject.uid":  string(app.UID),
+				}).String(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list events: %s", err)
+			}
+
 			describe.ObjectMeta(w, app.ObjectMeta)
 			fmt.Fprintln(w)
 
@@ -58,6 +83,12 @@ func NewGetAppCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command
 			describe.AppSpecInstances(w, app.Spec.Instances)
 			fmt.Fprintln(w)
 
+			describe.AppInstancesStatus(w, app.Status.Instances, v1alpha1.DescribeInstances(pods.Items))
+			fmt.Fprintln(w)
+
+			describe.AppServiceCredentialsStatus(w, app.Status.ServiceCredentials, events.Items)
+			fmt.Fprintln(w)
+
 			describe.AppSpecTemplate(w, app.Spec.Template)
 			fmt.Fprintln(w)
 
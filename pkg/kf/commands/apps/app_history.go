@@ -0,0 +1,148 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/completion"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewAppHistoryCommand creates a command that lists the revisions of an app
+// that were deployed.
+func NewAppHistoryCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "app-history APP_NAME",
+		Short: "List the revisions of an app that were deployed",
+		Long: `Lists the revisions of an app that were deployed, oldest first.
+		Each revision shows the image that ran and the environment variables
+		that changed from the revision before it.
+
+		Any revision listed can be rolled back to with kf rollback.`,
+		Example: `kf app-history myapp`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			cmd.SilenceUsage = true
+
+			app, err := client.Get(p.Namespace, appName)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Getting history of app %s in space %s\n\n", appName, p.Namespace)
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Revision\tDeployed\tImage\tEnv Changes")
+
+				var previous []corev1.EnvVar
+				for i, revision := range app.Status.History {
+					env := revisionEnv(revision)
+
+					changes := "-"
+					if i > 0 {
+						changes = envChanges(previous, env)
+					}
+					previous = env
+
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
+						revision.Revision,
+						revision.DeployedAt.UTC().Format(time.RFC3339),
+						revision.Image,
+						changes,
+					)
+				}
+			})
+
+			return nil
+		},
+	}
+
+	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
+
+	return cmd
+}
+
+// revisionEnv gets the environment variables of a revision's app container.
+func revisionEnv(revision v1alpha1.AppRevision) []corev1.EnvVar {
+	if containers := revision.Template.Spec.Containers; len(containers) > 0 {
+		return containers[0].Env
+	}
+
+	return nil
+}
+
+// envChanges summarizes the names of environment variables that were added
+// (+), removed (-) or changed (~) between two revisions. Values aren't shown
+// because they may contain credentials.
+func envChanges(previous, current []corev1.EnvVar) string {
+	before := make(map[string]corev1.EnvVar)
+	for _, env := range previous {
+		before[env.Name] = env
+	}
+
+	after := make(map[string]corev1.EnvVar)
+	for _, env := range current {
+		after[env.Name] = env
+	}
+
+	var changes []string
+	for name, env := range after {
+		old, ok := before[name]
+		switch {
+		case !ok:
+			changes = append(changes, "+"+name)
+		case old.Value != env.Value || !reflect.DeepEqual(old.ValueFrom, env.ValueFrom):
+			changes = append(changes, "~"+name)
+		}
+	}
+
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changes = append(changes, "-"+name)
+		}
+	}
+
+	if len(changes) == 0 {
+		return "none"
+	}
+
+	// Sort by name rather than change type so related variables stay together.
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i][1:] < changes[j][1:]
+	})
+
+	return strings.Join(changes, ", ")
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAppHistory(t *testing.T) {
+	t.Parallel()
+
+	revision := func(n int, image string, env ...corev1.EnvVar) v1alpha1.AppRevision {
+		return v1alpha1.AppRevision{
+			Revision:   n,
+			Image:      image,
+			DeployedAt: metav1.NewTime(time.Date(2019, 7, n, 12, 0, 0, 0, time.UTC)),
+			Template: v1alpha1.AppSpecTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Env: env}},
+				},
+			},
+		}
+	}
+
+	app := &v1alpha1.App{}
+	app.Status.History = []v1alpha1.AppRevision{
+		revision(1, "gcr.io/app@sha256:1", corev1.EnvVar{Name: "A", Value: "1"}, corev1.EnvVar{Name: "B", Value: "1"}),
+		revision(2, "gcr.io/app@sha256:2", corev1.EnvVar{Name: "A", Value: "2"}, corev1.EnvVar{Name: "C", Value: "1"}),
+		revision(3, "gcr.io/app@sha256:3", corev1.EnvVar{Name: "A", Value: "2"}, corev1.EnvVar{Name: "C", Value: "1"}),
+	}
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"lists revisions": {
+			Namespace: "default",
+			Args:      []string{"my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get("default", "my-app").Return(app, nil)
+			},
+			ExpectedStrings: []string{
+				"Revision", "Deployed", "Image", "Env Changes",
+				"1", "2019-07-01T12:00:00Z", "gcr.io/app@sha256:1",
+				"2", "2019-07-02T12:00:00Z", "gcr.io/app@sha256:2", "~A, -B, +C",
+				"3", "2019-07-03T12:00:00Z", "gcr.io/app@sha256:3", "none",
+			},
+		},
+		"no app name": {
+			Namespace:   "default",
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"getting app fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := NewAppHistoryCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
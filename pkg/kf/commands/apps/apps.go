@@ -63,9 +63,16 @@ func NewAppsCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command {
 						requestedState = "deleting"
 					}
 
-					// Instances
+					// Instances, the running counts are shown once the App has
+					// been reconciled and the scaling settings before that.
 					var instances string
 					switch {
+					case app.Status.ObservedGeneration != 0:
+						instances = fmt.Sprintf(
+							"%d/%d",
+							app.Status.Instances.Ready,
+							app.Status.Instances.Desired,
+						)
 					case app.Spec.Instances.Exactly != nil:
 						instances = strconv.FormatInt(int64(*app.Spec.Instances.Exactly), 10)
 					case app.Spec.Instances.Min == nil && app.Spec.Instances.Max == nil:
@@ -193,6 +193,26 @@ func TestAppsCommand(t *testing.T) {
 				testutil.AssertContainsAll(t, buffer.String(), []string{header1, "app-a", "0 - 101"})
 			},
 		},
+		"shows app ready and desired instances": {
+			namespace: "some-namespace",
+			setup: func(t *testing.T, fakeLister *fake.FakeClient) {
+				app := v1alpha1.App{}
+				app.Name = "app-a"
+				app.Spec.Instances.Exactly = intPtr(3)
+				app.Status.ObservedGeneration = 1
+				app.Status.Instances.Desired = 3
+				app.Status.Instances.Ready = 2
+
+				fakeLister.
+					EXPECT().
+					List(gomock.Any()).
+					Return([]v1alpha1.App{app}, nil)
+			},
+			assert: func(t *testing.T, buffer *bytes.Buffer) {
+				header1 := "Getting apps in space "
+				testutil.AssertContainsAll(t, buffer.String(), []string{header1, "app-a", "2/3"})
+			},
+		},
 		"shows app urls": {
 			namespace: "some-namespace",
 			setup: func(t *testing.T, fakeLister *fake.FakeClient) {
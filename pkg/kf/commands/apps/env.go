@@ -34,7 +34,17 @@ func NewEnvCommand(p *config.KfParams, appClient apps.Client) *cobra.Command {
 		environment variables for an application.
 
 		This command does not include environment variables that are set by kf
-		such as VCAP_SERVICES or set by operators for all apps on the space.`,
+		or set by operators for all apps on the space. Kf provides the following
+		variables to every app:
+
+		* VCAP_APPLICATION: information about the app, its routes and the instance
+		* VCAP_SERVICES: credentials of the services bound to the app
+		* PORT: the port the app must listen on
+		* CF_INSTANCE_GUID and INSTANCE_GUID: the unique ID of the instance
+		* CF_INSTANCE_INDEX and INSTANCE_INDEX: the ordinal of the instance
+		* CF_INSTANCE_IP and CF_INSTANCE_INTERNAL_IP: the IP of the instance
+
+		The instance index is -1 if kf couldn't assign one when the instance was created.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := utils.ValidateNamespace(p); err != nil {
 				return err
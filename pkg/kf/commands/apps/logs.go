@@ -31,6 +31,7 @@ func NewLogsCommand(p *config.KfParams, tailer logs.Tailer) *cobra.Command {
 	var (
 		numberLines int
 		follow      bool
+		task        string
 	)
 	cmd := &cobra.Command{
 		Use:   "logs APP_NAME",
@@ -43,6 +44,9 @@ func NewLogsCommand(p *config.KfParams, tailer logs.Tailer) *cobra.Command {
 
 		# Follow/tail the log stream
 		kf logs myapp -f
+
+		# Get the logs of a task run against the app
+		kf logs myapp --task migrate-db
   `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -58,6 +62,7 @@ func NewLogsCommand(p *config.KfParams, tailer logs.Tailer) *cobra.Command {
 				logs.WithTailNamespace(p.Namespace),
 				logs.WithTailNumberLines(numberLines),
 				logs.WithTailFollow(follow),
+				logs.WithTailTask(task),
 			); err != nil {
 				cmd.SilenceUsage = !kfi.ConfigError(err)
 				return fmt.Errorf("failed to tail logs: %s", err)
@@ -83,6 +88,13 @@ func NewLogsCommand(p *config.KfParams, tailer logs.Tailer) *cobra.Command {
 		"Follow the log stream of the app.",
 	)
 
+	cmd.Flags().StringVar(
+		&task,
+		"task",
+		"",
+		"Show the logs of the named task instead of the app.",
+	)
+
 	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
 
 	return cmd
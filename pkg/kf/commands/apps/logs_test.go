@@ -77,7 +77,7 @@ func TestLogsCommand(t *testing.T) {
 		},
 		"uses configuration": {
 			Namespace: "some-namespace",
-			Args:      []string{"some-app", "-n=15", "-f"},
+			Args:      []string{"some-app", "-n=15", "-f", "--task=some-task"},
 			Setup: func(t *testing.T, fake *fake.FakeTailer) {
 				fake.EXPECT().
 					Tail(gomock.Not(gomock.Nil()), "some-app", gomock.Not(gomock.Nil()), gomock.Any()).
@@ -85,6 +85,7 @@ func TestLogsCommand(t *testing.T) {
 						testutil.AssertEqual(t, "namespace", "some-namespace", logs.TailOptions(opts).Namespace())
 						testutil.AssertEqual(t, "number lines", 15, logs.TailOptions(opts).NumberLines())
 						testutil.AssertEqual(t, "follow", true, logs.TailOptions(opts).Follow())
+						testutil.AssertEqual(t, "task", "some-task", logs.TailOptions(opts).Task())
 					})
 			},
 			Assert: func(t *testing.T, cmd *cobra.Command, err error) {
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -91,6 +92,7 @@ func NewPushCommand(
 		memoryRequest      *resource.Quantity
 		storageRequest     *resource.Quantity
 		cpuRequest         *resource.Quantity
+		strategy           string
 
 		// Route Flags
 		rawRoutes         []string
@@ -105,6 +107,7 @@ func NewPushCommand(
   kf push myapp
   kf push myapp --buildpack my.special.buildpack # Discover via kf buildpacks
   kf push myapp --env FOO=bar --env BAZ=foo
+  kf push myapp --strategy rolling
   `,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -157,6 +160,14 @@ func NewPushCommand(
 				appsToDeploy = []manifest.Application{*app}
 			}
 
+			// Knative Serving only runs a single container per App so
+			// sidecars are rejected before anything is pushed.
+			for _, app := range appsToDeploy {
+				if len(app.Sidecars) > 0 {
+					return fmt.Errorf("app %s declares sidecars, sidecars aren't supported by Kf", app.Name)
+				}
+			}
+
 			overrides := &manifest.Application{}
 			{
 				overrides.Docker.Image = containerImage
@@ -270,6 +281,11 @@ func NewPushCommand(
 					return err
 				}
 
+				processes, err := processSpecs(app.WorkerProcesses())
+				if err != nil {
+					return err
+				}
+
 				var randomRouteDomain string
 				if app.RandomRoute != nil && *app.RandomRoute {
 					randomRouteDomain = defaultDomain
@@ -295,6 +311,12 @@ func NewPushCommand(
 					apps.WithPushHealthCheck(healthCheck),
 					apps.WithPushRandomRouteDomain(randomRouteDomain),
 					apps.WithPushDefaultRouteDomain(defaultRouteDomain),
+					apps.WithPushProcesses(processes),
+					apps.WithPushStrategy(strategy),
+				}
+
+				if app.Command != "" {
+					pushOpts = append(pushOpts, apps.WithPushArgs([]string{app.Command}))
 				}
 
 				if app.Docker.Image == "" {
@@ -502,6 +524,13 @@ func NewPushCommand(
 		"Use the routes flag to provide multiple HTTP and TCP routes. Each route for this app is created if it does not already exist.",
 	)
 
+	pushCmd.Flags().StringVar(
+		&strategy,
+		"strategy",
+		"",
+		"Move traffic to the new revision once it's healthy, either gradually (rolling) or all at once (blue-green). Traffic is rolled back if it doesn't become healthy.",
+	)
+
 	return pushCmd
 }
 
@@ -521,7 +550,7 @@ func calculateScaleBounds(instances, minScale, maxScale *int) (exact, min, max *
 }
 
 func createRoute(routeStr, namespace string) (v1alpha1.RouteSpecFields, error) {
-	hostname, domain, path, err := parseRouteStr(routeStr)
+	hostname, domain, path, port, err := parseRouteStr(routeStr)
 	if err != nil {
 		return v1alpha1.RouteSpecFields{}, err
 	}
@@ -530,22 +559,34 @@ func createRoute(routeStr, namespace string) (v1alpha1.RouteSpecFields, error) {
 		Hostname: hostname,
 		Domain:   domain,
 		Path:     path,
+		Port:     port,
 	}, nil
 }
 
-// parseRouteStr parses a route URL into a hostname, domain, and path
-func parseRouteStr(routeStr string) (string, string, string, error) {
+// parseRouteStr parses a route URL into a hostname, domain, and path. Routes
+// with a port (e.g. tcp.example.com:1234) are TCP routes, they're parsed into
+// a domain and port instead.
+func parseRouteStr(routeStr string) (string, string, string, int32, error) {
+	// Parsing URLs without schemes causes the hostname and domain to
+	// incorrectly be empty, or the domain to be parsed as the scheme if the
+	// route has a port. We handle this by assuming the route has a HTTP
+	// scheme if scheme is not provided.
+	if !strings.Contains(routeStr, "://") {
+		routeStr = "http://" + routeStr
+	}
+
 	u, err := url.Parse(routeStr)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to parse route: %s", err)
+		return "", "", "", 0, fmt.Errorf("failed to parse route: %s", err)
 	}
-	if u.Scheme == "" {
-		// Parsing URLs without schemes causes the hostname and domain to incorrectly be empty.
-		// We handle this by assuming the route has a HTTP scheme if scheme is not provided.
-		u, err = url.Parse("http://" + routeStr)
+
+	if u.Port() != "" {
+		port, err := strconv.ParseInt(u.Port(), 10, 32)
 		if err != nil {
-			return "", "", "", fmt.Errorf("failed to parse route: %s", err)
+			return "", "", "", 0, fmt.Errorf("failed to parse route port: %s", err)
 		}
+
+		return "", u.Hostname(), "", int32(port), nil
 	}
 
 	parts := strings.SplitN(u.Hostname(), ".", 3)
@@ -574,7 +615,7 @@ func parseRouteStr(routeStr string) (string, string, string, error) {
 
 	path = u.EscapedPath()
 
-	return hostname, domain, path, nil
+	return hostname, domain, path, 0, nil
 }
 
 // convertResourceQuantityStr converts CF resource quantities into the equivalent k8s quantity strings.
@@ -606,6 +647,49 @@ func convertResourceQuantityStr(r string) (string, error) {
 
 var cfValidBytesPattern = regexp.MustCompile(`(?i)^(-?\d+)([KMGT])B?$`)
 
+// processSpecs converts the non-web processes from a manifest into process
+// types on the App. Workers don't get a health check unless one is requested
+// because they don't usually listen on a port.
+func processSpecs(processes []manifest.Process) ([]v1alpha1.AppSpecProcess, error) {
+	var specs []v1alpha1.AppSpecProcess
+	for _, process := range processes {
+		spec := v1alpha1.AppSpecProcess{
+			Type:      process.Type,
+			Command:   process.Command,
+			Instances: process.Instances,
+		}
+
+		if process.Memory != "" {
+			memStr, err := convertResourceQuantityStr(process.Memory)
+			if err != nil {
+				return nil, err
+			}
+			mem, parseErr := resource.ParseQuantity(memStr)
+			if parseErr != nil {
+				return nil, fmt.Errorf("couldn't parse resource quantity %s: %v", memStr, parseErr)
+			}
+			spec.Memory = &mem
+		}
+
+		switch process.HealthCheckType {
+		case "", "process", "none":
+			if process.HealthCheckHTTPEndpoint != "" {
+				return nil, fmt.Errorf("process %s: health check endpoints can only be used with http checks", process.Type)
+			}
+		default:
+			healthCheck, err := apps.NewHealthCheck(process.HealthCheckType, process.HealthCheckHTTPEndpoint, process.HealthCheckTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("process %s: %v", process.Type, err)
+			}
+			spec.HealthCheck = healthCheck
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
 func spaceDefaultDomain(space *v1alpha1.Space) (string, error) {
 	for _, domain := range space.Spec.Execution.Domains {
 		if domain.Default {
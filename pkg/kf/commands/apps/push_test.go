@@ -49,6 +49,7 @@ func TestPushCommand(t *testing.T) {
 	wantMemory := resource.MustParse("2Gi")
 	wantDiskQuota := resource.MustParse("2Gi")
 	wantCPU := resource.MustParse("2")
+	wantWorkerMemory := resource.MustParse("128Mi")
 
 	defaultTCPHealthCheck := &corev1.Probe{
 		Handler: corev1.Handler{
@@ -114,6 +115,19 @@ func TestPushCommand(t *testing.T) {
 				}),
 			),
 		},
+		"strategy": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--docker-image", "some-image",
+				"--strategy", "blue-green",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushStrategy("blue-green"),
+			),
+		},
 		"uses current working directory for empty path": {
 			namespace: "some-namespace",
 			args: []string{
@@ -341,6 +355,44 @@ func TestPushCommand(t *testing.T) {
 				apps.WithPushContainerImage("gcr.io/docker-app"),
 			),
 		},
+		"sidecars in manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"sidecars-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantErr: errors.New("app sidecars-app declares sidecars, sidecars aren't supported by Kf"),
+		},
+		"processes from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"processes-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/processes-app"),
+				apps.WithPushExactScale(intPtr(2)),
+				apps.WithPushArgs([]string{"./web"}),
+				apps.WithPushProcesses([]v1alpha1.AppSpecProcess{
+					{
+						Type:      "worker",
+						Command:   "./worker",
+						Instances: intPtr(3),
+						Memory:    &wantWorkerMemory,
+					},
+					{
+						Type:    "monitor",
+						Command: "./monitor",
+						HealthCheck: &corev1.Probe{
+							Handler: corev1.Handler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"},
+							},
+						},
+					},
+				}),
+			),
+		},
 		"buildpack app from manifest": {
 			namespace: "some-namespace",
 			args: []string{
@@ -446,12 +498,14 @@ func TestPushCommand(t *testing.T) {
 				"routes-app",
 				"--route=https://withscheme.example.com/path1",
 				"--route=noscheme.example.com",
+				"--route=tcp.example.com:1234",
 			},
 			wantOpts: append(defaultOptions,
 				apps.WithPushNamespace("some-namespace"),
 				apps.WithPushRoutes([]v1alpha1.RouteSpecFields{
 					buildRoute("withscheme", "example.com", "/path1"),
 					buildRoute("noscheme", "example.com", ""),
+					{Domain: "tcp.example.com", Port: 1234},
 				}),
 				apps.WithPushDefaultRouteDomain(""),
 			),
@@ -545,6 +599,9 @@ func TestPushCommand(t *testing.T) {
 					testutil.AssertEqual(t, "health check", expectOpts.HealthCheck(), actualOpts.HealthCheck())
 					testutil.AssertEqual(t, "default route", expectOpts.DefaultRouteDomain(), actualOpts.DefaultRouteDomain())
 					testutil.AssertEqual(t, "random route", expectOpts.RandomRouteDomain(), actualOpts.RandomRouteDomain())
+					testutil.AssertEqual(t, "processes", expectOpts.Processes(), actualOpts.Processes())
+					testutil.AssertEqual(t, "args", expectOpts.Args(), actualOpts.Args())
+					testutil.AssertEqual(t, "strategy", expectOpts.Strategy(), actualOpts.Strategy())
 
 					if !strings.HasPrefix(actualOpts.SourceImage(), tc.wantImagePrefix) {
 						t.Errorf("Wanted srcImage to start with %s got: %s", tc.wantImagePrefix, actualOpts.SourceImage())
@@ -599,6 +656,7 @@ func buildTestRoutes() []v1alpha1.RouteSpecFields {
 		buildRoute("", "example.com", ""),
 		buildRoute("", "www.example.com", "/foo"),
 		buildRoute("host", "example.com", "/foo"),
+		{Domain: "tcp.example.com", Port: 1234},
 	}
 }
 
@@ -0,0 +1,78 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/completion"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewRollbackCommand creates a command that deploys a previous revision of an
+// app again.
+func NewRollbackCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var toRevision int
+
+	cmd := &cobra.Command{
+		Use:   "rollback APP_NAME [--to-revision REVISION]",
+		Short: "Deploy a previous revision of an app",
+		Long: `Deploys the image and configuration of a previous revision of an
+		app again. The image isn't rebuilt, so the app runs exactly what it ran
+		before.
+
+		The app's source is replaced with the image of the revision, so a
+		buildpack app no longer builds from its source code. Push the app again
+		to go back to building from source.
+
+		Use kf app-history to list the revisions that can be rolled back to.`,
+		Example: `
+  kf rollback myapp
+  kf rollback myapp --to-revision 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			cmd.SilenceUsage = true
+
+			if err := client.Rollback(p.Namespace, appName, toRevision); err != nil {
+				return fmt.Errorf("failed to roll back app: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Rolling back app %s\n", appName)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(
+		&toRevision,
+		"to-revision",
+		0,
+		"Revision to roll back to, defaults to the revision before the latest.")
+
+	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
+
+	return cmd
+}
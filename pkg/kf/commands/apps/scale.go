@@ -16,6 +16,7 @@ package apps
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -36,6 +37,7 @@ func NewScaleCommand(
 		instances    int
 		autoscaleMin int
 		autoscaleMax int
+		processType  string
 	)
 
 	cmd := &cobra.Command{
@@ -52,6 +54,8 @@ func NewScaleCommand(
 		kf scale myapp --max 5
 		# Scale between 3 and 5 instances depending on traffic
 		kf scale myapp --min 3 --max 5
+		# Scale the worker process to exactly 2 instances
+		kf scale myapp --process worker --instances 2
 		`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -62,6 +66,10 @@ func NewScaleCommand(
 
 			appName := args[0]
 
+			if processType != v1alpha1.WebProcessType {
+				return scaleProcess(cmd, p, client, appName, processType, instances, autoscaleMin, autoscaleMax)
+			}
+
 			if instances < 0 && autoscaleMin < 0 && autoscaleMax < 0 {
 				// Display current scaling properties.
 				app, err := client.Get(p.Namespace, appName)
@@ -134,7 +142,91 @@ func NewScaleCommand(
 		"Maximum number of instances to allow the autoscaler to scale to. 0 implies the app can be scaled to ∞.",
 	)
 
+	cmd.Flags().StringVar(
+		&processType,
+		"process",
+		v1alpha1.WebProcessType,
+		"Process type to scale. Processes other than web only support --instances.",
+	)
+
 	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
 
 	return cmd
 }
+
+// scaleProcess changes or displays the instance count of a single non-web
+// process on an App.
+func scaleProcess(
+	cmd *cobra.Command,
+	p *config.KfParams,
+	client apps.Client,
+	appName string,
+	processType string,
+	instances int,
+	autoscaleMin int,
+	autoscaleMax int,
+) error {
+	if autoscaleMin >= 0 || autoscaleMax >= 0 {
+		return errors.New("--min and --max can only be used with the web process")
+	}
+
+	if instances < 0 {
+		// Display current scaling properties.
+		app, err := client.Get(p.Namespace, appName)
+		if err != nil {
+			return fmt.Errorf("failed to get app: %s", err)
+		}
+
+		process, err := findProcess(app, processType)
+		if err != nil {
+			return err
+		}
+		describe.AppSpecInstances(cmd.OutOrStderr(), processInstances(process))
+
+		return nil
+	}
+
+	mutator := func(app *v1alpha1.App) error {
+		process, err := findProcess(app, processType)
+		if err != nil {
+			return err
+		}
+
+		process.Instances = &instances
+		if err := process.Validate(context.Background()); err != nil {
+			return err
+		}
+
+		describe.AppSpecInstances(cmd.OutOrStderr(), processInstances(process))
+
+		return nil
+	}
+
+	if err := client.Transform(p.Namespace, appName, mutator); err != nil {
+		return fmt.Errorf("failed to scale app: %s", err)
+	}
+
+	return nil
+}
+
+func findProcess(app *v1alpha1.App, processType string) (*v1alpha1.AppSpecProcess, error) {
+	for i := range app.Spec.Processes {
+		if app.Spec.Processes[i].Type == processType {
+			return &app.Spec.Processes[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("app %s has no process %q", app.Name, processType)
+}
+
+// processInstances converts a process's scale to AppSpecInstances so it can
+// be displayed the same way as the web process. Processes without an explicit
+// count run a single instance.
+func processInstances(process *v1alpha1.AppSpecProcess) v1alpha1.AppSpecInstances {
+	exactly := 1
+	if process.Instances != nil {
+		exactly = *process.Instances
+	}
+
+	return v1alpha1.AppSpecInstances{Exactly: &exactly}
+}
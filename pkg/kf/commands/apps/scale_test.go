@@ -136,6 +136,62 @@ func TestNewScaleCommand(t *testing.T) {
 					})
 			},
 		},
+		"updates process to exact instances": {
+			Namespace:       "default",
+			Args:            []string{"my-app", "--process=worker", "-i=2"},
+			ExpectedStrings: []string{"Exactly:", "2"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						exactly := 3
+						app := v1alpha1.App{}
+						app.Spec.Instances.Exactly = &exactly
+						app.Spec.Processes = []v1alpha1.AppSpecProcess{
+							{Type: "worker", Command: "./worker"},
+						}
+						testutil.AssertNil(t, "mutator error", m(&app))
+						testutil.AssertEqual(t, "app.spec.processes[0].instances", 2, *app.Spec.Processes[0].Instances)
+
+						// Assert the web process wasn't altered
+						testutil.AssertEqual(t, "app.spec.instances.exactly", 3, *app.Spec.Instances.Exactly)
+					})
+			},
+		},
+		"process flag set, displays current value": {
+			Namespace:       "default",
+			Args:            []string{"my-app", "--process=worker"},
+			ExpectedStrings: []string{"Exactly:", "4"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				instances := 4
+				fake.EXPECT().Get("default", "my-app").Return(&v1alpha1.App{
+					Spec: v1alpha1.AppSpec{
+						Processes: []v1alpha1.AppSpecProcess{
+							{Type: "worker", Instances: &instances},
+						},
+					},
+				}, nil)
+			},
+		},
+		"missing process": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "--process=clock", "-i=2"},
+			ExpectedErr: errors.New("failed to scale app: app my-app has no process \"clock\""),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					DoAndReturn(func(_, _ string, m apps.Mutator) error {
+						app := v1alpha1.App{}
+						app.Name = "my-app"
+						return m(&app)
+					})
+			},
+		},
+		"autoscale flags with a process": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "--process=worker", "--min=3"},
+			ExpectedErr: errors.New("--min and --max can only be used with the web process"),
+		},
 		"updating app fails": {
 			Namespace:   "default",
 			Args:        []string{"my-app", "-i=3"},
@@ -31,6 +31,9 @@ const (
 
 	// SpaceCompletion is the type for completing spaces
 	SpaceCompletion = "spaces"
+
+	// DomainCompletion is the type for completing domains
+	DomainCompletion = "domains"
 )
 
 var namespacedTypes = map[string]schema.GroupVersionResource{
@@ -53,6 +56,12 @@ var globalTypes = map[string]schema.GroupVersionResource{
 		Version:  "v1alpha1",
 		Resource: "spaces",
 	},
+
+	DomainCompletion: {
+		Group:    "kf.dev",
+		Version:  "v1alpha1",
+		Resource: "domains",
+	},
 }
 
 // KnownGenericTypes returns the keys for all registered generic types.
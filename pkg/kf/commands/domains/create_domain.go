@@ -0,0 +1,195 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type domainFlags struct {
+	internal   bool
+	tcp        bool
+	tlsSecret  string
+	selfSigned bool
+}
+
+func (f *domainFlags) add(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&f.internal,
+		"internal",
+		false,
+		"Only allow traffic to routes on the domain from inside the cluster.",
+	)
+
+	cmd.Flags().BoolVar(
+		&f.tcp,
+		"tcp",
+		false,
+		"Use the domain for TCP routes that reserve a port.",
+	)
+
+	cmd.Flags().StringVar(
+		&f.tlsSecret,
+		"tls-secret",
+		"",
+		"Serve HTTPS for routes on the domain with the certificate in this kubernetes.io/tls Secret in istio-system.",
+	)
+
+	cmd.Flags().BoolVar(
+		&f.selfSigned,
+		"self-signed",
+		false,
+		"Serve HTTPS for routes on the domain with a generated self-signed certificate, meant for development.",
+	)
+}
+
+func (f *domainFlags) domain(name string) (*v1alpha1.Domain, error) {
+	if f.internal && f.tcp {
+		return nil, errors.New("--internal and --tcp can't be used together")
+	}
+
+	var tls *v1alpha1.DomainTLS
+	switch {
+	case f.tlsSecret != "" && f.selfSigned:
+		return nil, errors.New("--tls-secret and --self-signed can't be used together")
+	case (f.tlsSecret != "" || f.selfSigned) && (f.internal || f.tcp):
+		return nil, errors.New("--tls-secret and --self-signed can't be used with --internal or --tcp")
+	case f.tlsSecret != "" || f.selfSigned:
+		tls = &v1alpha1.DomainTLS{
+			SecretName: f.tlsSecret,
+			SelfSigned: f.selfSigned,
+		}
+	}
+
+	return &v1alpha1.Domain{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Domain",
+			APIVersion: "kf.dev/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1alpha1.DomainSpec{
+			Internal: f.internal,
+			TCP:      f.tcp,
+			TLS:      tls,
+		},
+	}, nil
+}
+
+// NewCreateDomainCommand allows users to create a domain private to the
+// targeted space.
+func NewCreateDomainCommand(
+	p *config.KfParams,
+	client domains.Client,
+	spacesClient spaces.Client,
+) *cobra.Command {
+	var flags domainFlags
+
+	cmd := &cobra.Command{
+		Use:   "create-domain DOMAIN [--internal | --tcp | --tls-secret SECRET | --self-signed]",
+		Short: "Create a domain private to the space",
+		Long: `Create a domain that only routes in the targeted space can use.
+
+		The domain is deleted along with the space.
+		`,
+		Example: `
+  kf create-domain example.com
+  kf create-domain apps.internal --internal
+  kf create-domain tcp.example.com --tcp
+  kf create-domain secure.example.com --tls-secret secure-example-com
+  kf create-domain dev.example.com --self-signed`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			domain, err := flags.domain(args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			space, err := spacesClient.Get(p.Namespace)
+			if err != nil {
+				return fmt.Errorf("failed to get space: %s", err)
+			}
+
+			domain.Spec.Space = space.Name
+			domain.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(space, v1alpha1.SchemeGroupVersion.WithKind("Space")),
+			}
+
+			if _, err := client.Create(domain); err != nil {
+				return fmt.Errorf("failed to create domain: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created domain %s in space %s\n", domain.Name, space.Name)
+			return nil
+		},
+	}
+
+	flags.add(cmd)
+
+	return cmd
+}
+
+// NewCreateSharedDomainCommand allows users to create a domain every space
+// can use.
+func NewCreateSharedDomainCommand(p *config.KfParams, client domains.Client) *cobra.Command {
+	var flags domainFlags
+
+	cmd := &cobra.Command{
+		Use:   "create-shared-domain DOMAIN [--internal | --tcp | --tls-secret SECRET | --self-signed]",
+		Short: "Create a domain shared with all spaces",
+		Example: `
+  kf create-shared-domain example.com
+  kf create-shared-domain apps.internal --internal
+  kf create-shared-domain tcp.example.com --tcp
+  kf create-shared-domain secure.example.com --tls-secret secure-example-com
+  kf create-shared-domain dev.example.com --self-signed`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, err := flags.domain(args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			if _, err := client.Create(domain); err != nil {
+				return fmt.Errorf("failed to create domain: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created shared domain %s\n", domain.Name)
+			return nil
+		},
+	}
+
+	flags.add(cmd)
+
+	return cmd
+}
@@ -0,0 +1,213 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/google/kf/pkg/kf/domains/fake"
+	spacesfake "github.com/google/kf/pkg/kf/spaces/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCreateDomainCommand(t *testing.T) {
+	t.Parallel()
+
+	space := &v1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", UID: "some-uid"},
+	}
+
+	cases := map[string]struct {
+		namespace string
+		args      []string
+		setup     func(t *testing.T, fakeDomains *fake.FakeClient, fakeSpaces *spacesfake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			namespace: "dev",
+			args:      []string{},
+			wantErr:   errors.New("accepts 1 arg(s), received 0"),
+		},
+		"empty namespace": {
+			args:    []string{"example.com"},
+			wantErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"internal and tcp": {
+			namespace: "dev",
+			args:      []string{"example.com", "--internal", "--tcp"},
+			wantErr:   errors.New("--internal and --tcp can't be used together"),
+		},
+		"tls-secret and self-signed": {
+			namespace: "dev",
+			args:      []string{"example.com", "--tls-secret", "my-cert", "--self-signed"},
+			wantErr:   errors.New("--tls-secret and --self-signed can't be used together"),
+		},
+		"TLS on TCP domain": {
+			namespace: "dev",
+			args:      []string{"tcp.example.com", "--tcp", "--self-signed"},
+			wantErr:   errors.New("--tls-secret and --self-signed can't be used with --internal or --tcp"),
+		},
+		"creates private domain": {
+			namespace: "dev",
+			args:      []string{"tcp.example.com", "--tcp"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient, fakeSpaces *spacesfake.FakeClient) {
+				fakeSpaces.EXPECT().Get("dev").Return(space, nil)
+				fakeDomains.EXPECT().
+					Create(gomock.Any()).
+					Do(func(domain *v1alpha1.Domain, opts ...domains.CreateOption) {
+						testutil.AssertEqual(t, "name", "tcp.example.com", domain.Name)
+						testutil.AssertEqual(t, "space", "dev", domain.Spec.Space)
+						testutil.AssertEqual(t, "tcp", true, domain.Spec.TCP)
+						testutil.AssertEqual(t, "internal", false, domain.Spec.Internal)
+						testutil.AssertEqual(t, "owner", true, metav1.IsControlledBy(domain, space))
+					})
+			},
+			expectedStrings: []string{"Created domain tcp.example.com in space dev"},
+		},
+		"getting space fails": {
+			namespace: "dev",
+			args:      []string{"example.com"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient, fakeSpaces *spacesfake.FakeClient) {
+				fakeSpaces.EXPECT().Get("dev").Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to get space: some-server-error"),
+		},
+		"creating domain fails": {
+			namespace: "dev",
+			args:      []string{"example.com"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient, fakeSpaces *spacesfake.FakeClient) {
+				fakeSpaces.EXPECT().Get("dev").Return(space, nil)
+				fakeDomains.EXPECT().Create(gomock.Any()).Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to create domain: some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeDomains := fake.NewFakeClient(ctrl)
+			fakeSpaces := spacesfake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeDomains, fakeSpaces)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewCreateDomainCommand(&config.KfParams{Namespace: tc.namespace}, fakeDomains, fakeSpaces)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestNewCreateSharedDomainCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args  []string
+		setup func(t *testing.T, fakeDomains *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"creates shared domain": {
+			args: []string{"apps.internal", "--internal"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().
+					Create(gomock.Any()).
+					Do(func(domain *v1alpha1.Domain, opts ...domains.CreateOption) {
+						testutil.AssertEqual(t, "name", "apps.internal", domain.Name)
+						testutil.AssertEqual(t, "shared", true, domain.IsShared())
+						testutil.AssertEqual(t, "internal", true, domain.Spec.Internal)
+					})
+			},
+			expectedStrings: []string{"Created shared domain apps.internal"},
+		},
+		"server failure": {
+			args: []string{"example.com"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().Create(gomock.Any()).Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to create domain: some-server-error"),
+		},
+		"creates shared domain with TLS": {
+			args: []string{"example.com", "--tls-secret", "example-com"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().
+					Create(gomock.Any()).
+					Do(func(domain *v1alpha1.Domain, opts ...domains.CreateOption) {
+						testutil.AssertEqual(t, "tls", &v1alpha1.DomainTLS{SecretName: "example-com"}, domain.Spec.TLS)
+					})
+			},
+			expectedStrings: []string{"Created shared domain example.com"},
+		},
+		"creates self-signed shared domain": {
+			args: []string{"dev.example.com", "--self-signed"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().
+					Create(gomock.Any()).
+					Do(func(domain *v1alpha1.Domain, opts ...domains.CreateOption) {
+						testutil.AssertEqual(t, "tls", &v1alpha1.DomainTLS{SelfSigned: true}, domain.Spec.TLS)
+					})
+			},
+			expectedStrings: []string{"Created shared domain dev.example.com"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeDomains := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeDomains)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewCreateSharedDomainCommand(&config.KfParams{}, fakeDomains)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
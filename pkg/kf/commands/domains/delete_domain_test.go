@@ -0,0 +1,80 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/domains/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewDeleteDomainCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args  []string
+		setup func(t *testing.T, fakeDomains *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"calls delete": {
+			args: []string{"example.com"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().Delete("example.com")
+			},
+			expectedStrings: []string{"Deleted domain example.com"},
+		},
+		"server failure": {
+			args: []string{"example.com"},
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().Delete("example.com").Return(errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to delete domain: some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeDomains := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeDomains)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewDeleteDomainCommand(&config.KfParams{}, fakeDomains)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
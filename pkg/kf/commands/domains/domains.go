@@ -0,0 +1,81 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/domains"
+	"github.com/spf13/cobra"
+)
+
+// NewDomainsCommand allows users to list the domains available to the
+// targeted space.
+func NewDomainsCommand(p *config.KfParams, client domains.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "domains",
+		Short: "List domains available to the space",
+		Long: `List the shared domains and the domains private to the targeted space.
+
+		Domains listed directly on the space with configure-space aren't shown,
+		use configure-space get-domains to see them.
+		`,
+		Example: `kf domains`,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			list, err := client.List()
+			if err != nil {
+				return err
+			}
+
+			available := domains.List(list).Filter(domains.AvailableTo(p.Namespace))
+			sort.Slice(available, func(i, j int) bool {
+				return available[i].Name < available[j].Name
+			})
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Getting domains in space: %s\n\n", p.Namespace)
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Name\tAvailability\tInternal?\tTCP?")
+				for _, domain := range available {
+					availability := "private"
+					if domain.IsShared() {
+						availability = "shared"
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%t\t%t\n",
+						domain.Name,
+						availability,
+						domain.Spec.Internal,
+						domain.Spec.TCP,
+					)
+				}
+			})
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domains
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/domains/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewDomainsCommand(t *testing.T) {
+	t.Parallel()
+
+	list := []v1alpha1.Domain{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev.example.com"},
+			Spec:       v1alpha1.DomainSpec{Space: "dev", Internal: true},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod.example.com"},
+			Spec:       v1alpha1.DomainSpec{Space: "prod"},
+		},
+	}
+
+	cases := map[string]struct {
+		namespace string
+		args      []string
+		setup     func(t *testing.T, fakeDomains *fake.FakeClient)
+
+		wantErr           error
+		expectedStrings   []string
+		unexpectedStrings []string
+	}{
+		"invalid number of args": {
+			namespace: "dev",
+			args:      []string{"example.com"},
+			wantErr:   errors.New("accepts 0 arg(s), received 1"),
+		},
+		"empty namespace": {
+			wantErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"lists available domains": {
+			namespace: "dev",
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().List().Return(list, nil)
+			},
+			expectedStrings: []string{
+				"Getting domains in space: dev",
+				"Name", "Availability", "Internal?", "TCP?",
+				"example.com", "shared",
+				"dev.example.com", "private", "true",
+			},
+			unexpectedStrings: []string{"prod.example.com"},
+		},
+		"server failure": {
+			namespace: "dev",
+			setup: func(t *testing.T, fakeDomains *fake.FakeClient) {
+				fakeDomains.EXPECT().List().Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeDomains := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeDomains)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewDomainsCommand(&config.KfParams{Namespace: tc.namespace}, fakeDomains)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			for _, unexpected := range tc.unexpectedStrings {
+				testutil.AssertEqual(t, "contains "+unexpected, false, strings.Contains(buffer.String(), unexpected))
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/completion"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewAddNetworkPolicyCommand allows users to let one App connect directly to
+// another.
+func NewAddNetworkPolicyCommand(
+	p *config.KfParams,
+	appsClient apps.Client,
+	k8sClient kubernetes.Interface,
+) *cobra.Command {
+	var (
+		destinationApp string
+		protocol       string
+		port           int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add-network-policy SOURCE_APP --destination-app DESTINATION_APP [--protocol (tcp | udp) --port PORT]",
+		Short: "Allow direct network traffic from one app to another",
+		Long: `Allows the instances of the source app to connect directly to
+		the instances of the destination app on the given port, bypassing
+		routes.
+
+		Once an app is the destination of a policy, only traffic allowed by a
+		policy or delivered through the app's routes can reach it.`,
+		Example: `
+  kf add-network-policy frontend --destination-app backend
+  kf add-network-policy frontend --destination-app backend --protocol udp --port 9000`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			sourceName := args[0]
+
+			if destinationApp == "" {
+				return fmt.Errorf("--destination-app is required")
+			}
+
+			proto, err := parseProtocol(protocol)
+			if err != nil {
+				return err
+			}
+
+			if port < 1 || port > 65535 {
+				return fmt.Errorf("invalid port %d, must be between 1 and 65535", port)
+			}
+
+			cmd.SilenceUsage = true
+
+			source, err := appsClient.Get(p.Namespace, sourceName)
+			if err != nil {
+				return err
+			}
+
+			destination, err := appsClient.Get(p.Namespace, destinationApp)
+			if err != nil {
+				return err
+			}
+
+			np := cfutil.MakeNetworkPolicy(source, destination, proto, port)
+			if _, err := k8sClient.NetworkingV1().NetworkPolicies(p.Namespace).Create(np); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"Allowed %s traffic from app %s to app %s on port %d in space %s\n",
+				strings.ToLower(string(proto)),
+				sourceName,
+				destinationApp,
+				port,
+				p.Namespace,
+			)
+
+			return nil
+		},
+	}
+
+	addPolicyFlags(cmd, &destinationApp, &protocol, &port)
+	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
+
+	return cmd
+}
+
+// addPolicyFlags registers the flags shared by the commands that add and
+// remove network policies.
+func addPolicyFlags(cmd *cobra.Command, destinationApp, protocol *string, port *int32) {
+	cmd.Flags().StringVar(
+		destinationApp,
+		"destination-app",
+		"",
+		"Name of the app traffic is allowed to.")
+
+	cmd.Flags().StringVar(
+		protocol,
+		"protocol",
+		"tcp",
+		"Protocol to allow, either tcp or udp.")
+
+	cmd.Flags().Int32Var(
+		port,
+		"port",
+		cfutil.DefaultNetworkPolicyPort,
+		"Port on the destination app to allow traffic to.")
+}
+
+// parseProtocol converts a user supplied protocol into a Kubernetes protocol.
+func parseProtocol(protocol string) (corev1.Protocol, error) {
+	switch strings.ToLower(protocol) {
+	case "tcp":
+		return corev1.ProtocolTCP, nil
+	case "udp":
+		return corev1.ProtocolUDP, nil
+	default:
+		return "", fmt.Errorf("invalid protocol %q, must be tcp or udp", protocol)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	networkpolicies "github.com/google/kf/pkg/kf/commands/network-policies"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewAddNetworkPolicyCommand(t *testing.T) {
+	t.Parallel()
+
+	expectApps := func(fake *fake.FakeClient) {
+		fake.EXPECT().Get("custom-ns", "frontend").Return(dummyApp("custom-ns", "frontend"), nil)
+		fake.EXPECT().Get("custom-ns", "backend").Return(dummyApp("custom-ns", "backend"), nil)
+	}
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+		ExpectedErr     error
+		ExpectedStrings []string
+		Validate        func(t *testing.T, client kubernetes.Interface)
+	}{
+		"too few params": {
+			Namespace:   "custom-ns",
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"empty namespace": {
+			Args:        []string{"frontend", "--destination-app", "backend"},
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"missing destination": {
+			Namespace:   "custom-ns",
+			Args:        []string{"frontend"},
+			ExpectedErr: errors.New("--destination-app is required"),
+		},
+		"invalid protocol": {
+			Namespace:   "custom-ns",
+			Args:        []string{"frontend", "--destination-app", "backend", "--protocol", "icmp"},
+			ExpectedErr: errors.New(`invalid protocol "icmp", must be tcp or udp`),
+		},
+		"invalid port": {
+			Namespace:   "custom-ns",
+			Args:        []string{"frontend", "--destination-app", "backend", "--port", "0"},
+			ExpectedErr: errors.New("invalid port 0, must be between 1 and 65535"),
+		},
+		"getting app fails": {
+			Namespace: "custom-ns",
+			Args:      []string{"frontend", "--destination-app", "backend"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get("custom-ns", "frontend").Return(nil, errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("some-error"),
+		},
+		"defaults": {
+			Namespace: "custom-ns",
+			Args:      []string{"frontend", "--destination-app", "backend"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				expectApps(fake)
+			},
+			ExpectedStrings: []string{"Allowed tcp traffic from app frontend to app backend on port 8080 in space custom-ns"},
+			Validate: func(t *testing.T, client kubernetes.Interface) {
+				assertPolicyExists(t, client, "custom-ns", cfutil.NetworkPolicy{
+					Source:      "frontend",
+					Destination: "backend",
+					Protocol:    corev1.ProtocolTCP,
+					Port:        8080,
+				})
+			},
+		},
+		"all flags": {
+			Namespace: "custom-ns",
+			Args:      []string{"frontend", "--destination-app", "backend", "--protocol", "UDP", "--port", "9000"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				expectApps(fake)
+			},
+			ExpectedStrings: []string{"Allowed udp traffic from app frontend to app backend on port 9000 in space custom-ns"},
+			Validate: func(t *testing.T, client kubernetes.Interface) {
+				assertPolicyExists(t, client, "custom-ns", cfutil.NetworkPolicy{
+					Source:      "frontend",
+					Destination: "backend",
+					Protocol:    corev1.ProtocolUDP,
+					Port:        9000,
+				})
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			appsClient := fake.NewFakeClient(ctrl)
+			if tc.Setup != nil {
+				tc.Setup(t, appsClient)
+			}
+
+			k8sClient := k8sfake.NewSimpleClientset()
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := networkpolicies.NewAddNetworkPolicyCommand(p, appsClient, k8sClient)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+
+			if tc.Validate != nil {
+				tc.Validate(t, k8sClient)
+			}
+		})
+	}
+}
+
+func dummyApp(namespace, name string) *v1alpha1.App {
+	app := &v1alpha1.App{}
+	app.Namespace = namespace
+	app.Name = name
+	return app
+}
+
+// assertPolicyExists checks that a NetworkPolicy was created for the policy.
+func assertPolicyExists(t *testing.T, client kubernetes.Interface, namespace string, expected cfutil.NetworkPolicy) {
+	t.Helper()
+
+	np, err := client.
+		NetworkingV1().
+		NetworkPolicies(namespace).
+		Get(cfutil.NetworkPolicyName(expected), metav1.GetOptions{})
+	testutil.AssertNil(t, "get NetworkPolicy err", err)
+
+	actual, err := cfutil.ParseNetworkPolicy(np)
+	testutil.AssertNil(t, "parse err", err)
+	testutil.AssertEqual(t, "policy", expected, *actual)
+}
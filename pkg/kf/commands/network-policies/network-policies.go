@@ -0,0 +1,104 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewNetworkPoliciesCommand allows users to list the network policies
+// between Apps.
+func NewNetworkPoliciesCommand(p *config.KfParams, k8sClient kubernetes.Interface) *cobra.Command {
+	var sourceApp string
+
+	cmd := &cobra.Command{
+		Use:   "network-policies [--source-app SOURCE_APP]",
+		Short: "List direct network traffic allowed between apps",
+		Example: `
+  kf network-policies
+  kf network-policies --source-app frontend`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			list, err := k8sClient.NetworkingV1().NetworkPolicies(p.Namespace).List(metav1.ListOptions{
+				LabelSelector: cfutil.NetworkPolicySelector().String(),
+			})
+			if err != nil {
+				return err
+			}
+
+			var policies []cfutil.NetworkPolicy
+			for i := range list.Items {
+				policy, err := cfutil.ParseNetworkPolicy(&list.Items[i])
+				if err != nil {
+					return err
+				}
+
+				if sourceApp != "" && policy.Source != sourceApp {
+					continue
+				}
+
+				policies = append(policies, *policy)
+			}
+
+			sort.Slice(policies, func(i, j int) bool {
+				if policies[i].Source != policies[j].Source {
+					return policies[i].Source < policies[j].Source
+				}
+				return policies[i].Destination < policies[j].Destination
+			})
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Listing network policies in space %s\n\n", p.Namespace)
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Source\tDestination\tProtocol\tPort")
+				for _, policy := range policies {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%d\n",
+						policy.Source,
+						policy.Destination,
+						strings.ToLower(string(policy.Protocol)),
+						policy.Port,
+					)
+				}
+			})
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&sourceApp,
+		"source-app",
+		"",
+		"Only list policies with this source app.")
+
+	return cmd
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	networkpolicies "github.com/google/kf/pkg/kf/commands/network-policies"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewNetworkPoliciesCommand(t *testing.T) {
+	t.Parallel()
+
+	objects := []runtime.Object{
+		cfutil.MakeNetworkPolicy(dummyApp("custom-ns", "worker"), dummyApp("custom-ns", "backend"), corev1.ProtocolUDP, 9000),
+		cfutil.MakeNetworkPolicy(dummyApp("custom-ns", "frontend"), dummyApp("custom-ns", "backend"), corev1.ProtocolTCP, 8080),
+	}
+
+	cases := map[string]struct {
+		Namespace         string
+		Args              []string
+		ExpectedErr       error
+		ExpectedStrings   []string
+		UnexpectedStrings []string
+	}{
+		"empty namespace": {
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"lists all": {
+			Namespace: "custom-ns",
+			ExpectedStrings: []string{
+				"Listing network policies in space custom-ns",
+				"Source", "Destination", "Protocol", "Port",
+				"frontend", "backend", "tcp", "8080",
+				"worker", "udp", "9000",
+			},
+		},
+		"filters by source": {
+			Namespace:         "custom-ns",
+			Args:              []string{"--source-app", "worker"},
+			ExpectedStrings:   []string{"worker", "backend", "udp", "9000"},
+			UnexpectedStrings: []string{"frontend"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			k8sClient := k8sfake.NewSimpleClientset(objects...)
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := networkpolicies.NewNetworkPoliciesCommand(p, k8sClient)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+
+			for _, unexpected := range tc.UnexpectedStrings {
+				testutil.AssertEqual(t, "contains "+unexpected, false, strings.Contains(buf.String(), unexpected))
+			}
+		})
+	}
+}
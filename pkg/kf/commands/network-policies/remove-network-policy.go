@@ -0,0 +1,95 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/completion"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewRemoveNetworkPolicyCommand allows users to stop one App from connecting
+// directly to another.
+func NewRemoveNetworkPolicyCommand(p *config.KfParams, k8sClient kubernetes.Interface) *cobra.Command {
+	var (
+		destinationApp string
+		protocol       string
+		port           int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "remove-network-policy SOURCE_APP --destination-app DESTINATION_APP [--protocol (tcp | udp) --port PORT]",
+		Short: "Remove direct network traffic from one app to another",
+		Long: `Removes a policy created by add-network-policy. The protocol
+		and port must match the ones the policy was added with.`,
+		Example: `
+  kf remove-network-policy frontend --destination-app backend
+  kf remove-network-policy frontend --destination-app backend --protocol udp --port 9000`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			sourceName := args[0]
+
+			if destinationApp == "" {
+				return fmt.Errorf("--destination-app is required")
+			}
+
+			proto, err := parseProtocol(protocol)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			name := cfutil.NetworkPolicyName(cfutil.NetworkPolicy{
+				Source:      sourceName,
+				Destination: destinationApp,
+				Protocol:    proto,
+				Port:        port,
+			})
+
+			if err := k8sClient.NetworkingV1().NetworkPolicies(p.Namespace).Delete(name, &metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"Removed %s traffic from app %s to app %s on port %d in space %s\n",
+				strings.ToLower(string(proto)),
+				sourceName,
+				destinationApp,
+				port,
+				p.Namespace,
+			)
+
+			return nil
+		},
+	}
+
+	addPolicyFlags(cmd, &destinationApp, &protocol, &port)
+	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
+
+	return cmd
+}
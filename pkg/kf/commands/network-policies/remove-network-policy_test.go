@@ -0,0 +1,88 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	networkpolicies "github.com/google/kf/pkg/kf/commands/network-policies"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewRemoveNetworkPolicyCommand(t *testing.T) {
+	t.Parallel()
+
+	existing := cfutil.MakeNetworkPolicy(
+		dummyApp("custom-ns", "frontend"),
+		dummyApp("custom-ns", "backend"),
+		corev1.ProtocolUDP,
+		9000,
+	)
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		Objects         []runtime.Object
+		ExpectedErr     error
+		ExpectedStrings []string
+	}{
+		"missing destination": {
+			Namespace:   "custom-ns",
+			Args:        []string{"frontend"},
+			ExpectedErr: errors.New("--destination-app is required"),
+		},
+		"removes policy": {
+			Namespace:       "custom-ns",
+			Args:            []string{"frontend", "--destination-app", "backend", "--protocol", "udp", "--port", "9000"},
+			Objects:         []runtime.Object{existing},
+			ExpectedStrings: []string{"Removed udp traffic from app frontend to app backend on port 9000 in space custom-ns"},
+		},
+		"policy doesn't match": {
+			Namespace:   "custom-ns",
+			Args:        []string{"frontend", "--destination-app", "backend"},
+			Objects:     []runtime.Object{existing},
+			ExpectedErr: errors.New(`networkpolicies.networking.k8s.io "` + cfutil.NetworkPolicyName(cfutil.NetworkPolicy{Source: "frontend", Destination: "backend", Protocol: corev1.ProtocolTCP, Port: 8080}) + `" not found`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			k8sClient := k8sfake.NewSimpleClientset(tc.Objects...)
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := networkpolicies.NewRemoveNetworkPolicyCommand(p, k8sClient)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+		})
+	}
+}
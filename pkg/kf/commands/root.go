@@ -85,11 +85,21 @@ func NewKfCommand() *cobra.Command {
 				InjectStop(p),
 				InjectRestart(p),
 				InjectRestage(p),
+				InjectRollback(p),
+				InjectAppHistory(p),
 				InjectScale(p),
 				InjectLogs(p),
 				InjectProxy(p),
 			},
 		},
+		{
+			Name: "Tasks",
+			Commands: []*cobra.Command{
+				InjectRunTask(p),
+				InjectTasks(p),
+				InjectTerminateTask(p),
+			},
+		},
 		{
 			Name: "Environment Variables",
 			Commands: []*cobra.Command{
@@ -110,9 +120,29 @@ func NewKfCommand() *cobra.Command {
 			Commands: []*cobra.Command{
 				InjectRoutes(p),
 				InjectCreateRoute(p),
+				InjectUpdateRoute(p),
 				InjectDeleteRoute(p),
 				InjectMapRoute(p),
 				InjectUnmapRoute(p),
+				InjectBindRouteService(p),
+				InjectUnbindRouteService(p),
+			},
+		},
+		{
+			Name: "Domains",
+			Commands: []*cobra.Command{
+				InjectDomains(p),
+				InjectCreateDomain(p),
+				InjectCreateSharedDomain(p),
+				InjectDeleteDomain(p),
+			},
+		},
+		{
+			Name: "Network Policies",
+			Commands: []*cobra.Command{
+				InjectAddNetworkPolicy(p),
+				InjectNetworkPolicies(p),
+				InjectRemoveNetworkPolicy(p),
 			},
 		},
 		{
@@ -127,12 +157,23 @@ func NewKfCommand() *cobra.Command {
 			Name: "Services",
 			Commands: []*cobra.Command{
 				InjectCreateService(p),
+				InjectCreateUserProvidedService(p),
+				InjectUpdateUserProvidedService(p),
 				InjectDeleteService(p),
 				InjectGetService(p),
 				InjectListServices(p),
 				InjectMarketplace(p),
 			},
 		},
+		{
+			Name: "Service Brokers",
+			Commands: []*cobra.Command{
+				InjectCreateServiceBroker(p),
+				InjectDeleteServiceBroker(p),
+				InjectListServiceBrokers(p),
+				InjectRefreshServiceBroker(p),
+			},
+		},
 		{
 			Name: "Service Bindings",
 			Commands: []*cobra.Command{
@@ -170,6 +211,7 @@ func NewKfCommand() *cobra.Command {
 				doctor.NewDoctorCommand(p, []doctor.DoctorTest{
 					{Name: "cluster", Test: pkgdoctor.NewClusterDiagnostic(config.GetKubernetes(p))},
 					{Name: "buildpacks", Test: InjectBuildpacksClient(p)},
+					{Name: "routes", Test: pkgdoctor.NewRoutesDiagnostic(config.GetKfClient(p), config.GetDynamicClient(p))},
 				}),
 
 				completionCommand(rootCmd),
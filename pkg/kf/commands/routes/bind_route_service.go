@@ -0,0 +1,130 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/routeclaims"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewBindRouteServiceCommand creates a BindRouteService command.
+func NewBindRouteServiceCommand(
+	p *config.KfParams,
+	c routeclaims.Client,
+	k8sClient kubernetes.Interface,
+) *cobra.Command {
+	var hostname, urlPath string
+
+	cmd := &cobra.Command{
+		Use:   "bind-route-service DOMAIN SERVICE_INSTANCE [--hostname HOSTNAME] [--path PATH]",
+		Short: "Forward a route's traffic through a service before it reaches the apps",
+		Long: `Binds a route service to a route. Requests to the route are sent to
+		the service's route_service_url with the X-CF-Forwarded-Url,
+		X-CF-Proxy-Signature and X-CF-Proxy-Metadata headers set. The service
+		forwards requests it accepts to the X-CF-Forwarded-Url, keeping the
+		signature and metadata headers, and they are then sent on to the apps
+		bound to the route.
+
+		The signature is only valid for the URL of the request and expires
+		after 60 seconds. Requests with a missing, expired or forged signature
+		don't reach the apps.
+
+		The service instance must be a user-provided service created with a
+		route service URL.`,
+		Example: `
+  kf create-user-provided-service my-ratelimiter -r https://ratelimiter.example.com
+  kf bind-route-service example.com my-ratelimiter --hostname myapp
+  kf bind-route-service example.com my-ratelimiter --hostname myapp --path /mypath`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			domain, instanceName := args[0], args[1]
+
+			if hostname == "" {
+				return errors.New("--hostname is required")
+			}
+
+			cmd.SilenceUsage = true
+
+			secret, err := k8sClient.
+				CoreV1().
+				Secrets(p.Namespace).
+				Get(cfutil.UserProvidedServiceSecretName(instanceName), metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get service instance: %s", err)
+			}
+
+			ups, err := cfutil.ParseUserProvidedServiceSecret(secret)
+			if err != nil {
+				return err
+			}
+
+			if ups.RouteServiceURL == "" {
+				return fmt.Errorf("service instance %s doesn't have a route service URL", instanceName)
+			}
+
+			urlPath = path.Join("/", urlPath)
+			claimName := v1alpha1.GenerateRouteClaimName(hostname, domain, urlPath)
+
+			if err := c.Transform(p.Namespace, claimName, func(claim *v1alpha1.RouteClaim) error {
+				claim.Spec.RouteService = &v1alpha1.RouteServiceBinding{
+					InstanceName: instanceName,
+					URL:          ups.RouteServiceURL,
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to bind route service: %s", err)
+			}
+
+			route := v1alpha1.RouteSpecFields{Hostname: hostname, Domain: domain, Path: urlPath}
+			fmt.Fprintf(cmd.OutOrStdout(), "Bound route service %s to route %s\n", instanceName, route.String())
+			return nil
+		},
+	}
+
+	addRouteServiceFlags(cmd, &hostname, &urlPath)
+
+	return cmd
+}
+
+// addRouteServiceFlags registers the flags shared by the commands that bind
+// and unbind route services.
+func addRouteServiceFlags(cmd *cobra.Command, hostname, urlPath *string) {
+	cmd.Flags().StringVar(
+		hostname,
+		"hostname",
+		"",
+		"Hostname for the route",
+	)
+	cmd.Flags().StringVar(
+		urlPath,
+		"path",
+		"",
+		"URL Path for the route",
+	)
+}
@@ -0,0 +1,155 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/routes"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/routeclaims"
+	fakerouteclaims "github.com/google/kf/pkg/kf/routeclaims/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestBindRouteService(t *testing.T) {
+	t.Parallel()
+
+	ratelimiter, err := cfutil.MakeUserProvidedServiceSecret("some-namespace", cfutil.UserProvidedService{
+		Name:            "my-ratelimiter",
+		RouteServiceURL: "https://ratelimiter.example.com",
+	})
+	testutil.AssertNil(t, "ratelimiter err", err)
+
+	credentials, err := cfutil.MakeUserProvidedServiceSecret("some-namespace", cfutil.UserProvidedService{
+		Name: "my-db",
+	})
+	testutil.AssertNil(t, "credentials err", err)
+
+	for tn, tc := range map[string]struct {
+		Namespace string
+		Args      []string
+		Setup     func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient)
+		Assert    func(t *testing.T, buffer *bytes.Buffer, err error)
+	}{
+		"wrong number of args": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("accepts 2 arg(s), received 1"), err)
+			},
+		},
+		"without namespace": {
+			Args: []string{"example.com", "my-ratelimiter", "--hostname=myapp"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New(utils.EmptyNamespaceError), err)
+			},
+		},
+		"missing hostname": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "my-ratelimiter"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--hostname is required"), err)
+			},
+		},
+		"service instance doesn't exist": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "missing", "--hostname=myapp"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New(`failed to get service instance: secrets "user-provided-service-missing" not found`), err)
+			},
+		},
+		"service instance isn't a route service": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "my-db", "--hostname=myapp"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("service instance my-db doesn't have a route service URL"), err)
+			},
+		},
+		"transform fails": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "my-ratelimiter", "--hostname=myapp"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				fakeRouteClaims.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("some-error"))
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("failed to bind route service: some-error"), err)
+			},
+		},
+		"binds route service": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "my-ratelimiter", "--hostname=myapp", "--path=somepath"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				expectedName := v1alpha1.GenerateRouteClaimName("myapp", "example.com", "/somepath")
+				fakeRouteClaims.EXPECT().
+					Transform("some-namespace", expectedName, gomock.Any()).
+					DoAndReturn(func(namespace, name string, mutator routeclaims.Mutator) error {
+						claim := &v1alpha1.RouteClaim{}
+						testutil.AssertNil(t, "mutator err", mutator(claim))
+						testutil.AssertEqual(t, "RouteService", &v1alpha1.RouteServiceBinding{
+							InstanceName: "my-ratelimiter",
+							URL:          "https://ratelimiter.example.com",
+						}, claim.Spec.RouteService)
+						return nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"Bound route service my-ratelimiter to route myapp.example.com/somepath"})
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeRouteClaims := fakerouteclaims.NewFakeClient(ctrl)
+			k8sClient := k8sfake.NewSimpleClientset(ratelimiter, credentials)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fakeRouteClaims)
+			}
+
+			var buffer bytes.Buffer
+			cmd := routes.NewBindRouteServiceCommand(
+				&config.KfParams{
+					Namespace: tc.Namespace,
+				},
+				fakeRouteClaims,
+				k8sClient,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+
+			if tc.Assert != nil {
+				tc.Assert(t, &buffer, gotErr)
+			}
+
+			if gotErr != nil {
+				return
+			}
+			ctrl.Finish()
+		})
+	}
+}
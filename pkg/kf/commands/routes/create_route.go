@@ -17,6 +17,7 @@ package routes
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"path"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -32,10 +33,16 @@ func NewCreateRouteCommand(
 	p *config.KfParams,
 	c routeclaims.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		port              int32
+		randomPort        bool
+		httpsRedirect     bool
+		policyFlags       trafficPolicyFlags
+	)
 
 	cmd := &cobra.Command{
-		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--https-redirect] [--timeout SECONDS] [--retries N] [--port PORT | --random-port]",
 		Short: "Create a route",
 		Example: `
   # Using namespace (instead of SPACE)
@@ -43,6 +50,18 @@ func NewCreateRouteCommand(
   kf create-route --namespace myspace example.com --hostname myapp # myapp.example.com
   kf create-route example.com --hostname myapp --path /mypath # myapp.example.com/mypath
 
+  # Redirect HTTP to HTTPS, the domain must have TLS configured
+  kf create-route example.com --hostname myapp --https-redirect
+
+  # Traffic policy for HTTP routes
+  kf create-route example.com --hostname myapp --timeout 30 --retries 3
+  kf create-route example.com --hostname myapp --cors-allow-origin https://example.com --cors-allow-method GET
+  kf create-route example.com --hostname myapp --add-request-header X-Team=payments --remove-response-header Server
+
+  # TCP routes on one of the space's TCP domains
+  kf create-route tcp.example.com --port 1234 # tcp.example.com:1234
+  kf create-route tcp.example.com --random-port
+
   # [DEPRECATED] Using SPACE to match 'cf'
   kf create-route myspace example.com --hostname myapp # myapp.example.com
   kf create-route myspace example.com --hostname myapp --path /mypath # myapp.example.com/mypath
@@ -66,38 +85,68 @@ Use the --namespace flag instead.`)
 				return fmt.Errorf("SPACE (argument=%q) and namespace (flag=%q) (if provided) must match", space, p.Namespace)
 			}
 
-			if hostname == "" {
+			tcp := port != 0 || randomPort
+			switch {
+			case port != 0 && randomPort:
+				return errors.New("--port and --random-port can't be used together")
+			case tcp && (hostname != "" || urlPath != ""):
+				return errors.New("--hostname and --path can't be used with --port or --random-port")
+			case tcp && httpsRedirect:
+				return errors.New("--https-redirect can't be used with --port or --random-port")
+			case tcp && policyFlags.Changed(cmd):
+				return errors.New("traffic policy flags can't be used with --port or --random-port")
+			case !tcp && hostname == "":
 				return errors.New("--hostname is required")
 			}
 
+			var policy *v1alpha1.RouteTrafficPolicy
+			if policyFlags.Changed(cmd) {
+				policy = &v1alpha1.RouteTrafficPolicy{}
+				if err := policyFlags.Apply(cmd, policy); err != nil {
+					return err
+				}
+			}
+
 			cmd.SilenceUsage = true
 
 			urlPath = path.Join("/", urlPath)
 
+			if tcp {
+				var err error
+				if port, err = reservePort(c, port); err != nil {
+					return err
+				}
+			}
+
+			fields := v1alpha1.RouteSpecFields{
+				Hostname: hostname,
+				Domain:   domain,
+				Path:     urlPath,
+				Port:     port,
+			}
+
 			r := &v1alpha1.RouteClaim{
 				TypeMeta: metav1.TypeMeta{
 					Kind: "RouteClaim",
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: space,
-					Name: v1alpha1.GenerateRouteClaimName(
-						hostname,
-						domain,
-						urlPath,
-					),
+					Name:      v1alpha1.GenerateRouteNameFromSpec(fields, ""),
 				},
 				Spec: v1alpha1.RouteClaimSpec{
-					RouteSpecFields: v1alpha1.RouteSpecFields{
-						Hostname: hostname,
-						Domain:   domain,
-						Path:     urlPath,
-					},
+					RouteSpecFields: fields,
+					HTTPSRedirect:   httpsRedirect,
+					TrafficPolicy:   policy,
 				},
 			}
 
 			if _, err := c.Create(space, r); err != nil {
 				return fmt.Errorf("failed to create Route: %s", err)
 			}
+
+			if tcp {
+				fmt.Fprintf(cmd.OutOrStdout(), "Created route %s\n", fields)
+			}
 			return nil
 		},
 	}
@@ -114,6 +163,68 @@ Use the --namespace flag instead.`)
 		"",
 		"URL Path for the route",
 	)
+	cmd.Flags().BoolVar(
+		&httpsRedirect,
+		"https-redirect",
+		false,
+		"Redirect HTTP requests for the route's hostname to HTTPS, the domain must have TLS configured",
+	)
+	addPortFlag(cmd, &port)
+	cmd.Flags().BoolVar(
+		&randomPort,
+		"random-port",
+		false,
+		"Reserve a random free port for a TCP route",
+	)
+	policyFlags.Add(cmd)
 
 	return cmd
 }
+
+// addPortFlag adds the flag for the port of TCP routes.
+func addPortFlag(cmd *cobra.Command, port *int32) {
+	cmd.Flags().Int32Var(
+		port,
+		"port",
+		0,
+		"Port for a TCP route, the domain must be one of the space's TCP domains",
+	)
+}
+
+// reservePort checks that the port isn't used by a TCP route in any space.
+// If port is 0, a random free port is picked instead. TCP gateways can't
+// tell domains apart, so ports are unique across all of them. This only
+// gives a friendlier error, the webhook and the Route reconciler enforce it.
+func reservePort(c routeclaims.Client, port int32) (int32, error) {
+	claims, err := c.List(metav1.NamespaceAll)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list TCP routes: %s", err)
+	}
+
+	taken := map[int32]bool{}
+	for _, claim := range claims {
+		if claim.Spec.Port != 0 {
+			taken[claim.Spec.Port] = true
+		}
+	}
+
+	if port != 0 {
+		if taken[port] {
+			return 0, fmt.Errorf("port %d is already reserved by another route", port)
+		}
+		return port, nil
+	}
+
+	// Start at a random port and walk forward until a free one is found so
+	// the search finishes even when most ports are taken.
+	size := v1alpha1.MaxTCPRoutePort - v1alpha1.MinTCPRoutePort + 1
+	start := rand.Intn(size)
+	for i := 0; i < size; i++ {
+		candidate := int32(v1alpha1.MinTCPRoutePort + (start+i)%size)
+		if !taken[candidate] {
+			return candidate, nil
+		}
+	}
+
+	return 0, errors.New("no free ports left for TCP routes")
+}
@@ -151,6 +151,163 @@ func TestCreateRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"creates TCP route with port": {
+			Args:      []string{"tcp.example.com", "--port=1234"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().List("").Return([]v1alpha1.RouteClaim{
+					{Spec: v1alpha1.RouteClaimSpec{RouteSpecFields: v1alpha1.RouteSpecFields{Domain: "tcp.example.com", Port: 4321}}},
+				}, nil)
+				routesfake.EXPECT().Create(gomock.Any(),
+					&v1alpha1.RouteClaim{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "RouteClaim",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "some-space",
+							Name:      v1alpha1.GenerateTCPRouteClaimName("tcp.example.com", 1234),
+						},
+						Spec: v1alpha1.RouteClaimSpec{
+							RouteSpecFields: v1alpha1.RouteSpecFields{
+								Domain: "tcp.example.com",
+								Path:   "/",
+								Port:   1234,
+							},
+						},
+					},
+				)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"tcp.example.com:1234"})
+			},
+		},
+		"port is reserved in another space": {
+			Args:      []string{"tcp.example.com", "--port=1234"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().List("").Return([]v1alpha1.RouteClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "other-space"},
+						Spec:       v1alpha1.RouteClaimSpec{RouteSpecFields: v1alpha1.RouteSpecFields{Domain: "tcp.example.com", Port: 1234}},
+					},
+				}, nil)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("port 1234 is already reserved by another route"), err)
+			},
+		},
+		"creates TCP route with random port": {
+			Args:      []string{"tcp.example.com", "--random-port"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().List("").Return(nil, nil)
+				routesfake.EXPECT().Create("some-space", gomock.Any()).Do(func(_ string, claim *v1alpha1.RouteClaim) {
+					port := claim.Spec.Port
+					if port < v1alpha1.MinTCPRoutePort || port > v1alpha1.MaxTCPRoutePort {
+						t.Errorf("expected port in the TCP range, got %d", port)
+					}
+					testutil.AssertEqual(t, "name", v1alpha1.GenerateTCPRouteClaimName("tcp.example.com", port), claim.Name)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"port and random port": {
+			Args:      []string{"tcp.example.com", "--port=1234", "--random-port"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--port and --random-port can't be used together"), err)
+			},
+		},
+		"port and hostname": {
+			Args:      []string{"tcp.example.com", "--port=1234", "--hostname=some-hostname"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--hostname and --path can't be used with --port or --random-port"), err)
+			},
+		},
+		"port and HTTPS redirect": {
+			Args:      []string{"tcp.example.com", "--port=1234", "--https-redirect"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--https-redirect can't be used with --port or --random-port"), err)
+			},
+		},
+		"creates route with HTTPS redirect": {
+			Args:      []string{"example.com", "--hostname=some-hostname", "--https-redirect"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create("some-space", gomock.Any()).Do(func(_ string, claim *v1alpha1.RouteClaim) {
+					testutil.AssertEqual(t, "HTTPSRedirect", true, claim.Spec.HTTPSRedirect)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"port and traffic policy": {
+			Args:      []string{"tcp.example.com", "--port=1234", "--timeout=30"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("traffic policy flags can't be used with --port or --random-port"), err)
+			},
+		},
+		"malformed header": {
+			Args:      []string{"example.com", "--hostname=some-hostname", "--add-request-header=X-Team"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("malformed header: X-Team"), err)
+			},
+		},
+		"creates route with traffic policy": {
+			Args: []string{
+				"example.com",
+				"--hostname=some-hostname",
+				"--timeout=30",
+				"--retries=3",
+				"--cors-allow-origin=https://example.com",
+				"--cors-allow-method=GET",
+				"--add-request-header=X-Team=payments",
+				"--remove-response-header=Server",
+			},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create("some-space", gomock.Any()).Do(func(_ string, claim *v1alpha1.RouteClaim) {
+					thirty, three := int32(30), int32(3)
+					testutil.AssertEqual(t, "TrafficPolicy", &v1alpha1.RouteTrafficPolicy{
+						TimeoutSeconds: &thirty,
+						Retries:        &three,
+						CORS: &v1alpha1.RouteCORSPolicy{
+							AllowOrigins: []string{"https://example.com"},
+							AllowMethods: []string{"GET"},
+						},
+						RequestHeaders: &v1alpha1.RouteHeaderOperations{
+							Add: map[string]string{"X-Team": "payments"},
+						},
+						ResponseHeaders: &v1alpha1.RouteHeaderOperations{
+							Remove: []string{"Server"},
+						},
+					}, claim.Spec.TrafficPolicy)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"creates route without traffic policy": {
+			Args:      []string{"example.com", "--hostname=some-hostname"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create("some-space", gomock.Any()).Do(func(_ string, claim *v1alpha1.RouteClaim) {
+					testutil.AssertEqual(t, "TrafficPolicy", (*v1alpha1.RouteTrafficPolicy)(nil), claim.Spec.TrafficPolicy)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
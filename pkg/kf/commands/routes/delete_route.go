@@ -35,14 +35,18 @@ func NewDeleteRouteCommand(
 	c routeclaims.Client,
 	a apps.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		port              int32
+	)
 
 	cmd := &cobra.Command{
-		Use:   "delete-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "delete-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT]",
 		Short: "Delete a route",
 		Example: `
   kf delete-route example.com --hostname myapp # myapp.example.com
   kf delete-route example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf delete-route tcp.example.com --port 1234 # tcp.example.com:1234
   `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -57,6 +61,7 @@ func NewDeleteRouteCommand(
 				Hostname: hostname,
 				Domain:   domain,
 				Path:     urlPath,
+				Port:     port,
 			}
 
 			// TODO: This is O(apps). We could do better if we lookup the
@@ -92,11 +97,7 @@ func NewDeleteRouteCommand(
 			fmt.Fprintf(cmd.OutOrStderr(), "Deleting route claim...\n")
 			if err := c.Delete(
 				p.Namespace,
-				v1alpha1.GenerateRouteClaimName(
-					hostname,
-					domain,
-					urlPath,
-				),
+				v1alpha1.GenerateRouteNameFromSpec(route, ""),
 			); err != nil {
 				return fmt.Errorf("failed to delete Route: %s", err)
 			}
@@ -116,6 +117,7 @@ func NewDeleteRouteCommand(
 		"",
 		"URL Path for the route",
 	)
+	addPortFlag(cmd, &port)
 
 	return cmd
 }
@@ -132,6 +132,22 @@ func TestDeleteRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"delete TCP RouteClaim": {
+			Args:      []string{"tcp.example.com", "--port=1234"},
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().
+					List(gomock.Any(), gomock.Any())
+				fakeRouteClaims.EXPECT().
+					Delete(
+						gomock.Any(),
+						v1alpha1.GenerateTCPRouteClaimName("tcp.example.com", 1234),
+					)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
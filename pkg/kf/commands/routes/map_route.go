@@ -15,6 +15,7 @@
 package routes
 
 import (
+	"errors"
 	"fmt"
 	"path"
 
@@ -30,15 +31,24 @@ func NewMapRouteCommand(
 	p *config.KfParams,
 	appsClient apps.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		weight            int
+		port              int32
+		headers, cookies  []string
+	)
 
 	cmd := &cobra.Command{
-		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT] [--weight WEIGHT] [--header NAME=VALUE] [--cookie NAME=VALUE]",
 		Short: "Map a route to an app",
 		Example: `
   kf map-route myapp example.com --hostname myapp # myapp.example.com
   kf map-route --namespace myspace myapp example.com --hostname myapp # myapp.example.com
   kf map-route myapp example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf map-route myapp-canary example.com --hostname myapp --weight 10 # 10% of myapp.example.com
+  kf map-route myapp-v2 example.com --hostname myapp --header x-canary=true # requests with the header
+  kf map-route myapp-preview example.com --hostname myapp --cookie preview=true # requests with the cookie
+  kf map-route myapp tcp.example.com --port 1234 # tcp.example.com:1234
   `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -49,20 +59,63 @@ func NewMapRouteCommand(
 			}
 			appName, domain := args[0], args[1]
 
+			route := v1alpha1.RouteSpecFields{
+				Hostname: hostname,
+				Domain:   domain,
+				Path:     path.Join("/", urlPath),
+				Port:     port,
+			}
+			if cmd.Flags().Changed("weight") {
+				route.Weight = &weight
+			}
+
+			headerMatch, err := parseKeyValues("header", headers)
+			if err != nil {
+				return err
+			}
+
+			cookieMatch, err := parseKeyValues("cookie", cookies)
+			if err != nil {
+				return err
+			}
+
+			if headerMatch != nil || cookieMatch != nil {
+				if port != 0 {
+					return errors.New("--header and --cookie can't be used with --port")
+				}
+
+				route.Match = &v1alpha1.RouteMatch{
+					Headers: headerMatch,
+					Cookies: cookieMatch,
+				}
+			}
+
 			mutator := func(app *v1alpha1.App) error {
-				app.Spec.Routes = append(
-					app.Spec.Routes,
-					v1alpha1.RouteSpecFields{
-						Hostname: hostname,
-						Domain:   domain,
-						Path:     path.Join("/", urlPath),
-					},
-				)
+				// If the route is already mapped, only its weight and match
+				// can change.
+				for i, r := range app.Spec.Routes {
+					if r.Hostname != route.Hostname ||
+						r.Domain != route.Domain ||
+						path.Join("/", r.Path) != route.Path ||
+						r.Port != route.Port {
+						continue
+					}
+
+					if route.Weight != nil {
+						app.Spec.Routes[i].Weight = route.Weight
+					}
+
+					if route.Match != nil {
+						app.Spec.Routes[i].Match = route.Match
+					}
+					return nil
+				}
+
+				app.Spec.Routes = append(app.Spec.Routes, route)
 				return nil
 			}
 
-			err := appsClient.Transform(p.Namespace, appName, mutator)
-			if err != nil {
+			if err := appsClient.Transform(p.Namespace, appName, mutator); err != nil {
 				return fmt.Errorf("failed to map Route: %s", err)
 			}
 
@@ -82,6 +135,25 @@ func NewMapRouteCommand(
 		"",
 		"URL Path for the route",
 	)
+	addPortFlag(cmd, &port)
+	cmd.Flags().IntVar(
+		&weight,
+		"weight",
+		0,
+		"Percentage (0-100) of the route's traffic to send to the app when multiple apps are mapped to it",
+	)
+	cmd.Flags().StringArrayVar(
+		&headers,
+		"header",
+		nil,
+		"Only send requests with the header to the app, formatted as NAME=VALUE",
+	)
+	cmd.Flags().StringArrayVar(
+		&cookies,
+		"cookie",
+		nil,
+		"Only send requests with the cookie to the app, formatted as NAME=VALUE",
+	)
 
 	return cmd
 }
@@ -104,6 +104,147 @@ func TestMapRoute(t *testing.T) {
 					})
 			},
 		},
+		"transform App by adding a TCP route": {
+			Args:      []string{"some-app", "tcp.example.com", "--port=1234"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						oldApp := v1alpha1.App{}
+						oldApp.Spec.Routes = []v1alpha1.RouteSpecFields{
+							{Domain: "tcp.example.com", Path: "/", Port: 4321},
+						}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						testutil.AssertEqual(t, "len(Routes)", 2, len(oldApp.Spec.Routes))
+						testutil.AssertEqual(t, "Port", int32(1234), oldApp.Spec.Routes[1].Port)
+					})
+			},
+		},
+		"transform App by adding a weighted route": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--weight=10"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						oldApp := v1alpha1.App{}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						weight := 10
+						testutil.AssertEqual(t, "Weight", &weight, oldApp.Spec.Routes[0].Weight)
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"transform App by updating the weight of a mapped route": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--weight=90"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						weight := 10
+						oldApp := v1alpha1.App{}
+						oldApp.Spec.Routes = []v1alpha1.RouteSpecFields{
+							{Hostname: "some-host", Domain: "example.com", Weight: &weight},
+						}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						newWeight := 90
+						testutil.AssertEqual(t, "len(Routes)", 1, len(oldApp.Spec.Routes))
+						testutil.AssertEqual(t, "Weight", &newWeight, oldApp.Spec.Routes[0].Weight)
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"remapping a route without a weight keeps the old weight": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						weight := 10
+						oldApp := v1alpha1.App{}
+						oldApp.Spec.Routes = []v1alpha1.RouteSpecFields{
+							{Hostname: "some-host", Domain: "example.com", Weight: &weight},
+						}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						testutil.AssertEqual(t, "len(Routes)", 1, len(oldApp.Spec.Routes))
+						testutil.AssertEqual(t, "Weight", &weight, oldApp.Spec.Routes[0].Weight)
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"transform App by adding a route with a header match": {
+			Args:      []string{"app-v2", "example.com", "--hostname=api", "--header=x-canary=true"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), "app-v2", gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						oldApp := v1alpha1.App{}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						testutil.AssertEqual(t, "Match", &v1alpha1.RouteMatch{
+							Headers: map[string]string{"x-canary": "true"},
+						}, oldApp.Spec.Routes[0].Match)
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"transform App by updating the match of a mapped route": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--cookie=preview=v2"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						oldApp := v1alpha1.App{}
+						oldApp.Spec.Routes = []v1alpha1.RouteSpecFields{
+							{
+								Hostname: "some-host",
+								Domain:   "example.com",
+								Match:    &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}},
+							},
+						}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						testutil.AssertEqual(t, "len(Routes)", 1, len(oldApp.Spec.Routes))
+						testutil.AssertEqual(t, "Match", &v1alpha1.RouteMatch{
+							Cookies: map[string]string{"preview": "v2"},
+						}, oldApp.Spec.Routes[0].Match)
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"malformed header": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--header=x-canary"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("malformed header: x-canary"), err)
+			},
+		},
+		"match with port": {
+			Args:      []string{"some-app", "tcp.example.com", "--port=1234", "--cookie=preview=v2"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--header and --cookie can't be used with --port"), err)
+			},
+		},
 		"transform App and keep old routes": {
 			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--path=some-path"},
 			Namespace: "some-space",
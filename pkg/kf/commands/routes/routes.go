@@ -26,8 +26,10 @@ import (
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/domains"
 	"github.com/google/kf/pkg/kf/routeclaims"
 	"github.com/google/kf/pkg/kf/routes"
+	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/spf13/cobra"
 )
 
@@ -37,12 +39,17 @@ func NewRoutesCommand(
 	r routes.Client,
 	c routeclaims.Client,
 	a apps.Client,
+	s spaces.Client,
+	d domains.Client,
 ) *cobra.Command {
-	return &cobra.Command{
+	var details bool
+
+	cmd := &cobra.Command{
 		Use:   "routes",
 		Short: "List routes in space",
 		Example: `
   kf routes
+  kf routes --details
   `,
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -70,24 +77,62 @@ func NewRoutesCommand(
 				return fmt.Errorf("failed to fetch Apps: %s", err)
 			}
 
+			// The space's domains decide which routes are served over HTTPS.
+			space, err := s.Get(p.Namespace)
+			if err != nil {
+				return fmt.Errorf("failed to fetch Space: %s", err)
+			}
+
+			domainList, err := d.List()
+			if err != nil {
+				return fmt.Errorf("failed to fetch Domains: %s", err)
+			}
+
+			var clusterDomains []*v1alpha1.Domain
+			for i := range domainList {
+				clusterDomains = append(clusterDomains, &domainList[i])
+			}
+			space = space.WithDomains(clusterDomains)
+
 			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
-				fmt.Fprintln(w, "Host\tDomain\tPath\tApps")
+				header := "Host\tDomain\tPath\tScheme\tStatus\tApps"
+				if details {
+					header += "\tTraffic Policy"
+				}
+				fmt.Fprintln(w, header)
+
 				for _, route := range groupRoutes(routes, routeClaims) {
 					names := strings.Join(appNames(apps, route), ", ")
 					fmt.Fprintf(
 						w,
-						"%s\t%s\t%s\t%s\n",
+						"%s\t%s\t%s\t%s\t%s\t%s",
 						route.Hostname,
 						route.Domain,
 						route.Path,
+						scheme(space, routeClaims, route),
+						status(routes, route),
 						names,
 					)
+
+					if details {
+						fmt.Fprintf(w, "\t%s", describeTrafficPolicy(trafficPolicy(routeClaims, route)))
+					}
+					fmt.Fprintln(w)
 				}
 			})
 
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(
+		&details,
+		"details",
+		false,
+		"Show the traffic policy of each route",
+	)
+
+	return cmd
 }
 
 func groupRoutes(
@@ -110,6 +155,79 @@ func groupRoutes(
 	return []v1alpha1.RouteSpecFields(fields)
 }
 
+// scheme returns the schemes the route is served with. Routes on domains with
+// TLS are served over HTTPS, and only HTTPS if a claim on the route's host
+// asks for HTTP requests to be redirected.
+func scheme(space *v1alpha1.Space, claims []v1alpha1.RouteClaim, route v1alpha1.RouteSpecFields) string {
+	if route.Port != 0 {
+		return "tcp"
+	}
+
+	spaceDomain, _ := space.Spec.Execution.LookupDomain(route.Domain)
+	if spaceDomain.TLS == nil {
+		return "http"
+	}
+
+	for _, claim := range claims {
+		if claim.Spec.HTTPSRedirect &&
+			claim.Spec.Hostname == route.Hostname &&
+			claim.Spec.Domain == route.Domain &&
+			claim.Spec.Port == 0 {
+			return "https"
+		}
+	}
+
+	return "http, https"
+}
+
+// status summarizes the state of the Routes bound to the route. Routes that
+// another space already claimed are never served so the conflict is shown
+// first, then the least ready of the Routes. Claims without Routes have no
+// status.
+func status(routes []v1alpha1.Route, route v1alpha1.RouteSpecFields) string {
+	name := v1alpha1.GenerateRouteNameFromSpec(route, "")
+
+	var summary string
+	for _, r := range routes {
+		if v1alpha1.GenerateRouteNameFromSpec(r.Spec.RouteSpecFields, "") != name {
+			continue
+		}
+
+		if r.Status.ConflictingDomain != "" {
+			return fmt.Sprintf("port reserved by %s in space %s", r.Status.ConflictingDomain, r.Status.ConflictingSpace)
+		}
+
+		if r.Status.ConflictingSpace != "" {
+			return fmt.Sprintf("conflicts with space %s", r.Status.ConflictingSpace)
+		}
+
+		cond := r.Status.GetCondition(v1alpha1.RouteConditionReady)
+		switch {
+		case cond != nil && cond.IsFalse():
+			summary = "failed"
+		case (cond == nil || cond.IsUnknown()) && summary != "failed":
+			summary = "pending"
+		case summary == "":
+			summary = "ready"
+		}
+	}
+
+	return summary
+}
+
+// trafficPolicy returns the traffic policy of the claim for the route, if
+// any.
+func trafficPolicy(claims []v1alpha1.RouteClaim, route v1alpha1.RouteSpecFields) *v1alpha1.RouteTrafficPolicy {
+	name := v1alpha1.GenerateRouteNameFromSpec(route, "")
+	for _, claim := range claims {
+		if v1alpha1.GenerateRouteNameFromSpec(claim.Spec.RouteSpecFields, "") == name {
+			return claim.Spec.TrafficPolicy
+		}
+	}
+
+	return nil
+}
+
 func appNames(apps []v1alpha1.App, route v1alpha1.RouteSpecFields) []string {
 	var names []string
 	for _, app := range apps {
@@ -122,11 +240,43 @@ func appNames(apps []v1alpha1.App, route v1alpha1.RouteSpecFields) []string {
 			continue
 		}
 
-		names = append(names, app.Name)
+		name := app.Name
+		if match := routeMatch(app, route); match != nil {
+			name += fmt.Sprintf(" (%s)", describeMatch(match))
+		}
+
+		names = append(names, name)
 	}
 	return names
 }
 
+// routeMatch returns the match the App was bound to the route with, if any.
+func routeMatch(app v1alpha1.App, route v1alpha1.RouteSpecFields) *v1alpha1.RouteMatch {
+	name := v1alpha1.GenerateRouteNameFromSpec(route, "")
+	for _, appRoute := range app.Spec.Routes {
+		if v1alpha1.GenerateRouteNameFromSpec(appRoute, "") == name {
+			return appRoute.Match
+		}
+	}
+
+	return nil
+}
+
+// describeMatch lists the headers and cookies of a match.
+func describeMatch(match *v1alpha1.RouteMatch) string {
+	var parts []string
+	for name, value := range match.Headers {
+		parts = append(parts, fmt.Sprintf("header %s=%s", name, value))
+	}
+
+	for name, value := range match.Cookies {
+		parts = append(parts, fmt.Sprintf("cookie %s=%s", name, value))
+	}
+	sort.Strings(parts)
+
+	return strings.Join(parts, ", ")
+}
+
 func splitHost(h string) (subDomain, domain string) {
 	// A subdomain implies there are at least 2 periods. If parts has a length
 	// less than 3, then we don't have a subdomain.
@@ -24,10 +24,13 @@ import (
 	fakeapps "github.com/google/kf/pkg/kf/apps/fake"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/routes"
+	fakedomains "github.com/google/kf/pkg/kf/domains/fake"
 	fakerouteclaims "github.com/google/kf/pkg/kf/routeclaims/fake"
 	fakeroutes "github.com/google/kf/pkg/kf/routes/fake"
+	fakespaces "github.com/google/kf/pkg/kf/spaces/fake"
 	"github.com/google/kf/pkg/kf/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
 )
 
 func TestRoutes(t *testing.T) {
@@ -38,6 +41,7 @@ func TestRoutes(t *testing.T) {
 		ExpectedErr error
 		Args        []string
 		Setup       func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient)
+		SetupSpace  func(t *testing.T, fakeSpace *fakespaces.FakeClient, fakeDomain *fakedomains.FakeClient)
 		BufferF     func(t *testing.T, buffer *bytes.Buffer)
 	}{
 		"wrong number of args": {
@@ -100,6 +104,27 @@ func TestRoutes(t *testing.T) {
 				testutil.AssertContainsAll(t, buffer.String(), []string{"host-2", "example.com", "/path1", "app-3"})
 			},
 		},
+		"display matches": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
+				canary := buildApp("app-2", "host-1", "example.com", "/")
+				canary.Spec.Routes[0].Match = &v1alpha1.RouteMatch{
+					Headers: map[string]string{"x-canary": "true"},
+				}
+
+				fakeRouteClaim.EXPECT().List(gomock.Any())
+				fakeRoute.EXPECT().List(gomock.Any()).Return([]v1alpha1.Route{
+					buildRoute("host-1", "example.com", "/"),
+				}, nil)
+				fakeApp.EXPECT().List(gomock.Any()).Return([]v1alpha1.App{
+					buildApp("app-1", "host-1", "example.com", "/"),
+					canary,
+				}, nil)
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{"app-1, app-2 (header x-canary=true)"})
+			},
+		},
 		"display claim": {
 			Namespace: "some-namespace",
 			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
@@ -118,17 +143,182 @@ func TestRoutes(t *testing.T) {
 				testutil.AssertContainsAll(t, buffer.String(), []string{"host-2", "example.com", "/path2", "app-2"})
 			},
 		},
+		"display status": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
+				ready := buildRoute("ready", "example.com", "/")
+				ready.Status.InitializeConditions()
+				ready.Status.MarkClaimed()
+				ready.Status.PropagateVirtualServiceStatus(&networking.VirtualService{})
+				failed := buildRoute("failed", "example.com", "/")
+				failed.Status.InitializeConditions()
+				failed.Status.MarkClaimed()
+				failed.Status.VirtualServiceCondition().MarkReconciliationError("updating", errors.New("some-error"))
+				conflict := buildRoute("conflict", "example.com", "/")
+				conflict.Status.InitializeConditions()
+				conflict.Status.MarkConflictsWithSpace("other-space")
+
+				fakeRouteClaim.EXPECT().List(gomock.Any()).Return([]v1alpha1.RouteClaim{
+					buildRouteClaim("unbound", "example.com", "/"),
+				}, nil)
+				fakeRoute.EXPECT().List(gomock.Any()).Return([]v1alpha1.Route{
+					ready,
+					failed,
+					conflict,
+					buildRoute("pending", "example.com", "/"),
+				}, nil)
+				fakeApp.EXPECT().List(gomock.Any())
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{
+					"conflict  example.com  /     http    conflicts with space other-space",
+					"failed    example.com  /     http    failed",
+					"pending   example.com  /     http    pending",
+					"ready     example.com  /     http    ready",
+					"unbound   example.com  /     http                                      \n",
+				})
+			},
+		},
+		"display port conflicts": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
+				conflict := buildRoute("", "tcp.example.com", "")
+				conflict.Spec.Port = 1234
+				conflict.Status.InitializeConditions()
+				owner := buildRouteClaim("", "tcp2.example.com", "")
+				owner.Namespace = "other-space"
+				conflict.Status.MarkPortConflict(1234, &owner)
+
+				fakeRouteClaim.EXPECT().List(gomock.Any())
+				fakeRoute.EXPECT().List(gomock.Any()).Return([]v1alpha1.Route{conflict}, nil)
+				fakeApp.EXPECT().List(gomock.Any())
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{
+					"tcp.example.com",
+					"port reserved by tcp2.example.com in space other-space",
+				})
+			},
+		},
+		"fetching space fails": {
+			Namespace:   "some-namespace",
+			ExpectedErr: errors.New("failed to fetch Space: some-error"),
+			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
+				fakeRoute.EXPECT().List(gomock.Any()).AnyTimes()
+				fakeRouteClaim.EXPECT().List(gomock.Any()).AnyTimes()
+				fakeApp.EXPECT().List(gomock.Any()).AnyTimes()
+			},
+			SetupSpace: func(t *testing.T, fakeSpace *fakespaces.FakeClient, fakeDomain *fakedomains.FakeClient) {
+				fakeDomain.EXPECT().List().AnyTimes()
+				fakeSpace.EXPECT().Get("some-namespace").Return(nil, errors.New("some-error"))
+			},
+		},
+		"listing domains fails": {
+			Namespace:   "some-namespace",
+			ExpectedErr: errors.New("failed to fetch Domains: some-error"),
+			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
+				fakeRoute.EXPECT().List(gomock.Any()).AnyTimes()
+				fakeRouteClaim.EXPECT().List(gomock.Any()).AnyTimes()
+				fakeApp.EXPECT().List(gomock.Any()).AnyTimes()
+			},
+			SetupSpace: func(t *testing.T, fakeSpace *fakespaces.FakeClient, fakeDomain *fakedomains.FakeClient) {
+				fakeSpace.EXPECT().Get(gomock.Any()).Return(&v1alpha1.Space{}, nil).AnyTimes()
+				fakeDomain.EXPECT().List().Return(nil, errors.New("some-error"))
+			},
+		},
+		"display schemes": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
+				redirect := buildRouteClaim("secure", "tls.example.com", "/")
+				redirect.Spec.HTTPSRedirect = true
+				tcp := buildRouteClaim("", "tcp.example.com", "")
+				tcp.Spec.Port = 1234
+
+				fakeRouteClaim.EXPECT().List(gomock.Any()).Return([]v1alpha1.RouteClaim{
+					buildRouteClaim("plain", "example.com", "/"),
+					buildRouteClaim("both", "tls.example.com", "/"),
+					redirect,
+					tcp,
+				}, nil)
+				fakeRoute.EXPECT().List(gomock.Any())
+				fakeApp.EXPECT().List(gomock.Any())
+			},
+			SetupSpace: func(t *testing.T, fakeSpace *fakespaces.FakeClient, fakeDomain *fakedomains.FakeClient) {
+				space := &v1alpha1.Space{}
+				space.Name = "some-namespace"
+				space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+					{Domain: "example.com", Default: true},
+					{Domain: "tcp.example.com", TCP: true},
+				}
+				fakeSpace.EXPECT().Get("some-namespace").Return(space, nil)
+
+				fakeDomain.EXPECT().List().Return([]v1alpha1.Domain{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "tls.example.com"},
+						Spec:       v1alpha1.DomainSpec{TLS: &v1alpha1.DomainTLS{SelfSigned: true}},
+					},
+				}, nil)
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{
+					"plain   example.com      /     http   ",
+					"both    tls.example.com  /     http, https  ",
+					"secure  tls.example.com  /     https  ",
+					"        tcp.example.com        tcp  ",
+				})
+			},
+		},
+		"display details": {
+			Namespace: "some-namespace",
+			Args:      []string{"--details"},
+			Setup: func(t *testing.T, fakeRoute *fakeroutes.FakeClient, fakeRouteClaim *fakerouteclaims.FakeClient, fakeApp *fakeapps.FakeClient) {
+				thirty, three := int32(30), int32(3)
+				claim := buildRouteClaim("host-1", "example.com", "/")
+				claim.Spec.TrafficPolicy = &v1alpha1.RouteTrafficPolicy{
+					TimeoutSeconds: &thirty,
+					Retries:        &three,
+					CORS: &v1alpha1.RouteCORSPolicy{
+						AllowOrigins: []string{"https://example.com"},
+					},
+					RequestHeaders: &v1alpha1.RouteHeaderOperations{
+						Add: map[string]string{"X-Team": "payments"},
+					},
+					ResponseHeaders: &v1alpha1.RouteHeaderOperations{
+						Remove: []string{"Server"},
+					},
+				}
+
+				fakeRouteClaim.EXPECT().List(gomock.Any()).Return([]v1alpha1.RouteClaim{claim}, nil)
+				fakeRoute.EXPECT().List(gomock.Any())
+				fakeApp.EXPECT().List(gomock.Any())
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{
+					"Traffic Policy",
+					"timeout=30s retries=3 cors=https://example.com request-headers=+X-Team response-headers=-Server",
+				})
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			fakeRoute := fakeroutes.NewFakeClient(ctrl)
 			fakeRouteClaim := fakerouteclaims.NewFakeClient(ctrl)
 			fakeApp := fakeapps.NewFakeClient(ctrl)
+			fakeSpace := fakespaces.NewFakeClient(ctrl)
+			fakeDomain := fakedomains.NewFakeClient(ctrl)
 
 			if tc.Setup != nil {
 				tc.Setup(t, fakeRoute, fakeRouteClaim, fakeApp)
 			}
 
+			if tc.SetupSpace != nil {
+				tc.SetupSpace(t, fakeSpace, fakeDomain)
+			} else {
+				fakeSpace.EXPECT().Get(gomock.Any()).Return(&v1alpha1.Space{}, nil).AnyTimes()
+				fakeDomain.EXPECT().List().AnyTimes()
+			}
+
 			var buffer bytes.Buffer
 			cmd := routes.NewRoutesCommand(
 				&config.KfParams{
@@ -137,6 +327,8 @@ func TestRoutes(t *testing.T) {
 				fakeRoute,
 				fakeRouteClaim,
 				fakeApp,
+				fakeSpace,
+				fakeDomain,
 			)
 			cmd.SetArgs(tc.Args)
 			cmd.SetOutput(&buffer)
@@ -0,0 +1,314 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+// trafficPolicyFlagNames are the names of the flags registered by
+// trafficPolicyFlags.Add.
+var trafficPolicyFlagNames = []string{
+	"timeout",
+	"retries",
+	"per-try-timeout",
+	"cors-allow-origin",
+	"cors-allow-method",
+	"cors-allow-header",
+	"cors-expose-header",
+	"cors-max-age",
+	"add-request-header",
+	"remove-request-header",
+	"add-response-header",
+	"remove-response-header",
+}
+
+// trafficPolicyFlags holds the flags create-route and update-route use to
+// set a route's traffic policy.
+type trafficPolicyFlags struct {
+	timeout       int32
+	retries       int32
+	perTryTimeout int32
+
+	corsAllowOrigins  []string
+	corsAllowMethods  []string
+	corsAllowHeaders  []string
+	corsExposeHeaders []string
+	corsMaxAge        int32
+
+	addRequestHeaders     []string
+	removeRequestHeaders  []string
+	addResponseHeaders    []string
+	removeResponseHeaders []string
+}
+
+// Add registers the traffic policy flags on the command.
+func (f *trafficPolicyFlags) Add(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	flags.Int32Var(
+		&f.timeout,
+		"timeout",
+		0,
+		"Time (in seconds) a request to the route may take before it fails",
+	)
+	flags.Int32Var(
+		&f.retries,
+		"retries",
+		0,
+		"Number of times a failed request to the route is retried",
+	)
+	flags.Int32Var(
+		&f.perTryTimeout,
+		"per-try-timeout",
+		0,
+		"Time (in seconds) each retry may take, defaults to the timeout",
+	)
+	flags.StringArrayVar(
+		&f.corsAllowOrigins,
+		"cors-allow-origin",
+		nil,
+		"Origin allowed to make cross-origin requests to the route, enables CORS",
+	)
+	flags.StringArrayVar(
+		&f.corsAllowMethods,
+		"cors-allow-method",
+		nil,
+		"HTTP method allowed in cross-origin requests",
+	)
+	flags.StringArrayVar(
+		&f.corsAllowHeaders,
+		"cors-allow-header",
+		nil,
+		"Header allowed in cross-origin requests",
+	)
+	flags.StringArrayVar(
+		&f.corsExposeHeaders,
+		"cors-expose-header",
+		nil,
+		"Response header browsers may read from cross-origin requests",
+	)
+	flags.Int32Var(
+		&f.corsMaxAge,
+		"cors-max-age",
+		0,
+		"Time (in seconds) browsers may cache the result of a CORS preflight request",
+	)
+	flags.StringArrayVar(
+		&f.addRequestHeaders,
+		"add-request-header",
+		nil,
+		"Header to add to requests before they reach the apps, formatted as NAME=VALUE",
+	)
+	flags.StringArrayVar(
+		&f.removeRequestHeaders,
+		"remove-request-header",
+		nil,
+		"Header to remove from requests before they reach the apps",
+	)
+	flags.StringArrayVar(
+		&f.addResponseHeaders,
+		"add-response-header",
+		nil,
+		"Header to add to responses from the apps, formatted as NAME=VALUE",
+	)
+	flags.StringArrayVar(
+		&f.removeResponseHeaders,
+		"remove-response-header",
+		nil,
+		"Header to remove from responses from the apps",
+	)
+}
+
+// Changed returns true if any of the traffic policy flags were set.
+func (f *trafficPolicyFlags) Changed(cmd *cobra.Command) bool {
+	for _, name := range trafficPolicyFlagNames {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply sets the fields of the policy that the user changed with flags. The
+// rest of the policy is left alone so update-route only touches what it's
+// asked to.
+func (f *trafficPolicyFlags) Apply(cmd *cobra.Command, policy *v1alpha1.RouteTrafficPolicy) error {
+	flags := cmd.Flags()
+
+	if flags.Changed("timeout") {
+		policy.TimeoutSeconds = int32Ptr(f.timeout)
+	}
+
+	if flags.Changed("retries") {
+		policy.Retries = int32Ptr(f.retries)
+	}
+
+	if flags.Changed("per-try-timeout") {
+		policy.PerTryTimeoutSeconds = int32Ptr(f.perTryTimeout)
+	}
+
+	if flags.Changed("cors-allow-origin") ||
+		flags.Changed("cors-allow-method") ||
+		flags.Changed("cors-allow-header") ||
+		flags.Changed("cors-expose-header") ||
+		flags.Changed("cors-max-age") {
+		if policy.CORS == nil {
+			policy.CORS = &v1alpha1.RouteCORSPolicy{}
+		}
+
+		if flags.Changed("cors-allow-origin") {
+			policy.CORS.AllowOrigins = f.corsAllowOrigins
+		}
+
+		if flags.Changed("cors-allow-method") {
+			policy.CORS.AllowMethods = f.corsAllowMethods
+		}
+
+		if flags.Changed("cors-allow-header") {
+			policy.CORS.AllowHeaders = f.corsAllowHeaders
+		}
+
+		if flags.Changed("cors-expose-header") {
+			policy.CORS.ExposeHeaders = f.corsExposeHeaders
+		}
+
+		if flags.Changed("cors-max-age") {
+			policy.CORS.MaxAgeSeconds = int32Ptr(f.corsMaxAge)
+		}
+	}
+
+	var err error
+	if policy.RequestHeaders, err = applyHeaderFlags(policy.RequestHeaders, f.addRequestHeaders, f.removeRequestHeaders); err != nil {
+		return err
+	}
+
+	if policy.ResponseHeaders, err = applyHeaderFlags(policy.ResponseHeaders, f.addResponseHeaders, f.removeResponseHeaders); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// applyHeaderFlags adds the NAME=VALUE headers in add and the names in remove
+// to the header operations.
+func applyHeaderFlags(ops *v1alpha1.RouteHeaderOperations, add, remove []string) (*v1alpha1.RouteHeaderOperations, error) {
+	if len(add) == 0 && len(remove) == 0 {
+		return ops, nil
+	}
+
+	headers, err := parseKeyValues("header", add)
+	if err != nil {
+		return nil, err
+	}
+
+	if ops == nil {
+		ops = &v1alpha1.RouteHeaderOperations{}
+	}
+
+	for name, value := range headers {
+		if ops.Add == nil {
+			ops.Add = map[string]string{}
+		}
+		ops.Add[name] = value
+	}
+
+	ops.Remove = append(ops.Remove, remove...)
+
+	return ops, nil
+}
+
+// parseKeyValues turns a slice of strings formatted as NAME=VALUE into a map.
+// Values may contain '=', kind names what's being parsed in errors.
+func parseKeyValues(kind string, pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	out := map[string]string{}
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("malformed %s: %s", kind, pair)
+		}
+
+		out[parts[0]] = parts[1]
+	}
+
+	return out, nil
+}
+
+// describeTrafficPolicy summarizes a traffic policy in a single line for
+// tables.
+func describeTrafficPolicy(policy *v1alpha1.RouteTrafficPolicy) string {
+	if policy == nil {
+		return ""
+	}
+
+	var parts []string
+	if policy.TimeoutSeconds != nil {
+		parts = append(parts, fmt.Sprintf("timeout=%ds", *policy.TimeoutSeconds))
+	}
+
+	if policy.Retries != nil {
+		retries := fmt.Sprintf("retries=%d", *policy.Retries)
+		if policy.PerTryTimeoutSeconds != nil {
+			retries += fmt.Sprintf("/%ds", *policy.PerTryTimeoutSeconds)
+		}
+		parts = append(parts, retries)
+	}
+
+	if policy.CORS != nil {
+		parts = append(parts, "cors="+strings.Join(policy.CORS.AllowOrigins, ","))
+	}
+
+	if headers := describeHeaderOperations(policy.RequestHeaders); headers != "" {
+		parts = append(parts, "request-headers="+headers)
+	}
+
+	if headers := describeHeaderOperations(policy.ResponseHeaders); headers != "" {
+		parts = append(parts, "response-headers="+headers)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func describeHeaderOperations(ops *v1alpha1.RouteHeaderOperations) string {
+	if ops == nil {
+		return ""
+	}
+
+	var names []string
+	for name := range ops.Add {
+		names = append(names, "+"+name)
+	}
+	sort.Strings(names)
+
+	for _, name := range ops.Remove {
+		names = append(names, "-"+name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+func int32Ptr(val int32) *int32 {
+	return &val
+}
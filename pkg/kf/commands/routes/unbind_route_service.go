@@ -0,0 +1,79 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/routeclaims"
+	"github.com/spf13/cobra"
+)
+
+// NewUnbindRouteServiceCommand creates an UnbindRouteService command.
+func NewUnbindRouteServiceCommand(
+	p *config.KfParams,
+	c routeclaims.Client,
+) *cobra.Command {
+	var hostname, urlPath string
+
+	cmd := &cobra.Command{
+		Use:   "unbind-route-service DOMAIN SERVICE_INSTANCE [--hostname HOSTNAME] [--path PATH]",
+		Short: "Stop forwarding a route's traffic through a service",
+		Example: `
+  kf unbind-route-service example.com my-ratelimiter --hostname myapp
+  kf unbind-route-service example.com my-ratelimiter --hostname myapp --path /mypath`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			domain, instanceName := args[0], args[1]
+
+			if hostname == "" {
+				return errors.New("--hostname is required")
+			}
+
+			cmd.SilenceUsage = true
+
+			urlPath = path.Join("/", urlPath)
+			claimName := v1alpha1.GenerateRouteClaimName(hostname, domain, urlPath)
+
+			if err := c.Transform(p.Namespace, claimName, func(claim *v1alpha1.RouteClaim) error {
+				if claim.Spec.RouteService == nil || claim.Spec.RouteService.InstanceName != instanceName {
+					return fmt.Errorf("service instance %s isn't bound to the route", instanceName)
+				}
+
+				claim.Spec.RouteService = nil
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to unbind route service: %s", err)
+			}
+
+			route := v1alpha1.RouteSpecFields{Hostname: hostname, Domain: domain, Path: urlPath}
+			fmt.Fprintf(cmd.OutOrStdout(), "Unbound route service %s from route %s\n", instanceName, route.String())
+			return nil
+		},
+	}
+
+	addRouteServiceFlags(cmd, &hostname, &urlPath)
+
+	return cmd
+}
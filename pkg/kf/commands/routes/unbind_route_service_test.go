@@ -0,0 +1,137 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/routes"
+	"github.com/google/kf/pkg/kf/routeclaims"
+	fakerouteclaims "github.com/google/kf/pkg/kf/routeclaims/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestUnbindRouteService(t *testing.T) {
+	t.Parallel()
+
+	// applyToClaim runs the mutator passed to Transform against the claim.
+	applyToClaim := func(claim *v1alpha1.RouteClaim) func(string, string, routeclaims.Mutator) error {
+		return func(namespace, name string, mutator routeclaims.Mutator) error {
+			return mutator(claim)
+		}
+	}
+
+	boundClaim := func() *v1alpha1.RouteClaim {
+		claim := &v1alpha1.RouteClaim{}
+		claim.Spec.RouteService = &v1alpha1.RouteServiceBinding{
+			InstanceName: "my-ratelimiter",
+			URL:          "https://ratelimiter.example.com",
+		}
+		return claim
+	}
+
+	for tn, tc := range map[string]struct {
+		Namespace string
+		Args      []string
+		Setup     func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient)
+		Assert    func(t *testing.T, buffer *bytes.Buffer, err error)
+	}{
+		"missing hostname": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "my-ratelimiter"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--hostname is required"), err)
+			},
+		},
+		"different service bound": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "other-service", "--hostname=myapp"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				fakeRouteClaims.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(applyToClaim(boundClaim()))
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("failed to unbind route service: service instance other-service isn't bound to the route"), err)
+			},
+		},
+		"nothing bound": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "my-ratelimiter", "--hostname=myapp"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				fakeRouteClaims.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(applyToClaim(&v1alpha1.RouteClaim{}))
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("failed to unbind route service: service instance my-ratelimiter isn't bound to the route"), err)
+			},
+		},
+		"unbinds route service": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "my-ratelimiter", "--hostname=myapp"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				claim := boundClaim()
+				expectedName := v1alpha1.GenerateRouteClaimName("myapp", "example.com", "/")
+				fakeRouteClaims.EXPECT().
+					Transform("some-namespace", expectedName, gomock.Any()).
+					DoAndReturn(func(namespace, name string, mutator routeclaims.Mutator) error {
+						testutil.AssertNil(t, "mutator err", mutator(claim))
+						testutil.AssertEqual(t, "RouteService", (*v1alpha1.RouteServiceBinding)(nil), claim.Spec.RouteService)
+						return nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"Unbound route service my-ratelimiter from route myapp.example.com/"})
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeRouteClaims := fakerouteclaims.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fakeRouteClaims)
+			}
+
+			var buffer bytes.Buffer
+			cmd := routes.NewUnbindRouteServiceCommand(
+				&config.KfParams{
+					Namespace: tc.Namespace,
+				},
+				fakeRouteClaims,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+
+			if tc.Assert != nil {
+				tc.Assert(t, &buffer, gotErr)
+			}
+
+			if gotErr != nil {
+				return
+			}
+			ctrl.Finish()
+		})
+	}
+}
@@ -31,15 +31,19 @@ func NewUnmapRouteCommand(
 	p *config.KfParams,
 	c apps.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		port              int32
+	)
 
 	cmd := &cobra.Command{
-		Use:   "unmap-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "unmap-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT]",
 		Short: "Unmap a route from an app",
 		Example: `
   kf unmap-route myapp example.com --hostname myapp # myapp.example.com
   kf unmap-route --namespace myspace myapp example.com --hostname myapp # myapp.example.com
   kf unmap-route myapp example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf unmap-route myapp tcp.example.com --port 1234 # tcp.example.com:1234
   `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -54,6 +58,7 @@ func NewUnmapRouteCommand(
 				Hostname: hostname,
 				Domain:   domain,
 				Path:     path.Join("/", urlPath),
+				Port:     port,
 			}
 
 			return unmapApp(p.Namespace, appName, route, c)
@@ -72,6 +77,7 @@ func NewUnmapRouteCommand(
 		"",
 		"URL Path for the route",
 	)
+	addPortFlag(cmd, &port)
 
 	return cmd
 }
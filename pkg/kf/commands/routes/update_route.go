@@ -0,0 +1,122 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/routeclaims"
+	"github.com/spf13/cobra"
+)
+
+// NewUpdateRouteCommand creates an UpdateRoute command.
+func NewUpdateRouteCommand(
+	p *config.KfParams,
+	c routeclaims.Client,
+) *cobra.Command {
+	var (
+		hostname, urlPath string
+		clearPolicy       bool
+		policyFlags       trafficPolicyFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update-route DOMAIN --hostname HOSTNAME [--path PATH] [--timeout SECONDS] [--retries N] [--clear-traffic-policy]",
+		Short: "Update the traffic policy of a route",
+		Long: `Updates the traffic policy of an HTTP route. Only the parts of the
+		policy given as flags are changed, use --clear-traffic-policy to start
+		from an empty policy.`,
+		Example: `
+  kf update-route example.com --hostname myapp --timeout 30 --retries 3
+  kf update-route example.com --hostname myapp --path /mypath --cors-allow-origin https://example.com
+  kf update-route example.com --hostname myapp --clear-traffic-policy`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			domain := args[0]
+
+			if hostname == "" {
+				return errors.New("--hostname is required")
+			}
+
+			if !clearPolicy && !policyFlags.Changed(cmd) {
+				return errors.New("at least one traffic policy flag or --clear-traffic-policy is required")
+			}
+
+			cmd.SilenceUsage = true
+
+			urlPath = path.Join("/", urlPath)
+			claimName := v1alpha1.GenerateRouteClaimName(hostname, domain, urlPath)
+
+			if err := c.Transform(p.Namespace, claimName, func(claim *v1alpha1.RouteClaim) error {
+				if clearPolicy {
+					claim.Spec.TrafficPolicy = nil
+				}
+
+				if !policyFlags.Changed(cmd) {
+					return nil
+				}
+
+				policy := claim.Spec.TrafficPolicy
+				if policy == nil {
+					policy = &v1alpha1.RouteTrafficPolicy{}
+				}
+
+				if err := policyFlags.Apply(cmd, policy); err != nil {
+					return err
+				}
+
+				claim.Spec.TrafficPolicy = policy
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to update route: %s", err)
+			}
+
+			route := v1alpha1.RouteSpecFields{Hostname: hostname, Domain: domain, Path: urlPath}
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated route %s\n", route.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&hostname,
+		"hostname",
+		"",
+		"Hostname for the route",
+	)
+	cmd.Flags().StringVar(
+		&urlPath,
+		"path",
+		"",
+		"URL Path for the route",
+	)
+	cmd.Flags().BoolVar(
+		&clearPolicy,
+		"clear-traffic-policy",
+		false,
+		"Remove the route's traffic policy before applying any other flags",
+	)
+	policyFlags.Add(cmd)
+
+	return cmd
+}
@@ -0,0 +1,174 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/routes"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/routeclaims"
+	fakerouteclaims "github.com/google/kf/pkg/kf/routeclaims/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestUpdateRoute(t *testing.T) {
+	t.Parallel()
+
+	ten, thirty, three := int32(10), int32(30), int32(3)
+
+	for tn, tc := range map[string]struct {
+		Namespace string
+		Args      []string
+		Setup     func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient)
+		Assert    func(t *testing.T, buffer *bytes.Buffer, err error)
+	}{
+		"wrong number of args": {
+			Namespace: "some-namespace",
+			Args:      []string{},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("accepts 1 arg(s), received 0"), err)
+			},
+		},
+		"without namespace": {
+			Args: []string{"example.com", "--hostname=myapp", "--timeout=30"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New(utils.EmptyNamespaceError), err)
+			},
+		},
+		"missing hostname": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "--timeout=30"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--hostname is required"), err)
+			},
+		},
+		"no changes": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "--hostname=myapp"},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("at least one traffic policy flag or --clear-traffic-policy is required"), err)
+			},
+		},
+		"transform fails": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "--hostname=myapp", "--timeout=30"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				fakeRouteClaims.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("some-error"))
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("failed to update route: some-error"), err)
+			},
+		},
+		"updates only the given fields": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "--hostname=myapp", "--path=somepath", "--retries=3"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				expectedName := v1alpha1.GenerateRouteClaimName("myapp", "example.com", "/somepath")
+				fakeRouteClaims.EXPECT().
+					Transform("some-namespace", expectedName, gomock.Any()).
+					DoAndReturn(func(namespace, name string, mutator routeclaims.Mutator) error {
+						claim := &v1alpha1.RouteClaim{}
+						claim.Spec.TrafficPolicy = &v1alpha1.RouteTrafficPolicy{TimeoutSeconds: &thirty}
+						testutil.AssertNil(t, "mutator err", mutator(claim))
+						testutil.AssertEqual(t, "TrafficPolicy", &v1alpha1.RouteTrafficPolicy{
+							TimeoutSeconds: &thirty,
+							Retries:        &three,
+						}, claim.Spec.TrafficPolicy)
+						return nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertContainsAll(t, buffer.String(), []string{"Updated route myapp.example.com/somepath"})
+			},
+		},
+		"clears the policy": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "--hostname=myapp", "--clear-traffic-policy"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				fakeRouteClaims.EXPECT().
+					Transform("some-namespace", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(namespace, name string, mutator routeclaims.Mutator) error {
+						claim := &v1alpha1.RouteClaim{}
+						claim.Spec.TrafficPolicy = &v1alpha1.RouteTrafficPolicy{TimeoutSeconds: &thirty}
+						testutil.AssertNil(t, "mutator err", mutator(claim))
+						testutil.AssertEqual(t, "TrafficPolicy", (*v1alpha1.RouteTrafficPolicy)(nil), claim.Spec.TrafficPolicy)
+						return nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"clears and replaces the policy": {
+			Namespace: "some-namespace",
+			Args:      []string{"example.com", "--hostname=myapp", "--clear-traffic-policy", "--timeout=10"},
+			Setup: func(t *testing.T, fakeRouteClaims *fakerouteclaims.FakeClient) {
+				fakeRouteClaims.EXPECT().
+					Transform("some-namespace", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(namespace, name string, mutator routeclaims.Mutator) error {
+						claim := &v1alpha1.RouteClaim{}
+						claim.Spec.TrafficPolicy = &v1alpha1.RouteTrafficPolicy{Retries: &three}
+						testutil.AssertNil(t, "mutator err", mutator(claim))
+						testutil.AssertEqual(t, "TrafficPolicy", &v1alpha1.RouteTrafficPolicy{
+							TimeoutSeconds: &ten,
+						}, claim.Spec.TrafficPolicy)
+						return nil
+					})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeRouteClaims := fakerouteclaims.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fakeRouteClaims)
+			}
+
+			var buffer bytes.Buffer
+			cmd := routes.NewUpdateRouteCommand(
+				&config.KfParams{
+					Namespace: tc.Namespace,
+				},
+				fakeRouteClaims,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+
+			if tc.Assert != nil {
+				tc.Assert(t, &buffer, gotErr)
+			}
+
+			if gotErr != nil {
+				return
+			}
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/secrets"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/spf13/cobra"
+)
+
+// NewCreateServiceBrokerCommand allows users to register service brokers.
+func NewCreateServiceBrokerCommand(
+	p *config.KfParams,
+	client services.ClientInterface,
+	secretsClient secrets.ClientInterface,
+) *cobra.Command {
+	var spaceScoped bool
+
+	createCmd := &cobra.Command{
+		Use:     "create-service-broker BROKER_NAME USERNAME PASSWORD URL [--space-scoped]",
+		Aliases: []string{"csb"},
+		Short:   "Register a service broker",
+		Long: `Registers a service broker with the cluster, or only with the
+		targeted space if --space-scoped is set.
+
+		The username and password are stored in a secret that the service
+		catalog uses to authenticate with the broker.`,
+		Example: `
+  kf create-service-broker mybroker user pass https://broker.example.com
+  kf create-service-broker mybroker user pass https://broker.example.com --space-scoped`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			brokerName, username, password, url := args[0], args[1], args[2], args[3]
+
+			cmd.SilenceUsage = true
+
+			namespace, err := brokerNamespace(p, spaceScoped)
+			if err != nil {
+				return err
+			}
+
+			secretName := secrets.BrokerCredentialSecretName(brokerName)
+			if err := secretsClient.Create(
+				secretName,
+				secrets.WithCreateNamespace(namespace),
+				secrets.WithCreateStringData(map[string]string{
+					"username": username,
+					"password": password,
+				}),
+			); err != nil {
+				return fmt.Errorf("failed to store broker credentials: %s", err)
+			}
+
+			if _, err := client.CreateBroker(
+				brokerName,
+				url,
+				services.WithCreateBrokerNamespace(namespace),
+				services.WithCreateBrokerCredentialsSecret(secretName),
+				services.WithCreateBrokerSpaceScoped(spaceScoped),
+			); err != nil {
+				// Don't leave credentials behind for a broker that doesn't exist.
+				if deleteErr := secretsClient.Delete(secretName, secrets.WithDeleteNamespace(namespace)); deleteErr != nil {
+					return fmt.Errorf("%s (cleaning up broker credentials failed: %s)", err, deleteErr)
+				}
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Registered service broker %s\n", brokerName)
+			return nil
+		},
+	}
+
+	createCmd.Flags().BoolVar(
+		&spaceScoped,
+		"space-scoped",
+		false,
+		"Only register the broker with the targeted space.",
+	)
+
+	return createCmd
+}
+
+// brokerNamespace returns the namespace the broker's credentials are stored
+// in. Cluster brokers keep them in the Kf namespace so they outlive any
+// single space.
+func brokerNamespace(p *config.KfParams, spaceScoped bool) (string, error) {
+	if !spaceScoped {
+		return v1alpha1.KfNamespace, nil
+	}
+
+	if err := utils.ValidateNamespace(p); err != nil {
+		return "", err
+	}
+
+	return p.Namespace, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	servicebrokerscmd "github.com/google/kf/pkg/kf/commands/service-brokers"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/secrets"
+	secretsfake "github.com/google/kf/pkg/kf/secrets/fake"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/google/kf/pkg/kf/services/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/spf13/cobra"
+)
+
+func TestNewCreateServiceBrokerCommand(t *testing.T) {
+	args := []string{"mybroker", "user", "pass", "https://broker.example.com"}
+
+	cases := map[string]brokerTest{
+		"too few params": {
+			Args:        []string{"mybroker", "user", "pass"},
+			ExpectedErr: errors.New("accepts 4 arg(s), received 3"),
+		},
+		"cluster broker": {
+			Args: args,
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				s.EXPECT().Create("service-broker-mybroker-creds", gomock.Any()).Do(func(name string, opts ...secrets.CreateOption) {
+					testutil.AssertEqual(t, "namespace", v1alpha1.KfNamespace, secrets.CreateOptions(opts).Namespace())
+					testutil.AssertEqual(t, "data", map[string]string{
+						"username": "user",
+						"password": "pass",
+					}, secrets.CreateOptions(opts).StringData())
+				})
+
+				f.EXPECT().CreateBroker("mybroker", "https://broker.example.com", gomock.Any()).Do(func(name, url string, opts ...services.CreateBrokerOption) {
+					testutil.AssertEqual(t, "namespace", v1alpha1.KfNamespace, services.CreateBrokerOptions(opts).Namespace())
+					testutil.AssertEqual(t, "secret", "service-broker-mybroker-creds", services.CreateBrokerOptions(opts).CredentialsSecret())
+					testutil.AssertEqual(t, "space scoped", false, services.CreateBrokerOptions(opts).SpaceScoped())
+				}).Return(&v1beta1.ClusterServiceBroker{}, nil)
+			},
+			ExpectedStrings: []string{"mybroker"},
+		},
+		"space scoped broker": {
+			Args:      append(args, "--space-scoped"),
+			Namespace: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				s.EXPECT().Create("service-broker-mybroker-creds", gomock.Any()).Do(func(name string, opts ...secrets.CreateOption) {
+					testutil.AssertEqual(t, "namespace", "custom-ns", secrets.CreateOptions(opts).Namespace())
+				})
+
+				f.EXPECT().CreateBroker("mybroker", "https://broker.example.com", gomock.Any()).Do(func(name, url string, opts ...services.CreateBrokerOption) {
+					testutil.AssertEqual(t, "namespace", "custom-ns", services.CreateBrokerOptions(opts).Namespace())
+					testutil.AssertEqual(t, "space scoped", true, services.CreateBrokerOptions(opts).SpaceScoped())
+				}).Return(&v1beta1.ServiceBroker{}, nil)
+			},
+		},
+		"space scoped broker without namespace": {
+			Args:        append(args, "--space-scoped"),
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"storing credentials fails": {
+			Args: args,
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				s.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+			},
+			ExpectedErr: errors.New("failed to store broker credentials: some-error"),
+		},
+		"registering broker fails cleans up credentials": {
+			Args: args,
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				s.EXPECT().Create(gomock.Any(), gomock.Any())
+				f.EXPECT().CreateBroker(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+				s.EXPECT().Delete("service-broker-mybroker-creds", gomock.Any())
+			},
+			ExpectedErr: errors.New("some-error"),
+		},
+		"cleaning up credentials fails": {
+			Args: args,
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				s.EXPECT().Create(gomock.Any(), gomock.Any())
+				f.EXPECT().CreateBroker(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+				s.EXPECT().Delete("service-broker-mybroker-creds", gomock.Any()).Return(errors.New("delete-error"))
+			},
+			ExpectedErr: errors.New("some-error (cleaning up broker credentials failed: delete-error)"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runTest(t, tc, func(p *config.KfParams, client *fake.FakeClientInterface, secretsClient *secretsfake.FakeClientInterface) *cobra.Command {
+				return servicebrokerscmd.NewCreateServiceBrokerCommand(p, client, secretsClient)
+			})
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/secrets"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// NewDeleteServiceBrokerCommand allows users to remove service brokers.
+func NewDeleteServiceBrokerCommand(
+	p *config.KfParams,
+	client services.ClientInterface,
+	secretsClient secrets.ClientInterface,
+) *cobra.Command {
+	var spaceScoped bool
+
+	deleteCmd := &cobra.Command{
+		Use:     "delete-service-broker BROKER_NAME [--space-scoped]",
+		Aliases: []string{"dsb"},
+		Short:   "Remove a service broker",
+		Long: `Removes a service broker and the credentials kf stored for it.
+		Use --space-scoped to remove a broker registered with the targeted
+		space.`,
+		Example: `
+  kf delete-service-broker mybroker
+  kf delete-service-broker mybroker --space-scoped`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			brokerName := args[0]
+
+			cmd.SilenceUsage = true
+
+			namespace, err := brokerNamespace(p, spaceScoped)
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteBroker(
+				brokerName,
+				services.WithDeleteBrokerNamespace(namespace),
+				services.WithDeleteBrokerSpaceScoped(spaceScoped),
+			); err != nil {
+				return err
+			}
+
+			err = secretsClient.Delete(
+				secrets.BrokerCredentialSecretName(brokerName),
+				secrets.WithDeleteNamespace(namespace),
+			)
+			if err != nil && !apierrs.IsNotFound(err) {
+				return fmt.Errorf("failed to delete broker credentials: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted service broker %s\n", brokerName)
+			return nil
+		},
+	}
+
+	deleteCmd.Flags().BoolVar(
+		&spaceScoped,
+		"space-scoped",
+		false,
+		"The broker is registered with the targeted space rather than the cluster.",
+	)
+
+	return deleteCmd
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/commands/config"
+	secretsfake "github.com/google/kf/pkg/kf/secrets/fake"
+	"github.com/google/kf/pkg/kf/services/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+)
+
+type commandFactory func(p *config.KfParams, client *fake.FakeClientInterface, secretsClient *secretsfake.FakeClientInterface) *cobra.Command
+
+type brokerTest struct {
+	Args      []string
+	Setup     func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface)
+	Namespace string
+
+	ExpectedErr     error
+	ExpectedStrings []string
+}
+
+func runTest(t *testing.T, tc brokerTest, newCommand commandFactory) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := fake.NewFakeClientInterface(ctrl)
+	secretsClient := secretsfake.NewFakeClientInterface(ctrl)
+	if tc.Setup != nil {
+		tc.Setup(t, client, secretsClient)
+	}
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Namespace: tc.Namespace,
+	}
+
+	cmd := newCommand(p, client, secretsClient)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/spf13/cobra"
+)
+
+// NewRefreshServiceBrokerCommand allows users to refresh a broker's catalog.
+func NewRefreshServiceBrokerCommand(
+	p *config.KfParams,
+	client services.ClientInterface,
+) *cobra.Command {
+	var spaceScoped bool
+
+	refreshCmd := &cobra.Command{
+		Use:   "refresh-service-broker BROKER_NAME [--space-scoped]",
+		Short: "Refresh the catalog of a service broker",
+		Long: `Requests the service catalog fetch the broker's services and plans
+		again so changes show up in the marketplace.`,
+		Example: `
+  kf refresh-service-broker mybroker
+  kf refresh-service-broker mybroker --space-scoped`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			brokerName := args[0]
+
+			cmd.SilenceUsage = true
+
+			namespace, err := brokerNamespace(p, spaceScoped)
+			if err != nil {
+				return err
+			}
+
+			if err := client.SyncBroker(
+				brokerName,
+				services.WithSyncBrokerNamespace(namespace),
+				services.WithSyncBrokerSpaceScoped(spaceScoped),
+			); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Requested a catalog refresh for service broker %s\n", brokerName)
+			return nil
+		},
+	}
+
+	refreshCmd.Flags().BoolVar(
+		&spaceScoped,
+		"space-scoped",
+		false,
+		"The broker is registered with the targeted space rather than the cluster.",
+	)
+
+	return refreshCmd
+}
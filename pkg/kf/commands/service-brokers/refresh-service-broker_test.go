@@ -0,0 +1,72 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/commands/config"
+	servicebrokerscmd "github.com/google/kf/pkg/kf/commands/service-brokers"
+	secretsfake "github.com/google/kf/pkg/kf/secrets/fake"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/google/kf/pkg/kf/services/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+)
+
+func TestNewRefreshServiceBrokerCommand(t *testing.T) {
+	cases := map[string]brokerTest{
+		"too few params": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"cluster broker": {
+			Args: []string{"mybroker"},
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				f.EXPECT().SyncBroker("mybroker", gomock.Any()).Do(func(name string, opts ...services.SyncBrokerOption) {
+					testutil.AssertEqual(t, "space scoped", false, services.SyncBrokerOptions(opts).SpaceScoped())
+				})
+			},
+			ExpectedStrings: []string{"mybroker"},
+		},
+		"space scoped broker": {
+			Args:      []string{"mybroker", "--space-scoped"},
+			Namespace: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				f.EXPECT().SyncBroker("mybroker", gomock.Any()).Do(func(name string, opts ...services.SyncBrokerOption) {
+					testutil.AssertEqual(t, "namespace", "custom-ns", services.SyncBrokerOptions(opts).Namespace())
+					testutil.AssertEqual(t, "space scoped", true, services.SyncBrokerOptions(opts).SpaceScoped())
+				})
+			},
+		},
+		"bad server call": {
+			Args: []string{"mybroker"},
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				f.EXPECT().SyncBroker(gomock.Any(), gomock.Any()).Return(errors.New("server-call-error"))
+			},
+			ExpectedErr: errors.New("server-call-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runTest(t, tc, func(p *config.KfParams, client *fake.FakeClientInterface, _ *secretsfake.FakeClientInterface) *cobra.Command {
+				return servicebrokerscmd.NewRefreshServiceBrokerCommand(p, client)
+			})
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// NewListServiceBrokersCommand allows users to list service brokers.
+func NewListServiceBrokersCommand(
+	p *config.KfParams,
+	client services.ClientInterface,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:     "service-brokers",
+		Aliases: []string{"sb"},
+		Short:   "List service brokers",
+		Long: `Lists the service brokers available to the target space, including
+		those registered with the whole cluster.`,
+		Example: `kf service-brokers`,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			brokers, err := client.ListBrokers(services.WithListBrokersNamespace(p.Namespace))
+			if err != nil {
+				return err
+			}
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Name\tScope\tURL\tStatus")
+				for _, broker := range brokers {
+					scope := "cluster"
+					if broker.GetNamespace() != "" {
+						scope = "space"
+					}
+
+					fmt.Fprintf(
+						w,
+						"%s\t%s\t%s\t%s\n",
+						broker.GetName(),
+						scope,
+						broker.GetURL(),
+						brokerStatus(broker),
+					)
+				}
+			})
+
+			return nil
+		},
+	}
+}
+
+// brokerStatus summarizes the Ready condition of a broker.
+func brokerStatus(broker servicecatalog.Broker) string {
+	for _, cond := range broker.GetStatus().Conditions {
+		if cond.Type != v1beta1.ServiceBrokerConditionReady {
+			continue
+		}
+
+		if cond.Status == v1beta1.ConditionTrue {
+			return "Ready"
+		}
+
+		if cond.Reason != "" {
+			return cond.Reason
+		}
+		break
+	}
+
+	return "Unknown"
+}
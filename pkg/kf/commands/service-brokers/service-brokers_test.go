@@ -0,0 +1,101 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/commands/config"
+	servicebrokerscmd "github.com/google/kf/pkg/kf/commands/service-brokers"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	secretsfake "github.com/google/kf/pkg/kf/secrets/fake"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/google/kf/pkg/kf/services/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewListServiceBrokersCommand(t *testing.T) {
+	clusterBroker := &v1beta1.ClusterServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-broker"},
+		Spec: v1beta1.ClusterServiceBrokerSpec{
+			CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{URL: "https://cluster.example.com"},
+		},
+		Status: v1beta1.ClusterServiceBrokerStatus{
+			CommonServiceBrokerStatus: v1beta1.CommonServiceBrokerStatus{
+				Conditions: []v1beta1.ServiceBrokerCondition{
+					{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue},
+				},
+			},
+		},
+	}
+
+	spaceBroker := &v1beta1.ServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: "space-broker", Namespace: "custom-ns"},
+		Spec: v1beta1.ServiceBrokerSpec{
+			CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{URL: "https://space.example.com"},
+		},
+		Status: v1beta1.ServiceBrokerStatus{
+			CommonServiceBrokerStatus: v1beta1.CommonServiceBrokerStatus{
+				Conditions: []v1beta1.ServiceBrokerCondition{
+					{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionFalse, Reason: "ErrorFetchingCatalog"},
+				},
+			},
+		},
+	}
+
+	cases := map[string]brokerTest{
+		"too many params": {
+			Args:        []string{"extra"},
+			Namespace:   "custom-ns",
+			ExpectedErr: errors.New("accepts 0 arg(s), received 1"),
+		},
+		"empty namespace": {
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"lists brokers": {
+			Namespace: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				f.EXPECT().ListBrokers(gomock.Any()).Do(func(opts ...services.ListBrokersOption) {
+					testutil.AssertEqual(t, "namespace", "custom-ns", services.ListBrokersOptions(opts).Namespace())
+				}).Return([]servicecatalog.Broker{clusterBroker, spaceBroker}, nil)
+			},
+			ExpectedStrings: []string{
+				"cluster-broker", "https://cluster.example.com", "Ready",
+				"space-broker", "https://space.example.com", "ErrorFetchingCatalog",
+			},
+		},
+		"bad server call": {
+			Namespace: "custom-ns",
+			Setup: func(t *testing.T, f *fake.FakeClientInterface, s *secretsfake.FakeClientInterface) {
+				f.EXPECT().ListBrokers(gomock.Any()).Return(nil, errors.New("server-call-error"))
+			},
+			ExpectedErr: errors.New("server-call-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runTest(t, tc, func(p *config.KfParams, client *fake.FakeClientInterface, _ *secretsfake.FakeClientInterface) *cobra.Command {
+				return servicebrokerscmd.NewListServiceBrokersCommand(p, client)
+			})
+		})
+	}
+}
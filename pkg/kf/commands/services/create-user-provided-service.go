@@ -0,0 +1,136 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewCreateUserProvidedServiceCommand allows users to create service
+// instances that aren't backed by a broker.
+func NewCreateUserProvidedServiceCommand(p *config.KfParams, client kubernetes.Interface) *cobra.Command {
+	var (
+		credentials     string
+		tags            string
+		syslogDrainURL  string
+		routeServiceURL string
+	)
+
+	createCmd := &cobra.Command{
+		Use:     "create-user-provided-service SERVICE_INSTANCE [-p CREDENTIALS] [-t TAGS] [-l SYSLOG_DRAIN_URL] [-r ROUTE_SERVICE_URL]",
+		Aliases: []string{"cups"},
+		Short:   "Create a standalone service instance from existing credentials",
+		Long: `Creates a standalone service instance from existing credentials.
+		User-provided services can be used to inject credentials for services
+		managed outside of kf into apps.
+
+		The credentials are stored in a secret in the targeted space. Apps
+		bound to the service get them in VCAP_SERVICES under the
+		user-provided label.`,
+		Example: `
+  kf create-user-provided-service my-db -p '{"username":"admin", "password":"test123"}'
+  kf create-user-provided-service my-db -p ~/workspace/tmp/credentials.json -t "mysql, database"
+  kf create-user-provided-service my-drain -l syslog://logs.example.com:514`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			cmd.SilenceUsage = true
+
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			params, err := services.ParseJSONOrFile(credentials)
+			if err != nil {
+				return err
+			}
+
+			secret, err := cfutil.MakeUserProvidedServiceSecret(p.Namespace, cfutil.UserProvidedService{
+				Name:            instanceName,
+				Credentials:     params,
+				Tags:            parseTags(tags),
+				SyslogDrainURL:  syslogDrainURL,
+				RouteServiceURL: routeServiceURL,
+			})
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.CoreV1().Secrets(p.Namespace).Create(secret); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created user-provided service %s\n", instanceName)
+			return nil
+		},
+	}
+
+	addUserProvidedServiceFlags(createCmd, &credentials, &tags, &syslogDrainURL, &routeServiceURL)
+
+	return createCmd
+}
+
+// addUserProvidedServiceFlags registers the flags shared by the commands that
+// create and update user-provided services.
+func addUserProvidedServiceFlags(cmd *cobra.Command, credentials, tags, syslogDrainURL, routeServiceURL *string) {
+	cmd.Flags().StringVarP(
+		credentials,
+		"credentials",
+		"p",
+		"{}",
+		"Valid JSON object containing credentials for the service, provided in-line or in a file.")
+
+	cmd.Flags().StringVarP(
+		tags,
+		"tags",
+		"t",
+		"",
+		"Comma separated list of tags for the service instance.")
+
+	cmd.Flags().StringVarP(
+		syslogDrainURL,
+		"syslog-drain-url",
+		"l",
+		"",
+		"URL to which logs for bound applications will be streamed.")
+
+	cmd.Flags().StringVarP(
+		routeServiceURL,
+		"route-service-url",
+		"r",
+		"",
+		"URL to which requests for bound routes will be forwarded.")
+}
+
+// parseTags splits a comma separated list of tags.
+func parseTags(tags string) []string {
+	var out []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+
+	return out
+}
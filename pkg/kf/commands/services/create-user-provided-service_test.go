@@ -0,0 +1,89 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	servicescmd "github.com/google/kf/pkg/kf/commands/services"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/testutil"
+	"k8s.io/client-go/kubernetes"
+)
+
+func TestNewCreateUserProvidedServiceCommand(t *testing.T) {
+	cases := map[string]userProvidedServiceTest{
+		"too few params": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"empty namespace": {
+			Args:        []string{"my-ups"},
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"invalid credentials": {
+			Args:        []string{"my-ups", "-p", "not-a-file"},
+			Namespace:   "custom-ns",
+			ExpectedErr: errors.New("couldn't read file: open not-a-file: no such file or directory"),
+		},
+		"defaults": {
+			Args:            []string{"my-ups"},
+			Namespace:       "custom-ns",
+			ExpectedStrings: []string{"Created user-provided service my-ups"},
+			Validate: func(t *testing.T, client kubernetes.Interface) {
+				ups := getUserProvidedService(t, client, "custom-ns", "my-ups")
+				testutil.AssertEqual(t, "user-provided service", cfutil.UserProvidedService{
+					Name:        "my-ups",
+					Credentials: map[string]interface{}{},
+					Tags:        []string{},
+				}, *ups)
+			},
+		},
+		"all flags": {
+			Args: []string{
+				"my-ups",
+				"-p", `{"username":"admin"}`,
+				"-t", "mysql, database,",
+				"-l", "syslog://example.com",
+				"-r", "https://route-service.example.com",
+			},
+			Namespace: "custom-ns",
+			Validate: func(t *testing.T, client kubernetes.Interface) {
+				ups := getUserProvidedService(t, client, "custom-ns", "my-ups")
+				testutil.AssertEqual(t, "user-provided service", cfutil.UserProvidedService{
+					Name:            "my-ups",
+					Credentials:     map[string]interface{}{"username": "admin"},
+					Tags:            []string{"mysql", "database"},
+					SyslogDrainURL:  "syslog://example.com",
+					RouteServiceURL: "https://route-service.example.com",
+				}, *ups)
+			},
+		},
+		"already exists": {
+			Args:        []string{"my-ups"},
+			Namespace:   "custom-ns",
+			Objects:     userProvidedServiceObjects(t, "custom-ns", cfutil.UserProvidedService{Name: "my-ups"}),
+			ExpectedErr: errors.New(`secrets "user-provided-service-my-ups" already exists`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runUserProvidedServiceTest(t, tc, servicescmd.NewCreateUserProvidedServiceCommand)
+		})
+	}
+}
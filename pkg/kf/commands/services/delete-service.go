@@ -15,14 +15,23 @@
 package services
 
 import (
+	"github.com/google/kf/pkg/kf/cfutil"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/services"
 	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
-// NewDeleteServiceCommand allows users to delete service instances.
-func NewDeleteServiceCommand(p *config.KfParams, client services.ClientInterface) *cobra.Command {
+// NewDeleteServiceCommand allows users to delete service instances, including
+// user-provided ones.
+func NewDeleteServiceCommand(
+	p *config.KfParams,
+	client services.ClientInterface,
+	k8sClient kubernetes.Interface,
+) *cobra.Command {
 	deleteCmd := &cobra.Command{
 		Use:     "delete-service SERVICE_INSTANCE",
 		Aliases: []string{"ds"},
@@ -38,6 +47,16 @@ func NewDeleteServiceCommand(p *config.KfParams, client services.ClientInterface
 				return err
 			}
 
+			secrets := k8sClient.CoreV1().Secrets(p.Namespace)
+			secretName := cfutil.UserProvidedServiceSecretName(instanceName)
+			secret, err := secrets.Get(secretName, metav1.GetOptions{})
+			switch {
+			case err == nil && cfutil.IsUserProvidedServiceSecret(secret):
+				return secrets.Delete(secretName, &metav1.DeleteOptions{})
+			case err != nil && !apierrs.IsNotFound(err):
+				return err
+			}
+
 			return client.DeleteService(instanceName, services.WithDeleteServiceNamespace(p.Namespace))
 		},
 	}
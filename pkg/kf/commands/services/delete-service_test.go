@@ -15,15 +15,22 @@
 package services_test
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
 	servicescmd "github.com/google/kf/pkg/kf/commands/services"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/services"
 	"github.com/google/kf/pkg/kf/services/fake"
 	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
 func TestNewDeleteServiceCommand(t *testing.T) {
@@ -57,7 +64,31 @@ func TestNewDeleteServiceCommand(t *testing.T) {
 
 	for tn, tc := range cases {
 		t.Run(tn, func(t *testing.T) {
-			runTest(t, tc, servicescmd.NewDeleteServiceCommand)
+			runTest(t, tc, func(p *config.KfParams, client services.ClientInterface) *cobra.Command {
+				return servicescmd.NewDeleteServiceCommand(p, client, k8sfake.NewSimpleClientset())
+			})
 		})
 	}
 }
+
+func TestNewDeleteServiceCommand_userProvided(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The service catalog must not be called for user-provided services.
+	client := fake.NewFakeClientInterface(ctrl)
+
+	objects := userProvidedServiceObjects(t, "custom-ns", cfutil.UserProvidedService{Name: "my-ups"})
+	k8sClient := k8sfake.NewSimpleClientset(objects...)
+
+	cmd := servicescmd.NewDeleteServiceCommand(&config.KfParams{Namespace: "custom-ns"}, client, k8sClient)
+	cmd.SetOutput(new(bytes.Buffer))
+	cmd.SetArgs([]string{"my-ups"})
+	testutil.AssertNil(t, "err", cmd.Execute())
+
+	_, err := k8sClient.
+		CoreV1().
+		Secrets("custom-ns").
+		Get(cfutil.UserProvidedServiceSecretName("my-ups"), metav1.GetOptions{})
+	testutil.AssertEqual(t, "secret deleted", true, apierrs.IsNotFound(err))
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/kf/cfutil"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/services"
 	"github.com/google/kf/pkg/kf/services/fake"
@@ -27,6 +28,9 @@ import (
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
 type commandFactory func(p *config.KfParams, client services.ClientInterface) *cobra.Command
@@ -78,3 +82,67 @@ func runTest(t *testing.T, tc serviceTest, newCommand commandFactory) {
 
 	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
 }
+
+type userProvidedCommandFactory func(p *config.KfParams, client kubernetes.Interface) *cobra.Command
+
+type userProvidedServiceTest struct {
+	Args      []string
+	Objects   []runtime.Object
+	Namespace string
+
+	ExpectedErr     error
+	ExpectedStrings []string
+	Validate        func(t *testing.T, client kubernetes.Interface)
+}
+
+func runUserProvidedServiceTest(t *testing.T, tc userProvidedServiceTest, newCommand userProvidedCommandFactory) {
+	client := k8sfake.NewSimpleClientset(tc.Objects...)
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Namespace: tc.Namespace,
+	}
+
+	cmd := newCommand(p, client)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		return
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+
+	if tc.Validate != nil {
+		tc.Validate(t, client)
+	}
+}
+
+// getUserProvidedService reads back the user-provided service from the
+// secret backing it.
+func getUserProvidedService(t *testing.T, client kubernetes.Interface, namespace, name string) *cfutil.UserProvidedService {
+	t.Helper()
+
+	secret, err := client.
+		CoreV1().
+		Secrets(namespace).
+		Get(cfutil.UserProvidedServiceSecretName(name), metav1.GetOptions{})
+	testutil.AssertNil(t, "get secret err", err)
+
+	ups, err := cfutil.ParseUserProvidedServiceSecret(secret)
+	testutil.AssertNil(t, "parse err", err)
+
+	return ups
+}
+
+// userProvidedServiceObjects creates the objects backing a user-provided
+// service so they can be loaded into a fake client.
+func userProvidedServiceObjects(t *testing.T, namespace string, ups cfutil.UserProvidedService) []runtime.Object {
+	t.Helper()
+
+	secret, err := cfutil.MakeUserProvidedServiceSecret(namespace, ups)
+	testutil.AssertNil(t, "make secret err", err)
+
+	return []runtime.Object{secret}
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/services"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewUpdateUserProvidedServiceCommand allows users to update the credentials
+// and settings of user-provided service instances.
+func NewUpdateUserProvidedServiceCommand(p *config.KfParams, client kubernetes.Interface) *cobra.Command {
+	var (
+		credentials     string
+		tags            string
+		syslogDrainURL  string
+		routeServiceURL string
+	)
+
+	updateCmd := &cobra.Command{
+		Use:     "update-user-provided-service SERVICE_INSTANCE [-p CREDENTIALS] [-t TAGS] [-l SYSLOG_DRAIN_URL] [-r ROUTE_SERVICE_URL]",
+		Aliases: []string{"uups"},
+		Short:   "Update a user-provided service instance",
+		Long: `Updates a user-provided service instance. Only the values given by
+		flags are changed, everything else is kept.
+
+		Apps bound to the service need to be restarted to pick up the new
+		values.`,
+		Example: `
+  kf update-user-provided-service my-db -p '{"username":"admin", "password":"new-password"}'
+  kf update-user-provided-service my-db -t "mysql, database, primary"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			cmd.SilenceUsage = true
+
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			secrets := client.CoreV1().Secrets(p.Namespace)
+			existing, err := secrets.Get(cfutil.UserProvidedServiceSecretName(instanceName), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			ups, err := cfutil.ParseUserProvidedServiceSecret(existing)
+			if err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			if flags.Changed("credentials") {
+				if ups.Credentials, err = services.ParseJSONOrFile(credentials); err != nil {
+					return err
+				}
+			}
+
+			if flags.Changed("tags") {
+				ups.Tags = parseTags(tags)
+			}
+
+			if flags.Changed("syslog-drain-url") {
+				ups.SyslogDrainURL = syslogDrainURL
+			}
+
+			if flags.Changed("route-service-url") {
+				ups.RouteServiceURL = routeServiceURL
+			}
+
+			desired, err := cfutil.MakeUserProvidedServiceSecret(p.Namespace, *ups)
+			if err != nil {
+				return err
+			}
+
+			updated := existing.DeepCopy()
+			updated.Data = desired.Data
+			if _, err := secrets.Update(updated); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated user-provided service %s\n", instanceName)
+			fmt.Fprintln(cmd.OutOrStderr(), "Use 'kf restart' on bound apps to ensure your changes take effect")
+			return nil
+		},
+	}
+
+	addUserProvidedServiceFlags(updateCmd, &credentials, &tags, &syslogDrainURL, &routeServiceURL)
+
+	return updateCmd
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/cfutil"
+	servicescmd "github.com/google/kf/pkg/kf/commands/services"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/testutil"
+	"k8s.io/client-go/kubernetes"
+)
+
+func TestNewUpdateUserProvidedServiceCommand(t *testing.T) {
+	existing := cfutil.UserProvidedService{
+		Name:            "my-ups",
+		Credentials:     map[string]interface{}{"username": "admin"},
+		Tags:            []string{"mysql"},
+		SyslogDrainURL:  "syslog://example.com",
+		RouteServiceURL: "https://route-service.example.com",
+	}
+
+	cases := map[string]userProvidedServiceTest{
+		"too few params": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"empty namespace": {
+			Args:        []string{"my-ups"},
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"missing service": {
+			Args:        []string{"my-ups"},
+			Namespace:   "custom-ns",
+			ExpectedErr: errors.New(`secrets "user-provided-service-my-ups" not found`),
+		},
+		"no flags keeps values": {
+			Args:            []string{"my-ups"},
+			Namespace:       "custom-ns",
+			Objects:         userProvidedServiceObjects(t, "custom-ns", existing),
+			ExpectedStrings: []string{"Updated user-provided service my-ups", "kf restart"},
+			Validate: func(t *testing.T, client kubernetes.Interface) {
+				ups := getUserProvidedService(t, client, "custom-ns", "my-ups")
+				testutil.AssertEqual(t, "user-provided service", existing, *ups)
+			},
+		},
+		"changed flags replace values": {
+			Args:      []string{"my-ups", "-p", `{"password":"secret"}`, "-l", ""},
+			Namespace: "custom-ns",
+			Objects:   userProvidedServiceObjects(t, "custom-ns", existing),
+			Validate: func(t *testing.T, client kubernetes.Interface) {
+				ups := getUserProvidedService(t, client, "custom-ns", "my-ups")
+				testutil.AssertEqual(t, "user-provided service", cfutil.UserProvidedService{
+					Name:            "my-ups",
+					Credentials:     map[string]interface{}{"password": "secret"},
+					Tags:            []string{"mysql"},
+					RouteServiceURL: "https://route-service.example.com",
+				}, *ups)
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runUserProvidedServiceTest(t, tc, servicescmd.NewUpdateUserProvidedServiceCommand)
+		})
+	}
+}
@@ -15,7 +15,10 @@
 package spaces
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -26,6 +29,7 @@ import (
 	"github.com/google/kf/pkg/kf/commands/quotas"
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	k8syaml "sigs.k8s.io/yaml"
 )
@@ -61,6 +65,12 @@ func NewConfigSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		newAppendDomainMutator(),
 		newSetDefaultDomainMutator(),
 		newRemoveDomainMutator(),
+		newSetEgressMutator(),
+		newAllowEgressMutator(),
+		newDenyEgressMutator(),
+		newRemoveEgressMutator(),
+		newSetDefaultBackendMutator(),
+		newUnsetDefaultBackendMutator(),
 	}
 
 	for _, sm := range subcommands {
@@ -73,6 +83,8 @@ func NewConfigSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		newGetExecutionEnvAccessor(),
 		newGetBuildpackEnvAccessor(),
 		newGetDomainsAccessor(),
+		newGetEgressAccessor(),
+		newGetDefaultBackendAccessor(),
 	}
 
 	for _, sa := range accessors {
@@ -93,16 +105,17 @@ type spaceMutator struct {
 	Short       string
 	Args        []string
 	ExampleArgs []string
+	Flags       func(flags *pflag.FlagSet)
 	Init        func(args []string) (spaces.Mutator, error)
 }
 
 func (sm spaceMutator) ToCommand(client spaces.Client) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     fmt.Sprintf("%s SPACE_NAME %s", sm.Name, strings.Join(sm.Args, " ")),
+		Use:     strings.TrimSpace(fmt.Sprintf("%s SPACE_NAME %s", sm.Name, strings.Join(sm.Args, " "))),
 		Short:   sm.Short,
 		Long:    sm.Short,
 		Args:    cobra.ExactArgs(1 + len(sm.Args)),
-		Example: fmt.Sprintf("kf configure-space %s my-space %s", sm.Name, strings.Join(sm.ExampleArgs, " ")),
+		Example: strings.TrimSpace(fmt.Sprintf("kf configure-space %s my-space %s", sm.Name, strings.Join(sm.ExampleArgs, " "))),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			spaceName := args[0]
 
@@ -118,6 +131,10 @@ func (sm spaceMutator) ToCommand(client spaces.Client) *cobra.Command {
 		},
 	}
 
+	if sm.Flags != nil {
+		sm.Flags(cmd.Flags())
+	}
+
 	completion.MarkArgCompletionSupported(cmd, completion.SpaceCompletion)
 
 	return cmd
@@ -236,18 +253,34 @@ func newUnsetBuildpackEnvMutator() spaceMutator {
 }
 
 func newAppendDomainMutator() spaceMutator {
+	var internal, tcp bool
+
 	return spaceMutator{
 		Name:        "append-domain",
 		Short:       "Append a domain for a space",
 		Args:        []string{"DOMAIN"},
 		ExampleArgs: []string{"myspace.mycompany.com"},
+		Flags: func(flags *pflag.FlagSet) {
+			flags.BoolVar(
+				&internal,
+				"internal",
+				false,
+				"Only allow routes on the domain to be reached from inside the cluster, kf resolves them through CoreDNS.",
+			)
+			flags.BoolVar(
+				&tcp,
+				"tcp",
+				false,
+				"Routes on the domain reserve a port for TCP traffic instead of a hostname and path.",
+			)
+		},
 		Init: func(args []string) (spaces.Mutator, error) {
 			domain := args[0]
 
 			return func(space *v1alpha1.Space) error {
 				space.Spec.Execution.Domains = append(
 					space.Spec.Execution.Domains,
-					v1alpha1.SpaceDomain{Domain: domain},
+					v1alpha1.SpaceDomain{Domain: domain, Internal: internal, TCP: tcp},
 				)
 
 				return nil
@@ -307,6 +340,256 @@ func newRemoveDomainMutator() spaceMutator {
 	}
 }
 
+func newSetEgressMutator() spaceMutator {
+	return spaceMutator{
+		Name:        "set-egress",
+		Short:       "Set the egress policy for traffic that doesn't match any egress rules (Allow or Deny).",
+		Args:        []string{"POLICY"},
+		ExampleArgs: []string{"Deny"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			policy, err := parseEgressPolicy(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.Network.DefaultEgressPolicy = policy
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newAllowEgressMutator() spaceMutator {
+	var ports []string
+
+	return spaceMutator{
+		Name:        "allow-egress",
+		Short:       "Allow outbound traffic from the space to a CIDR.",
+		Args:        []string{"CIDR"},
+		ExampleArgs: []string{"10.0.0.0/8 --port 443 --port 53/udp"},
+		Flags: func(flags *pflag.FlagSet) {
+			flags.StringArrayVar(
+				&ports,
+				"port",
+				nil,
+				"Destination port to allow, optionally followed by /tcp or /udp (default all ports). May be specified multiple times.",
+			)
+		},
+		Init: func(args []string) (spaces.Mutator, error) {
+			cidr, err := parseEgressCIDR(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			rule := v1alpha1.SpaceEgressRule{
+				CIDR:   cidr,
+				Policy: v1alpha1.EgressPolicyAllow,
+			}
+
+			for _, p := range ports {
+				port, err := parseEgressPort(p)
+				if err != nil {
+					return nil, err
+				}
+				rule.Ports = append(rule.Ports, port)
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.Network.Egress = setEgressRule(space.Spec.Network.Egress, rule)
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newDenyEgressMutator() spaceMutator {
+	return spaceMutator{
+		Name:        "deny-egress",
+		Short:       "Deny outbound traffic from the space to a CIDR.",
+		Args:        []string{"CIDR"},
+		ExampleArgs: []string{"169.254.169.254/32"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			cidr, err := parseEgressCIDR(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			rule := v1alpha1.SpaceEgressRule{
+				CIDR:   cidr,
+				Policy: v1alpha1.EgressPolicyDeny,
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.Network.Egress = setEgressRule(space.Spec.Network.Egress, rule)
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newRemoveEgressMutator() spaceMutator {
+	return spaceMutator{
+		Name:        "remove-egress",
+		Short:       "Remove the egress rule for a CIDR from the space.",
+		Args:        []string{"CIDR"},
+		ExampleArgs: []string{"10.0.0.0/8"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			cidr, err := parseEgressCIDR(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return func(space *v1alpha1.Space) error {
+				var rules []v1alpha1.SpaceEgressRule
+				for _, rule := range space.Spec.Network.Egress {
+					if rule.CIDR != cidr {
+						rules = append(rules, rule)
+					}
+				}
+
+				if len(rules) == len(space.Spec.Network.Egress) {
+					return fmt.Errorf("failed to find egress rule for %s", cidr)
+				}
+
+				space.Spec.Network.Egress = rules
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetDefaultBackendMutator() spaceMutator {
+	var redirectURL string
+
+	return spaceMutator{
+		Name:        "set-default-backend",
+		Short:       "Set the response for requests to the space's routes that no App receives (unavailable, page or redirect).",
+		Args:        []string{"TYPE"},
+		ExampleArgs: []string{"redirect --redirect-url https://example.com/not-found"},
+		Flags: func(flags *pflag.FlagSet) {
+			flags.StringVar(
+				&redirectURL,
+				"redirect-url",
+				"",
+				"Absolute URL to redirect requests to, required by the redirect type.",
+			)
+		},
+		Init: func(args []string) (spaces.Mutator, error) {
+			backendType, err := parseDefaultBackendType(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case backendType == v1alpha1.RouteDefaultBackendRedirect && redirectURL == "":
+				return nil, fmt.Errorf("--redirect-url is required by the %s default backend", backendType)
+			case backendType != v1alpha1.RouteDefaultBackendRedirect && redirectURL != "":
+				return nil, fmt.Errorf("--redirect-url can only be used with the %s default backend", v1alpha1.RouteDefaultBackendRedirect)
+			}
+
+			backend := &v1alpha1.RouteDefaultBackend{
+				Type:        backendType,
+				RedirectURL: redirectURL,
+			}
+
+			if err := backend.Validate(context.Background()); err != nil {
+				return nil, fmt.Errorf("invalid default backend: %s", err)
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.Execution.DefaultBackend = backend
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newUnsetDefaultBackendMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "unset-default-backend",
+		Short: "Unset the space's default backend so the cluster-wide one is used.",
+		Init: func(args []string) (spaces.Mutator, error) {
+			return func(space *v1alpha1.Space) error {
+				space.Spec.Execution.DefaultBackend = nil
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+// setEgressRule replaces the rule with the same CIDR or appends it if none
+// exists.
+func setEgressRule(rules []v1alpha1.SpaceEgressRule, rule v1alpha1.SpaceEgressRule) []v1alpha1.SpaceEgressRule {
+	for i := range rules {
+		if rules[i].CIDR == rule.CIDR {
+			rules[i] = rule
+			return rules
+		}
+	}
+
+	return append(rules, rule)
+}
+
+func parseEgressPolicy(policy string) (v1alpha1.EgressPolicy, error) {
+	for _, p := range []v1alpha1.EgressPolicy{v1alpha1.EgressPolicyAllow, v1alpha1.EgressPolicyDeny} {
+		if strings.EqualFold(policy, string(p)) {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("egress policy must be Allow or Deny, got %q", policy)
+}
+
+func parseDefaultBackendType(backendType string) (v1alpha1.RouteDefaultBackendType, error) {
+	for _, t := range []v1alpha1.RouteDefaultBackendType{
+		v1alpha1.RouteDefaultBackendUnavailable,
+		v1alpha1.RouteDefaultBackendPage,
+		v1alpha1.RouteDefaultBackendRedirect,
+	} {
+		if strings.EqualFold(backendType, string(t)) {
+			return t, nil
+		}
+	}
+
+	return "", fmt.Errorf("default backend must be unavailable, page or redirect, got %q", backendType)
+}
+
+// parseEgressCIDR normalizes the CIDR so rules for the same network are
+// matched even if the user types the address differently.
+func parseEgressCIDR(cidr string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+
+	return ipNet.String(), nil
+}
+
+// parseEgressPort parses a port in the form PORT[/PROTOCOL].
+func parseEgressPort(port string) (v1alpha1.SpaceEgressPort, error) {
+	number, protocol := port, corev1.ProtocolTCP
+	if idx := strings.Index(port, "/"); idx >= 0 {
+		number, protocol = port[:idx], corev1.Protocol(strings.ToUpper(port[idx+1:]))
+	}
+
+	if protocol != corev1.ProtocolTCP && protocol != corev1.ProtocolUDP {
+		return v1alpha1.SpaceEgressPort{}, fmt.Errorf("invalid port %q: protocol must be tcp or udp", port)
+	}
+
+	n, err := strconv.ParseInt(number, 10, 32)
+	if err != nil || n < 1 || n > 65535 {
+		return v1alpha1.SpaceEgressPort{}, fmt.Errorf("invalid port %q: must be a number between 1 and 65535", port)
+	}
+
+	return v1alpha1.SpaceEgressPort{Protocol: protocol, Port: int32(n)}, nil
+}
+
 type spaceAccessor struct {
 	Name     string
 	Short    string
@@ -400,3 +683,23 @@ func newGetDomainsAccessor() spaceAccessor {
 		},
 	}
 }
+
+func newGetEgressAccessor() spaceAccessor {
+	return spaceAccessor{
+		Name:  "get-egress",
+		Short: "Get the egress policy and rules for the space.",
+		Accessor: func(space *v1alpha1.Space) interface{} {
+			return space.Spec.Network
+		},
+	}
+}
+
+func newGetDefaultBackendAccessor() spaceAccessor {
+	return spaceAccessor{
+		Name:  "get-default-backend",
+		Short: "Get the space's default backend, empty if the cluster-wide one is used.",
+		Accessor: func(space *v1alpha1.Space) interface{} {
+			return space.Spec.Execution.DefaultBackend
+		},
+	}
+}
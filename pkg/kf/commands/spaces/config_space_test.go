@@ -143,6 +143,24 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			},
 		},
 
+		"append-domain internal": {
+			args: []string{"append-domain", space, "apps.internal", "--internal"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains", []v1alpha1.SpaceDomain{
+					{Domain: "apps.internal", Internal: true},
+				}, space.Spec.Execution.Domains)
+			},
+		},
+
+		"append-domain TCP": {
+			args: []string{"append-domain", space, "tcp.example.com", "--tcp"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains", []v1alpha1.SpaceDomain{
+					{Domain: "tcp.example.com", TCP: true},
+				}, space.Spec.Execution.Domains)
+			},
+		},
+
 		"set-default-domain valid": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -194,6 +212,137 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 				testutil.AssertEqual(t, "domains", "example.com", space.Spec.Execution.Domains[0].Domain)
 			},
 		},
+
+		"set-egress valid": {
+			args: []string{"set-egress", space, "deny"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "default egress policy", v1alpha1.EgressPolicyDeny, space.Spec.Network.DefaultEgressPolicy)
+			},
+		},
+
+		"set-egress invalid": {
+			args:    []string{"set-egress", space, "block"},
+			wantErr: errors.New(`egress policy must be Allow or Deny, got "block"`),
+		},
+
+		"allow-egress valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Network: v1alpha1.SpaceSpecNetwork{
+						Egress: []v1alpha1.SpaceEgressRule{
+							{CIDR: "10.0.0.0/8", Policy: v1alpha1.EgressPolicyDeny},
+							{CIDR: "192.168.0.0/16", Policy: v1alpha1.EgressPolicyDeny},
+						},
+					},
+				},
+			},
+			args: []string{"allow-egress", space, "10.1.2.3/8", "--port", "443", "--port", "53/udp"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "egress", []v1alpha1.SpaceEgressRule{
+					{CIDR: "10.0.0.0/8", Policy: v1alpha1.EgressPolicyAllow, Ports: []v1alpha1.SpaceEgressPort{
+						{Protocol: "TCP", Port: 443},
+						{Protocol: "UDP", Port: 53},
+					}},
+					{CIDR: "192.168.0.0/16", Policy: v1alpha1.EgressPolicyDeny},
+				}, space.Spec.Network.Egress)
+			},
+		},
+
+		"allow-egress bad CIDR": {
+			args:    []string{"allow-egress", space, "10.0.0.0"},
+			wantErr: errors.New("invalid CIDR address: 10.0.0.0"),
+		},
+
+		"allow-egress bad port": {
+			args:    []string{"allow-egress", space, "10.0.0.0/8", "--port", "443/sctp"},
+			wantErr: errors.New(`invalid port "443/sctp": protocol must be tcp or udp`),
+		},
+
+		"deny-egress valid": {
+			args: []string{"deny-egress", space, "169.254.169.254/32"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "egress", []v1alpha1.SpaceEgressRule{
+					{CIDR: "169.254.169.254/32", Policy: v1alpha1.EgressPolicyDeny},
+				}, space.Spec.Network.Egress)
+			},
+		},
+
+		"remove-egress valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Network: v1alpha1.SpaceSpecNetwork{
+						Egress: []v1alpha1.SpaceEgressRule{
+							{CIDR: "10.0.0.0/8", Policy: v1alpha1.EgressPolicyDeny},
+							{CIDR: "192.168.0.0/16", Policy: v1alpha1.EgressPolicyDeny},
+						},
+					},
+				},
+			},
+			args: []string{"remove-egress", space, "10.0.0.0/8"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "egress", []v1alpha1.SpaceEgressRule{
+					{CIDR: "192.168.0.0/16", Policy: v1alpha1.EgressPolicyDeny},
+				}, space.Spec.Network.Egress)
+			},
+		},
+
+		"remove-egress missing": {
+			args:    []string{"remove-egress", space, "10.0.0.0/8"},
+			wantErr: errors.New("failed to find egress rule for 10.0.0.0/8"),
+		},
+
+		"set-default-backend page": {
+			args: []string{"set-default-backend", space, "Page"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "default backend", &v1alpha1.RouteDefaultBackend{
+					Type: v1alpha1.RouteDefaultBackendPage,
+				}, space.Spec.Execution.DefaultBackend)
+			},
+		},
+
+		"set-default-backend redirect": {
+			args: []string{"set-default-backend", space, "redirect", "--redirect-url", "https://example.com/not-found"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "default backend", &v1alpha1.RouteDefaultBackend{
+					Type:        v1alpha1.RouteDefaultBackendRedirect,
+					RedirectURL: "https://example.com/not-found",
+				}, space.Spec.Execution.DefaultBackend)
+			},
+		},
+
+		"set-default-backend invalid type": {
+			args:    []string{"set-default-backend", space, "teapot"},
+			wantErr: errors.New(`default backend must be unavailable, page or redirect, got "teapot"`),
+		},
+
+		"set-default-backend redirect without URL": {
+			args:    []string{"set-default-backend", space, "redirect"},
+			wantErr: errors.New("--redirect-url is required by the redirect default backend"),
+		},
+
+		"set-default-backend URL without redirect": {
+			args:    []string{"set-default-backend", space, "page", "--redirect-url", "https://example.com"},
+			wantErr: errors.New("--redirect-url can only be used with the redirect default backend"),
+		},
+
+		"set-default-backend relative URL": {
+			args:    []string{"set-default-backend", space, "redirect", "--redirect-url", "/not-found"},
+			wantErr: errors.New("invalid default backend: invalid value: /not-found: redirectURL"),
+		},
+
+		"unset-default-backend valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Execution: v1alpha1.SpaceSpecExecution{
+						DefaultBackend: &v1alpha1.RouteDefaultBackend{Type: v1alpha1.RouteDefaultBackendPage},
+					},
+				},
+			},
+			args: []string{"unset-default-backend", space},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "default backend", (*v1alpha1.RouteDefaultBackend)(nil), space.Spec.Execution.DefaultBackend)
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -247,6 +396,18 @@ func TestNewConfigSpaceCommand_accessors(t *testing.T) {
 					{Domain: "example.com", Default: true},
 					{Domain: "other-example.com"},
 				},
+				DefaultBackend: &v1alpha1.RouteDefaultBackend{
+					Type:        v1alpha1.RouteDefaultBackendRedirect,
+					RedirectURL: "https://example.com/not-found",
+				},
+			},
+			Network: v1alpha1.SpaceSpecNetwork{
+				DefaultEgressPolicy: v1alpha1.EgressPolicyDeny,
+				Egress: []v1alpha1.SpaceEgressRule{
+					{CIDR: "10.0.0.0/8", Policy: v1alpha1.EgressPolicyAllow, Ports: []v1alpha1.SpaceEgressPort{
+						{Protocol: "TCP", Port: 443},
+					}},
+				},
 			},
 		},
 	}
@@ -291,6 +452,25 @@ func TestNewConfigSpaceCommand_accessors(t *testing.T) {
 			wantOutput: `- default: true
   domain: example.com
 - domain: other-example.com
+`,
+		},
+		"get-egress valid": {
+			args:  []string{"get-egress", "space-name"},
+			space: space,
+			wantOutput: `defaultEgressPolicy: Deny
+egress:
+- cidr: 10.0.0.0/8
+  policy: Allow
+  ports:
+  - port: 443
+    protocol: TCP
+`,
+		},
+		"get-default-backend valid": {
+			args:  []string{"get-default-backend", "space-name"},
+			space: space,
+			wantOutput: `redirectURL: https://example.com/not-found
+type: redirect
 `,
 		},
 	}
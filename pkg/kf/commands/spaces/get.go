@@ -82,9 +82,9 @@ func NewGetSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 					}
 
 					describe.TabbedWriter(w, func(w io.Writer) {
-						fmt.Fprintln(w, "Name\tDefault?")
+						fmt.Fprintln(w, "Name\tDefault?\tInternal?\tTCP?")
 						for _, domain := range execution.Domains {
-							fmt.Fprintf(w, "%s\t%t\n", domain.Domain, domain.Default)
+							fmt.Fprintf(w, "%s\t%t\t%t\t%t\n", domain.Domain, domain.Default, domain.Internal, domain.TCP)
 						}
 					})
 				})
@@ -47,6 +47,8 @@ func TestNewGetSpaceCommand(t *testing.T) {
 	goodSpace.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
 		{Domain: "domain-1.com", Default: true},
 		{Domain: "domain-2.com"},
+		{Domain: "apps.internal", Internal: true},
+		{Domain: "tcp.example.com", TCP: true},
 	}
 
 	cases := map[string]struct {
@@ -77,7 +79,7 @@ func TestNewGetSpaceCommand(t *testing.T) {
 		"execution": {
 			args:       []string{"my-space"},
 			space:      goodSpace,
-			wantOutput: []string{"Execution", "ExecVar", "ExecVal", "domain-1.com", "domain-2.com"},
+			wantOutput: []string{"Execution", "ExecVar", "ExecVal", "domain-1.com", "domain-2.com", "Internal?", "apps.internal", "TCP?", "tcp.example.com"},
 		},
 		"client error": {
 			args:    []string{"my-space"},
@@ -0,0 +1,129 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/completion"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/logs"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewRunTaskCommand creates a command to run a one-off task against an app.
+func NewRunTaskCommand(
+	p *config.KfParams,
+	client tasks.Client,
+	tailer logs.Tailer,
+) *cobra.Command {
+	var (
+		name   string
+		follow bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run-task APP_NAME COMMAND",
+		Short: "Run a one-off task using the app's image and environment",
+		Example: `
+  kf run-task myapp "rake db:migrate"
+
+  # Give the task a name
+  kf run-task myapp "rake db:migrate" --name migrate
+
+  # Follow the logs of the task until it stops running
+  kf run-task myapp "rake db:migrate" -f
+  `,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+			command := args[1]
+
+			cmd.SilenceUsage = true
+
+			task := &v1alpha1.Task{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Task",
+					APIVersion: "kf.dev/v1alpha1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: p.Namespace,
+				},
+				Spec: v1alpha1.TaskSpec{
+					AppName: appName,
+					Command: command,
+				},
+			}
+
+			// Let the API server pick a unique name if one wasn't supplied.
+			if name == "" {
+				task.GenerateName = appName + "-"
+			}
+
+			created, err := client.Create(p.Namespace, task)
+			if err != nil {
+				return fmt.Errorf("failed to create task: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Task %s created for app %s in space %s\n", created.Name, appName, p.Namespace)
+
+			if !follow {
+				return nil
+			}
+
+			if err := tailer.Tail(
+				context.Background(),
+				appName,
+				cmd.OutOrStdout(),
+				logs.WithTailNamespace(p.Namespace),
+				logs.WithTailTask(created.Name),
+				logs.WithTailFollow(true),
+			); err != nil {
+				return fmt.Errorf("failed to tail logs: %s", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&name,
+		"name",
+		"",
+		"Name to give the task, one is generated if unset.",
+	)
+
+	cmd.Flags().BoolVarP(
+		&follow,
+		"follow",
+		"f",
+		false,
+		"Follow the log stream of the task.",
+	)
+
+	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
+
+	return cmd
+}
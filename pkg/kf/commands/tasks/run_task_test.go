@@ -0,0 +1,165 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/tasks"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/logs"
+	fakelogs "github.com/google/kf/pkg/kf/logs/fake"
+	faketasks "github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestRunTask(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fakeTasks *faketasks.FakeClient, fakeTailer *fakelogs.FakeTailer)
+	}{
+		"wrong number of args": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("accepts 2 arg(s), received 1"),
+		},
+		"missing namespace": {
+			Args:        []string{"my-app", "rake db:migrate"},
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"generates a name": {
+			Namespace: "default",
+			Args:      []string{"my-app", "rake db:migrate"},
+			Setup: func(t *testing.T, fakeTasks *faketasks.FakeClient, fakeTailer *fakelogs.FakeTailer) {
+				fakeTasks.EXPECT().
+					Create("default", gomock.Any()).
+					DoAndReturn(func(_ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "name", "", task.Name)
+						testutil.AssertEqual(t, "generateName", "my-app-", task.GenerateName)
+						testutil.AssertEqual(t, "namespace", "default", task.Namespace)
+						testutil.AssertEqual(t, "appName", "my-app", task.Spec.AppName)
+						testutil.AssertEqual(t, "command", "rake db:migrate", task.Spec.Command)
+
+						created := task.DeepCopy()
+						created.Name = "my-app-abcde"
+						return created, nil
+					})
+			},
+			ExpectedStrings: []string{"my-app-abcde", "my-app", "default"},
+		},
+		"uses supplied name": {
+			Namespace: "default",
+			Args:      []string{"my-app", "rake db:migrate", "--name", "migrate"},
+			Setup: func(t *testing.T, fakeTasks *faketasks.FakeClient, fakeTailer *fakelogs.FakeTailer) {
+				fakeTasks.EXPECT().
+					Create("default", gomock.Any()).
+					DoAndReturn(func(_ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "name", "migrate", task.Name)
+						testutil.AssertEqual(t, "generateName", "", task.GenerateName)
+						return task, nil
+					})
+			},
+			ExpectedStrings: []string{"migrate"},
+		},
+		"follows logs": {
+			Namespace: "default",
+			Args:      []string{"my-app", "rake db:migrate", "--name", "migrate", "-f"},
+			Setup: func(t *testing.T, fakeTasks *faketasks.FakeClient, fakeTailer *fakelogs.FakeTailer) {
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						return task, nil
+					})
+
+				fakeTailer.EXPECT().
+					Tail(gomock.Any(), "my-app", gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, appName string, out io.Writer, opts ...logs.TailOption) {
+						testutil.AssertEqual(t, "namespace", "default", logs.TailOptions(opts).Namespace())
+						testutil.AssertEqual(t, "task", "migrate", logs.TailOptions(opts).Task())
+						testutil.AssertEqual(t, "follow", true, logs.TailOptions(opts).Follow())
+					})
+			},
+		},
+		"creating task fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "rake db:migrate"},
+			ExpectedErr: errors.New("failed to create task: some-error"),
+			Setup: func(t *testing.T, fakeTasks *faketasks.FakeClient, fakeTailer *fakelogs.FakeTailer) {
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+		"tailing logs fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "rake db:migrate", "-f"},
+			ExpectedErr: errors.New("failed to tail logs: some-error"),
+			Setup: func(t *testing.T, fakeTasks *faketasks.FakeClient, fakeTailer *fakelogs.FakeTailer) {
+				fakeTasks.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						return task, nil
+					})
+
+				fakeTailer.EXPECT().
+					Tail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeTasks := faketasks.NewFakeClient(ctrl)
+			fakeTailer := fakelogs.NewFakeTailer(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fakeTasks, fakeTailer)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := tasks.NewRunTaskCommand(p, fakeTasks, fakeTailer)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/completion"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/describe"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewTasksCommand creates a command to list the tasks of an app.
+func NewTasksCommand(
+	p *config.KfParams,
+	client tasks.Client,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "tasks APP_NAME",
+		Short:   "List the tasks run against an app",
+		Example: `kf tasks myapp`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			cmd.SilenceUsage = true
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Getting tasks for app %s in space %s\n\n", appName, p.Namespace)
+
+			taskList, err := client.List(p.Namespace, tasks.WithListFilters([]tasks.Predicate{
+				func(task *v1alpha1.Task) bool {
+					return task.Spec.AppName == appName
+				},
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to list tasks: %s", err)
+			}
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Name\tState\tStart Time\tCommand")
+				for _, task := range taskList {
+					startTime := ""
+					if task.Status.StartTime != nil {
+						startTime = task.Status.StartTime.String()
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+						task.Name,
+						TaskState(&task),
+						startTime,
+						task.Spec.Command,
+					)
+				}
+			})
+
+			return nil
+		},
+	}
+
+	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
+
+	return cmd
+}
+
+// TaskState gets a human readable state for the Task.
+func TaskState(task *v1alpha1.Task) string {
+	cond := task.Status.GetCondition(v1alpha1.TaskConditionSucceeded)
+	switch {
+	case !task.DeletionTimestamp.IsZero():
+		return "DELETING"
+	case cond == nil:
+		return "PENDING"
+	case cond.Status == corev1.ConditionTrue:
+		return "SUCCEEDED"
+	case cond.Status == corev1.ConditionFalse && cond.Reason == "Terminated":
+		return "TERMINATED"
+	case cond.Status == corev1.ConditionFalse:
+		return "FAILED"
+	case task.Spec.Terminated:
+		return "TERMINATING"
+	default:
+		return "RUNNING"
+	}
+}
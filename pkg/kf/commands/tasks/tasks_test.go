@@ -0,0 +1,131 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/tasks"
+	faketasks "github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func buildTask(name, appName string, status corev1.ConditionStatus, reason string) v1alpha1.Task {
+	task := v1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.TaskSpec{
+			AppName: appName,
+			Command: "rake db:migrate",
+		},
+	}
+
+	if status != "" {
+		task.Status.Conditions = duckv1beta1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: status,
+			Reason: reason,
+		}}
+	}
+
+	return task
+}
+
+func TestTasks(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *faketasks.FakeClient)
+	}{
+		"wrong number of args": {
+			Namespace:   "default",
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"listing fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to list tasks: some-error"),
+			Setup: func(t *testing.T, fake *faketasks.FakeClient) {
+				fake.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+		"shows tasks": {
+			Namespace: "default",
+			Args:      []string{"my-app"},
+			Setup: func(t *testing.T, fake *faketasks.FakeClient) {
+				fake.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]v1alpha1.Task{
+						buildTask("task-succeeded", "my-app", corev1.ConditionTrue, ""),
+						buildTask("task-failed", "my-app", corev1.ConditionFalse, "Error"),
+						buildTask("task-terminated", "my-app", corev1.ConditionFalse, "Terminated"),
+						buildTask("task-running", "my-app", corev1.ConditionUnknown, ""),
+					}, nil)
+			},
+			ExpectedStrings: []string{
+				"task-succeeded", "SUCCEEDED",
+				"task-failed", "FAILED",
+				"task-terminated", "TERMINATED",
+				"task-running", "RUNNING",
+				"rake db:migrate",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := faketasks.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := tasks.NewTasksCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			ctrl.Finish()
+		})
+	}
+}
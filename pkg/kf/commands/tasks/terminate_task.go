@@ -0,0 +1,69 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/completion"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+)
+
+// NewTerminateTaskCommand creates a command to cancel a running task.
+func NewTerminateTaskCommand(
+	p *config.KfParams,
+	client tasks.Client,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "terminate-task APP_NAME TASK_NAME",
+		Short:   "Terminate a running task",
+		Example: `kf terminate-task myapp myapp-x7k2p`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+			taskName := args[1]
+
+			cmd.SilenceUsage = true
+
+			task, err := client.Get(p.Namespace, taskName)
+			if err != nil {
+				return fmt.Errorf("failed to terminate task: %s", err)
+			}
+
+			if task.Spec.AppName != appName {
+				return fmt.Errorf("failed to terminate task: task %s doesn't belong to app %s", taskName, appName)
+			}
+
+			if err := client.Terminate(p.Namespace, taskName); err != nil {
+				return fmt.Errorf("failed to terminate task: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Terminating task %s\n", taskName)
+
+			return nil
+		},
+	}
+
+	completion.MarkArgCompletionSupported(cmd, completion.AppCompletion)
+
+	return cmd
+}
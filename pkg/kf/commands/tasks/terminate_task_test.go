@@ -0,0 +1,114 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/tasks"
+	faketasks "github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestTerminateTask(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *faketasks.FakeClient)
+	}{
+		"wrong number of args": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("accepts 2 arg(s), received 1"),
+		},
+		"terminates task": {
+			Namespace: "default",
+			Args:      []string{"my-app", "my-task"},
+			Setup: func(t *testing.T, fake *faketasks.FakeClient) {
+				task := buildTask("my-task", "my-app", "", "")
+				fake.EXPECT().Get("default", "my-task").Return(&task, nil)
+				fake.EXPECT().Terminate("default", "my-task")
+			},
+			ExpectedStrings: []string{"my-task"},
+		},
+		"getting task fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "my-task"},
+			ExpectedErr: errors.New("failed to terminate task: some-error"),
+			Setup: func(t *testing.T, fake *faketasks.FakeClient) {
+				fake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+			},
+		},
+		"task belongs to another app": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "my-task"},
+			ExpectedErr: errors.New("failed to terminate task: task my-task doesn't belong to app my-app"),
+			Setup: func(t *testing.T, fake *faketasks.FakeClient) {
+				fake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&v1alpha1.Task{
+					Spec: v1alpha1.TaskSpec{AppName: "other-app"},
+				}, nil)
+			},
+		},
+		"terminating fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "my-task"},
+			ExpectedErr: errors.New("failed to terminate task: some-error"),
+			Setup: func(t *testing.T, fake *faketasks.FakeClient) {
+				task := buildTask("my-task", "my-app", "", "")
+				fake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&task, nil)
+				fake.EXPECT().Terminate(gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := faketasks.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := tasks.NewTerminateTaskCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			ctrl.Finish()
+		})
+	}
+}
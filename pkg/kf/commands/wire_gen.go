@@ -16,19 +16,26 @@ import (
 	"github.com/google/kf/pkg/kf/commands/builds"
 	"github.com/google/kf/pkg/kf/commands/completion"
 	"github.com/google/kf/pkg/kf/commands/config"
+	domains2 "github.com/google/kf/pkg/kf/commands/domains"
+	"github.com/google/kf/pkg/kf/commands/network-policies"
 	"github.com/google/kf/pkg/kf/commands/quotas"
 	routes2 "github.com/google/kf/pkg/kf/commands/routes"
 	servicebindings2 "github.com/google/kf/pkg/kf/commands/service-bindings"
+	"github.com/google/kf/pkg/kf/commands/service-brokers"
 	services2 "github.com/google/kf/pkg/kf/commands/services"
 	spaces2 "github.com/google/kf/pkg/kf/commands/spaces"
+	tasks2 "github.com/google/kf/pkg/kf/commands/tasks"
+	"github.com/google/kf/pkg/kf/domains"
 	"github.com/google/kf/pkg/kf/istio"
 	"github.com/google/kf/pkg/kf/logs"
 	"github.com/google/kf/pkg/kf/routeclaims"
 	"github.com/google/kf/pkg/kf/routes"
+	"github.com/google/kf/pkg/kf/secrets"
 	"github.com/google/kf/pkg/kf/service-bindings"
 	"github.com/google/kf/pkg/kf/services"
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/google/kf/pkg/kf/tasks"
 	"github.com/google/wire"
 	logs2 "github.com/knative/build/pkg/logs"
 	"github.com/poy/kontext"
@@ -49,7 +56,8 @@ func InjectPush(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	pusher := apps.NewPusher(appsClient)
 	srcImageBuilder := provideSrcImageBuilder()
 	versionedInterface := config.GetServiceCatalogClient(p)
@@ -64,7 +72,8 @@ func InjectDelete(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewDeleteCommand(p, appsClient)
 	return command
 }
@@ -75,7 +84,8 @@ func InjectApps(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewAppsCommand(p, appsClient)
 	return command
 }
@@ -86,8 +96,10 @@ func InjectGetApp(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
-	command := apps2.NewGetAppCommand(p, appsClient)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
+	coreV1Interface := provideCoreV1(p)
+	command := apps2.NewGetAppCommand(p, appsClient, coreV1Interface)
 	return command
 }
 
@@ -97,7 +109,8 @@ func InjectScale(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewScaleCommand(p, appsClient)
 	return command
 }
@@ -108,7 +121,8 @@ func InjectStart(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewStartCommand(p, appsClient)
 	return command
 }
@@ -119,7 +133,8 @@ func InjectStop(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewStopCommand(p, appsClient)
 	return command
 }
@@ -130,7 +145,8 @@ func InjectRestart(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewRestartCommand(p, appsClient)
 	return command
 }
@@ -141,18 +157,44 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewRestageCommand(p, appsClient)
 	return command
 }
 
+func InjectRollback(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
+	command := apps2.NewRollbackCommand(p, appsClient)
+	return command
+}
+
+func InjectAppHistory(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
+	command := apps2.NewAppHistoryCommand(p, appsClient)
+	return command
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	kubernetesInterface := config.GetKubernetes(p)
 	ingressLister := istio.NewIstioClient(kubernetesInterface)
 	command := apps2.NewProxyCommand(p, appsClient, ingressLister)
@@ -172,7 +214,8 @@ func InjectEnv(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewEnvCommand(p, appsClient)
 	return command
 }
@@ -183,7 +226,8 @@ func InjectSetEnv(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewSetEnvCommand(p, appsClient)
 	return command
 }
@@ -194,7 +238,8 @@ func InjectUnsetEnv(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := apps2.NewUnsetEnvCommand(p, appsClient)
 	return command
 }
@@ -209,7 +254,8 @@ func InjectCreateService(p *config.KfParams) *cobra.Command {
 func InjectDeleteService(p *config.KfParams) *cobra.Command {
 	sClientFactory := config.GetSvcatApp(p)
 	clientInterface := services.NewClient(sClientFactory)
-	command := services2.NewDeleteServiceCommand(p, clientInterface)
+	kubernetesInterface := config.GetKubernetes(p)
+	command := services2.NewDeleteServiceCommand(p, clientInterface, kubernetesInterface)
 	return command
 }
 
@@ -228,11 +274,24 @@ func InjectListServices(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := services2.NewListServicesCommand(p, clientInterface, appsClient)
 	return command
 }
 
+func InjectCreateUserProvidedService(p *config.KfParams) *cobra.Command {
+	kubernetesInterface := config.GetKubernetes(p)
+	command := services2.NewCreateUserProvidedServiceCommand(p, kubernetesInterface)
+	return command
+}
+
+func InjectUpdateUserProvidedService(p *config.KfParams) *cobra.Command {
+	kubernetesInterface := config.GetKubernetes(p)
+	command := services2.NewUpdateUserProvidedServiceCommand(p, kubernetesInterface)
+	return command
+}
+
 func InjectMarketplace(p *config.KfParams) *cobra.Command {
 	sClientFactory := config.GetSvcatApp(p)
 	clientInterface := services.NewClient(sClientFactory)
@@ -240,13 +299,46 @@ func InjectMarketplace(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCreateServiceBroker(p *config.KfParams) *cobra.Command {
+	sClientFactory := config.GetSvcatApp(p)
+	clientInterface := services.NewClient(sClientFactory)
+	kubernetesInterface := config.GetKubernetes(p)
+	secretsClientInterface := secrets.NewClient(kubernetesInterface)
+	command := servicebrokers.NewCreateServiceBrokerCommand(p, clientInterface, secretsClientInterface)
+	return command
+}
+
+func InjectDeleteServiceBroker(p *config.KfParams) *cobra.Command {
+	sClientFactory := config.GetSvcatApp(p)
+	clientInterface := services.NewClient(sClientFactory)
+	kubernetesInterface := config.GetKubernetes(p)
+	secretsClientInterface := secrets.NewClient(kubernetesInterface)
+	command := servicebrokers.NewDeleteServiceBrokerCommand(p, clientInterface, secretsClientInterface)
+	return command
+}
+
+func InjectListServiceBrokers(p *config.KfParams) *cobra.Command {
+	sClientFactory := config.GetSvcatApp(p)
+	clientInterface := services.NewClient(sClientFactory)
+	command := servicebrokers.NewListServiceBrokersCommand(p, clientInterface)
+	return command
+}
+
+func InjectRefreshServiceBroker(p *config.KfParams) *cobra.Command {
+	sClientFactory := config.GetSvcatApp(p)
+	clientInterface := services.NewClient(sClientFactory)
+	command := servicebrokers.NewRefreshServiceBrokerCommand(p, clientInterface)
+	return command
+}
+
 func InjectBindingService(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	versionedInterface := config.GetServiceCatalogClient(p)
 	clientInterface := servicebindings.NewClient(appsClient, versionedInterface)
 	command := servicebindings2.NewBindServiceCommand(p, clientInterface)
@@ -259,7 +351,8 @@ func InjectListBindings(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	versionedInterface := config.GetServiceCatalogClient(p)
 	clientInterface := servicebindings.NewClient(appsClient, versionedInterface)
 	command := servicebindings2.NewListBindingsCommand(p, clientInterface)
@@ -272,7 +365,8 @@ func InjectUnbindService(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	versionedInterface := config.GetServiceCatalogClient(p)
 	clientInterface := servicebindings.NewClient(appsClient, versionedInterface)
 	command := servicebindings2.NewUnbindServiceCommand(p, clientInterface)
@@ -375,8 +469,13 @@ func InjectRoutes(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	sourcesClient := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, sourcesClient)
-	command := routes2.NewRoutesCommand(p, client, routeclaimsClient, appsClient)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, sourcesClient, podsGetter)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	spacesClient := spaces.NewClient(spacesGetter)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	domainsClient := domains.NewClient(domainsGetter)
+	command := routes2.NewRoutesCommand(p, client, routeclaimsClient, appsClient, spacesClient, domainsClient)
 	return command
 }
 
@@ -387,6 +486,13 @@ func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectUpdateRoute(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routeclaims.NewClient(kfV1alpha1Interface)
+	command := routes2.NewUpdateRouteCommand(p, client)
+	return command
+}
+
 func InjectDeleteRoute(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	client := routeclaims.NewClient(kfV1alpha1Interface)
@@ -394,7 +500,8 @@ func InjectDeleteRoute(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	sourcesClient := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, sourcesClient)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, sourcesClient, podsGetter)
 	command := routes2.NewDeleteRouteCommand(p, client, appsClient)
 	return command
 }
@@ -405,7 +512,8 @@ func InjectMapRoute(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := routes2.NewMapRouteCommand(p, appsClient)
 	return command
 }
@@ -416,11 +524,112 @@ func InjectUnmapRoute(p *config.KfParams) *cobra.Command {
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
 	buildTailer := provideSourcesBuildTailer()
 	client := sources.NewClient(sourcesGetter, buildTailer)
-	appsClient := apps.NewClient(appsGetter, client)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
 	command := routes2.NewUnmapRouteCommand(p, appsClient)
 	return command
 }
 
+func InjectBindRouteService(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routeclaims.NewClient(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	command := routes2.NewBindRouteServiceCommand(p, client, kubernetesInterface)
+	return command
+}
+
+func InjectUnbindRouteService(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routeclaims.NewClient(kfV1alpha1Interface)
+	command := routes2.NewUnbindRouteServiceCommand(p, client)
+	return command
+}
+
+func InjectDomains(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	command := domains2.NewDomainsCommand(p, client)
+	return command
+}
+
+func InjectCreateDomain(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	spacesClient := spaces.NewClient(spacesGetter)
+	command := domains2.NewCreateDomainCommand(p, client, spacesClient)
+	return command
+}
+
+func InjectCreateSharedDomain(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	command := domains2.NewCreateSharedDomainCommand(p, client)
+	return command
+}
+
+func InjectDeleteDomain(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	domainsGetter := provideKfDomains(kfV1alpha1Interface)
+	client := domains.NewClient(domainsGetter)
+	command := domains2.NewDeleteDomainCommand(p, client)
+	return command
+}
+
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	podsGetter := provideAppsPodsGetter(p)
+	appsClient := apps.NewClient(appsGetter, client, podsGetter)
+	kubernetesInterface := config.GetKubernetes(p)
+	command := networkpolicies.NewAddNetworkPolicyCommand(p, appsClient, kubernetesInterface)
+	return command
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	kubernetesInterface := config.GetKubernetes(p)
+	command := networkpolicies.NewRemoveNetworkPolicyCommand(p, kubernetesInterface)
+	return command
+}
+
+func InjectNetworkPolicies(p *config.KfParams) *cobra.Command {
+	kubernetesInterface := config.GetKubernetes(p)
+	command := networkpolicies.NewNetworkPoliciesCommand(p, kubernetesInterface)
+	return command
+}
+
+func InjectRunTask(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	tasksGetter := provideKfTasks(kfV1alpha1Interface)
+	client := tasks.NewClient(tasksGetter)
+	coreV1Interface := provideCoreV1(p)
+	tailer := logs.NewTailer(coreV1Interface)
+	command := tasks2.NewRunTaskCommand(p, client, tailer)
+	return command
+}
+
+func InjectTasks(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	tasksGetter := provideKfTasks(kfV1alpha1Interface)
+	client := tasks.NewClient(tasksGetter)
+	command := tasks2.NewTasksCommand(p, client)
+	return command
+}
+
+func InjectTerminateTask(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	tasksGetter := provideKfTasks(kfV1alpha1Interface)
+	client := tasks.NewClient(tasksGetter)
+	command := tasks2.NewTerminateTaskCommand(p, client)
+	return command
+}
+
 func InjectBuilds(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
@@ -464,6 +673,10 @@ func provideAppsGetter(ki v1alpha1.KfV1alpha1Interface) v1alpha1.AppsGetter {
 	return ki
 }
 
+func provideAppsPodsGetter(p *config.KfParams) v1.PodsGetter {
+	return config.GetKubernetes(p).CoreV1()
+}
+
 func provideCoreV1(p *config.KfParams) v1.CoreV1Interface {
 	return config.GetKubernetes(p).CoreV1()
 }
@@ -481,6 +694,18 @@ func provideKfSpaces(ki v1alpha1.KfV1alpha1Interface) v1alpha1.SpacesGetter {
 	return ki
 }
 
+var DomainsSet = wire.NewSet(config.GetKfClient, provideKfDomains, domains.NewClient)
+
+func provideKfDomains(ki v1alpha1.KfV1alpha1Interface) v1alpha1.DomainsGetter {
+	return ki
+}
+
+var TasksSet = wire.NewSet(config.GetKfClient, provideKfTasks, tasks.NewClient)
+
+func provideKfTasks(ki v1alpha1.KfV1alpha1Interface) v1alpha1.TasksGetter {
+	return ki
+}
+
 var SourcesSet = wire.NewSet(config.GetKfClient, provideSourcesBuildTailer, provideKfSources, sources.NewClient)
 
 func provideKfSources(ki v1alpha1.KfV1alpha1Interface) v1alpha1.SourcesGetter {
@@ -27,19 +27,26 @@ import (
 	cbuilds "github.com/google/kf/pkg/kf/commands/builds"
 	ccompletion "github.com/google/kf/pkg/kf/commands/completion"
 	"github.com/google/kf/pkg/kf/commands/config"
+	cdomains "github.com/google/kf/pkg/kf/commands/domains"
+	cnetworkpolicies "github.com/google/kf/pkg/kf/commands/network-policies"
 	cquotas "github.com/google/kf/pkg/kf/commands/quotas"
 	croutes "github.com/google/kf/pkg/kf/commands/routes"
 	servicebindingscmd "github.com/google/kf/pkg/kf/commands/service-bindings"
+	servicebrokerscmd "github.com/google/kf/pkg/kf/commands/service-brokers"
 	servicescmd "github.com/google/kf/pkg/kf/commands/services"
 	cspaces "github.com/google/kf/pkg/kf/commands/spaces"
+	ctasks "github.com/google/kf/pkg/kf/commands/tasks"
+	"github.com/google/kf/pkg/kf/domains"
 	"github.com/google/kf/pkg/kf/istio"
 	kflogs "github.com/google/kf/pkg/kf/logs"
 	"github.com/google/kf/pkg/kf/routeclaims"
 	"github.com/google/kf/pkg/kf/routes"
+	"github.com/google/kf/pkg/kf/secrets"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
 	"github.com/google/kf/pkg/kf/services"
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/spaces"
+	"github.com/google/kf/pkg/kf/tasks"
 	"github.com/google/wire"
 	"github.com/knative/build/pkg/logs"
 	"github.com/poy/kontext"
@@ -62,6 +69,7 @@ func provideBuildTailer() builds.BuildTailer {
 var AppsSet = wire.NewSet(
 	SourcesSet,
 	provideAppsGetter,
+	provideAppsPodsGetter,
 	apps.NewClient,
 	apps.NewPusher,
 )
@@ -70,6 +78,10 @@ func provideAppsGetter(ki kfv1alpha1.KfV1alpha1Interface) kfv1alpha1.AppsGetter
 	return ki
 }
 
+func provideAppsPodsGetter(p *config.KfParams) corev1.PodsGetter {
+	return config.GetKubernetes(p).CoreV1()
+}
+
 func InjectPush(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		capps.NewPushCommand,
@@ -94,7 +106,7 @@ func InjectApps(p *config.KfParams) *cobra.Command {
 }
 
 func InjectGetApp(p *config.KfParams) *cobra.Command {
-	wire.Build(capps.NewGetAppCommand, AppsSet)
+	wire.Build(capps.NewGetAppCommand, AppsSet, provideCoreV1)
 
 	return nil
 }
@@ -124,6 +136,16 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectRollback(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewRollbackCommand, AppsSet)
+	return nil
+}
+
+func InjectAppHistory(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewAppHistoryCommand, AppsSet)
+	return nil
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		capps.NewProxyCommand,
@@ -186,6 +208,7 @@ func InjectDeleteService(p *config.KfParams) *cobra.Command {
 		services.NewClient,
 		servicescmd.NewDeleteServiceCommand,
 		config.GetSvcatApp,
+		config.GetKubernetes,
 	)
 	return nil
 }
@@ -209,6 +232,22 @@ func InjectListServices(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectCreateUserProvidedService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicescmd.NewCreateUserProvidedServiceCommand,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
+func InjectUpdateUserProvidedService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicescmd.NewUpdateUserProvidedServiceCommand,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
 func InjectMarketplace(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		services.NewClient,
@@ -218,6 +257,49 @@ func InjectMarketplace(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+//////////////////////
+// Service Brokers //
+////////////////////
+func InjectCreateServiceBroker(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		services.NewClient,
+		secrets.NewClient,
+		servicebrokerscmd.NewCreateServiceBrokerCommand,
+		config.GetSvcatApp,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
+func InjectDeleteServiceBroker(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		services.NewClient,
+		secrets.NewClient,
+		servicebrokerscmd.NewDeleteServiceBrokerCommand,
+		config.GetSvcatApp,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
+func InjectListServiceBrokers(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		services.NewClient,
+		servicebrokerscmd.NewListServiceBrokersCommand,
+		config.GetSvcatApp,
+	)
+	return nil
+}
+
+func InjectRefreshServiceBroker(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		services.NewClient,
+		servicebrokerscmd.NewRefreshServiceBrokerCommand,
+		config.GetSvcatApp,
+	)
+	return nil
+}
+
 ///////////////////////
 // Service Bindings //
 /////////////////////
@@ -362,6 +444,10 @@ func InjectRoutes(p *config.KfParams) *cobra.Command {
 		routes.NewClient,
 		routeclaims.NewClient,
 		AppsSet,
+		provideKfSpaces,
+		spaces.NewClient,
+		provideKfDomains,
+		domains.NewClient,
 	)
 	return nil
 }
@@ -375,6 +461,15 @@ func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectUpdateRoute(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewUpdateRouteCommand,
+		routeclaims.NewClient,
+		config.GetKfClient,
+	)
+	return nil
+}
+
 func InjectDeleteRoute(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		croutes.NewDeleteRouteCommand,
@@ -400,6 +495,119 @@ func InjectUnmapRoute(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectBindRouteService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewBindRouteServiceCommand,
+		routeclaims.NewClient,
+		config.GetKfClient,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
+func InjectUnbindRouteService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewUnbindRouteServiceCommand,
+		routeclaims.NewClient,
+		config.GetKfClient,
+	)
+	return nil
+}
+
+/////////////
+// Domains //
+///////////
+
+var DomainsSet = wire.NewSet(config.GetKfClient, provideKfDomains, domains.NewClient)
+
+func provideKfDomains(ki kfv1alpha1.KfV1alpha1Interface) kfv1alpha1.DomainsGetter {
+	return ki
+}
+
+func InjectDomains(p *config.KfParams) *cobra.Command {
+	wire.Build(cdomains.NewDomainsCommand, DomainsSet)
+	return nil
+}
+
+func InjectCreateDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		cdomains.NewCreateDomainCommand,
+		DomainsSet,
+		provideKfSpaces,
+		spaces.NewClient,
+	)
+	return nil
+}
+
+func InjectCreateSharedDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(cdomains.NewCreateSharedDomainCommand, DomainsSet)
+	return nil
+}
+
+func InjectDeleteDomain(p *config.KfParams) *cobra.Command {
+	wire.Build(cdomains.NewDeleteDomainCommand, DomainsSet)
+	return nil
+}
+
+//////////////////////
+// Network Policies //
+////////////////////
+
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		cnetworkpolicies.NewAddNetworkPolicyCommand,
+		AppsSet,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		cnetworkpolicies.NewRemoveNetworkPolicyCommand,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
+func InjectNetworkPolicies(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		cnetworkpolicies.NewNetworkPoliciesCommand,
+		config.GetKubernetes,
+	)
+	return nil
+}
+
+///////////
+// Tasks //
+/////////
+
+var TasksSet = wire.NewSet(config.GetKfClient, provideKfTasks, tasks.NewClient)
+
+func provideKfTasks(ki kfv1alpha1.KfV1alpha1Interface) kfv1alpha1.TasksGetter {
+	return ki
+}
+
+func InjectRunTask(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		ctasks.NewRunTaskCommand,
+		TasksSet,
+		kflogs.NewTailer,
+		provideCoreV1,
+	)
+	return nil
+}
+
+func InjectTasks(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewTasksCommand, TasksSet)
+	return nil
+}
+
+func InjectTerminateTask(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewTerminateTaskCommand, TasksSet)
+	return nil
+}
+
 ////////////////////
 // Builds Command //
 ////////////////////
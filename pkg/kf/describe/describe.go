@@ -235,6 +235,80 @@ func AppSpecInstances(w io.Writer, instances kfv1alpha1.AppSpecInstances) {
 	})
 }
 
+// AppInstancesStatus describes the running instances of the app, pods holds
+// the state of each instance.
+func AppInstancesStatus(w io.Writer, instances kfv1alpha1.AppInstancesStatus, pods []kfv1alpha1.AppInstanceStatus) {
+
+	SectionWriter(w, "Instances", func(w io.Writer) {
+		fmt.Fprintf(w, "Ready:\t%d/%d\n", instances.Ready, instances.Desired)
+		fmt.Fprintf(w, "Restarts:\t%d\n", instances.Restarts)
+		if instances.LastTerminationReason != "" {
+			fmt.Fprintf(w, "Last Termination:\t%s\n", instances.LastTerminationReason)
+		}
+
+		SectionWriter(w, "Pods", func(w io.Writer) {
+			if len(pods) == 0 {
+				return
+			}
+
+			fmt.Fprintln(w, "Name\tState\tRestarts\tLast Termination\tAge\tNode")
+			for _, pod := range pods {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+					pod.Name,
+					pod.State,
+					pod.Restarts,
+					pod.LastTerminationReason,
+					translateTimestampSince(pod.CreationTimestamp),
+					pod.NodeName,
+				)
+			}
+		})
+	})
+}
+
+// AppServiceCredentialsStatus describes the service credentials injected
+// into the app, when they last changed and the Events recorded when they
+// rolled the app's instances. events may hold any of the app's Events.
+func AppServiceCredentialsStatus(w io.Writer, credentials kfv1alpha1.AppServiceCredentialsStatus, events []corev1.Event) {
+
+	SectionWriter(w, "Service Credentials", func(w io.Writer) {
+		if credentials.Hash == "" {
+			return
+		}
+
+		hash := credentials.Hash
+		if len(hash) > 12 {
+			hash = hash[:12]
+		}
+		fmt.Fprintf(w, "Hash:\t%s\n", hash)
+
+		if changed := credentials.LastChanged; changed != nil {
+			fmt.Fprintf(w, "Last Changed:\t%s\n", changed)
+		}
+
+		SectionWriter(w, "Events", func(w io.Writer) {
+			var rolls []corev1.Event
+			for _, event := range events {
+				if event.Reason == kfv1alpha1.ServiceCredentialsChangedReason {
+					rolls = append(rolls, event)
+				}
+			}
+			if len(rolls) == 0 {
+				return
+			}
+
+			fmt.Fprintln(w, "Last Seen\tCount\tMessage")
+			for _, event := range rolls {
+				fmt.Fprintf(w, "%s\t%d\t%s\n",
+					translateTimestampSince(event.LastTimestamp),
+					event.Count,
+					event.Message,
+				)
+			}
+		})
+	})
+}
+
 // AppSpecTemplate describes the runtime configurations of the app.
 func AppSpecTemplate(w io.Writer, template kfv1alpha1.AppSpecTemplate) {
 
@@ -282,6 +282,80 @@ func ExampleAppSpecInstances_minMax() {
 	//   Max:       5
 }
 
+func ExampleAppInstancesStatus() {
+	describe.AppInstancesStatus(os.Stdout, kfv1alpha1.AppInstancesStatus{
+		Desired:               3,
+		Ready:                 1,
+		Restarts:              5,
+		LastTerminationReason: "OOMKilled",
+	}, []kfv1alpha1.AppInstanceStatus{
+		{
+			Name:     "my-app-abc",
+			State:    "Running",
+			Ready:    true,
+			NodeName: "node-1",
+		},
+		{
+			Name:                  "my-app-def",
+			State:                 "CrashLoopBackOff",
+			Restarts:              5,
+			LastTerminationReason: "OOMKilled",
+			NodeName:              "node-2",
+		},
+	})
+
+	// Output: Instances:
+	//   Ready:             1/3
+	//   Restarts:          5
+	//   Last Termination:  OOMKilled
+	//   Pods:
+	//     Name        State             Restarts  Last Termination  Age        Node
+	//     my-app-abc  Running           0                           <unknown>  node-1
+	//     my-app-def  CrashLoopBackOff  5         OOMKilled         <unknown>  node-2
+}
+
+func ExampleAppInstancesStatus_empty() {
+	describe.AppInstancesStatus(os.Stdout, kfv1alpha1.AppInstancesStatus{}, nil)
+
+	// Output: Instances:
+	//   Ready:     0/0
+	//   Restarts:  0
+	//   Pods: <empty>
+}
+
+func ExampleAppServiceCredentialsStatus() {
+	changed := metav1.NewTime(time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC))
+
+	describe.AppServiceCredentialsStatus(os.Stdout, kfv1alpha1.AppServiceCredentialsStatus{
+		Hash:        "44136fa355b3678a1146ad16f7e8649e",
+		LastChanged: &changed,
+	}, []corev1.Event{
+		{
+			Reason:  "ServiceCredentialsChanged",
+			Message: "Service credentials changed, rolling instances",
+			Count:   2,
+		},
+		{
+			Reason:  "SomethingElse",
+			Message: "Not about credentials",
+			Count:   1,
+		},
+	})
+
+	// Output: Service Credentials:
+	//   Hash:          44136fa355b3
+	//   Last Changed:  2019-07-01 12:00:00 +0000 UTC
+	//   Events:
+	//     Last Seen  Count  Message
+	//     <unknown>  2      Service credentials changed, rolling instances
+}
+
+func ExampleAppServiceCredentialsStatus_empty() {
+	describe.AppServiceCredentialsStatus(os.Stdout, kfv1alpha1.AppServiceCredentialsStatus{}, nil)
+
+	// Output: Service Credentials: <empty>
+}
+
 func ExampleSourceSpec_buildpack() {
 	spec := kfv1alpha1.SourceSpec{
 		ServiceAccount: "builder-account",
@@ -0,0 +1,99 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kf "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// virtualServicesResource is the Istio resource kf routes traffic with.
+var virtualServicesResource = schema.GroupVersionResource{
+	Group:    "networking.istio.io",
+	Version:  "v1alpha3",
+	Resource: "virtualservices",
+}
+
+// RoutesDiagnostic tests that the VirtualServices made for Routes haven't
+// outlived them.
+type RoutesDiagnostic struct {
+	kfClient      kf.KfV1alpha1Interface
+	dynamicClient dynamic.Interface
+}
+
+var _ Diagnosable = (*RoutesDiagnostic)(nil)
+
+// Diagnose looks for VirtualServices left behind by deleted Routes.
+func (r *RoutesDiagnostic) Diagnose(d *Diagnostic) {
+	d.Run("VirtualServices", func(d *Diagnostic) {
+		diagnoseOrphanedVirtualServices(d, r.kfClient, r.dynamicClient)
+	})
+}
+
+// NewRoutesDiagnostic creates a new RoutesDiagnostic to validate the Routes
+// of every space.
+func NewRoutesDiagnostic(kfClient kf.KfV1alpha1Interface, dynamicClient dynamic.Interface) *RoutesDiagnostic {
+	return &RoutesDiagnostic{
+		kfClient:      kfClient,
+		dynamicClient: dynamicClient,
+	}
+}
+
+// diagnoseOrphanedVirtualServices checks that every VirtualService kf made
+// still has a Route. VirtualServices live in the kf namespace so Kubernetes
+// doesn't garbage collect them with the Routes of other namespaces.
+func diagnoseOrphanedVirtualServices(d *Diagnostic, routesGetter kf.RoutesGetter, dynamicClient dynamic.Interface) {
+	routeList, err := routesGetter.Routes(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		d.Fatalf("Error listing Routes: %v", err)
+	}
+
+	var routes []*v1alpha1.Route
+	for i := range routeList.Items {
+		routes = append(routes, &routeList.Items[i])
+	}
+
+	vsList, err := dynamicClient.
+		Resource(virtualServicesResource).
+		Namespace(v1alpha1.KfNamespace).
+		List(metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(labels.Set{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: "virtualservice",
+			}).String(),
+		})
+	if err != nil {
+		d.Fatalf("Error listing VirtualServices: %v", err)
+	}
+
+	d.Logf("found %d VirtualServices for %d Routes", len(vsList.Items), len(routes))
+
+	for _, vs := range vsList.Items {
+		if len(v1alpha1.LiveRouteReferences(vs.GetOwnerReferences(), routes)) > 0 {
+			continue
+		}
+
+		d.Errorf(
+			"VirtualService %s/%s for space %q has no Routes left, it will be removed by the controller",
+			vs.GetNamespace(),
+			vs.GetName(),
+			vs.GetAnnotations()["space"],
+		)
+	}
+}
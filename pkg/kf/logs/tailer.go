@@ -22,6 +22,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"k8s.io/api/core/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,15 +78,22 @@ func (t *tailer) Tail(ctx context.Context, appName string, out io.Writer, opts .
 		Writer: out,
 	}
 
-	if err := t.watchForPods(ctx, namespace, appName, writer, logOpts); err != nil {
+	// App instances are managed by Knative while Tasks are run by Jobs that
+	// label their Pods with the Task name.
+	selector := "serving.knative.dev/service=" + appName
+	if cfg.Task != "" {
+		selector = v1alpha1.TaskNameLabel + "=" + cfg.Task
+	}
+
+	if err := t.watchForPods(ctx, namespace, selector, writer, logOpts); err != nil {
 		return fmt.Errorf("failed to watch pods: %s", err)
 	}
 	return nil
 }
 
-func (t *tailer) watchForPods(ctx context.Context, namespace, appName string, writer *MutexWriter, opts v1.PodLogOptions) error {
+func (t *tailer) watchForPods(ctx context.Context, namespace, selector string, writer *MutexWriter, opts v1.PodLogOptions) error {
 	w, err := t.client.Pods(namespace).Watch(metav1.ListOptions{
-		LabelSelector: "serving.knative.dev/service=" + appName,
+		LabelSelector: selector,
 	})
 	if err != nil {
 		return err
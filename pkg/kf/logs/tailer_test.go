@@ -67,6 +67,7 @@ func TestTailer_Tail(t *testing.T) {
 		pod            *v1.Pod
 		expectedOutput string
 		watchErr       error
+		wantSelector   string
 	}{
 		"default namespace": {
 			appName: "some-app",
@@ -116,6 +117,19 @@ func TestTailer_Tail(t *testing.T) {
 					Name: "some-app-pod1",
 				},
 			},
+			wantSelector: "serving.knative.dev/service=some-app",
+		},
+		"uses task selector": {
+			appName: "some-app",
+			opts: []logs.TailOption{
+				logs.WithTailTask("some-task"),
+			},
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "some-task-pod1",
+				},
+			},
+			wantSelector: "kf.dev/task=some-task",
 		},
 		"writes logs to the writer": {
 			appName: "some-app",
@@ -208,6 +222,11 @@ func TestTailer_Tail(t *testing.T) {
 			}
 
 			fakeClient.AddWatchReactor("*", ktesting.WatchReactionFunc(func(action ktesting.Action) (handled bool, ret watch.Interface, err error) {
+				if testCase.wantSelector != "" {
+					selector := action.(ktesting.WatchActionImpl).GetWatchRestrictions().Labels.String()
+					testutil.AssertEqual(t, "selector", testCase.wantSelector, selector)
+				}
+
 				return true, fakeWatcher, testCase.watchErr
 			}))
 
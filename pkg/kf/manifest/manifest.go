@@ -40,6 +40,7 @@ type Application struct {
 	Memory     string            `yaml:"memory,omitempty"`
 	CPU        string            `yaml:"cpu,omitempty"`
 	Instances  *int              `yaml:"instances,omitempty"`
+	Command    string            `yaml:"command,omitempty"`
 
 	// TODO(#95): These aren't CF proper. How do we expose these in the
 	// manifest?
@@ -61,6 +62,35 @@ type Application struct {
 	// HealthCheckHTTPEndpoint holds the HTTP endpoint that will receive the
 	// get requests to determine liveness if HealthCheckType is http.
 	HealthCheckHTTPEndpoint string `yaml:"health-check-http-endpoint,omitempty"`
+
+	// Sidecars holds additional processes that run next to the app. Kf can't
+	// run them because Knative Serving only supports a single container, they
+	// are only read so kf push can reject them.
+	Sidecars []Sidecar `yaml:"sidecars,omitempty"`
+
+	// Processes holds the process types that are started from the app's
+	// image. The web process configures the app itself.
+	Processes []Process `yaml:"processes,omitempty"`
+}
+
+// Process is a process type that runs from the application's image with its
+// own command, scale, memory and health check.
+type Process struct {
+	Type                    string `yaml:"type,omitempty"`
+	Command                 string `yaml:"command,omitempty"`
+	Instances               *int   `yaml:"instances,omitempty"`
+	Memory                  string `yaml:"memory,omitempty"`
+	HealthCheckType         string `yaml:"health-check-type,omitempty"`
+	HealthCheckHTTPEndpoint string `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckTimeout      int    `yaml:"timeout,omitempty"`
+}
+
+// Sidecar is an additional process that runs in the same container image as
+// the application.
+type Sidecar struct {
+	Name    string `yaml:"name,omitempty"`
+	Command string `yaml:"command,omitempty"`
+	Memory  string `yaml:"memory,omitempty"`
 }
 
 // AppDockerImage is the struct for docker configuration.
@@ -169,6 +199,8 @@ for more info.
 `)
 	}
 
+	app.applyWebProcess()
+
 	appEnv := envutil.MapToEnvVars(app.Env)
 	overrideEnv := envutil.MapToEnvVars(overrides.Env)
 	combined := append(appEnv, overrideEnv...)
@@ -199,6 +231,55 @@ for more info.
 	return nil
 }
 
+// WebProcessType is the process type that receives routed traffic.
+const WebProcessType = "web"
+
+// WorkerProcesses returns the processes other than the web process.
+func (app *Application) WorkerProcesses() []Process {
+	var out []Process
+	for _, process := range app.Processes {
+		if process.Type != WebProcessType {
+			out = append(out, process)
+		}
+	}
+
+	return out
+}
+
+// applyWebProcess copies the settings of the web process, if any, onto the
+// application. Values set on the web process take priority, matching CF.
+func (app *Application) applyWebProcess() {
+	for _, process := range app.Processes {
+		if process.Type != WebProcessType {
+			continue
+		}
+
+		if process.Command != "" {
+			app.Command = process.Command
+		}
+
+		if process.Instances != nil {
+			app.Instances = process.Instances
+		}
+
+		if process.Memory != "" {
+			app.Memory = process.Memory
+		}
+
+		if process.HealthCheckType != "" {
+			app.HealthCheckType = process.HealthCheckType
+		}
+
+		if process.HealthCheckHTTPEndpoint != "" {
+			app.HealthCheckHTTPEndpoint = process.HealthCheckHTTPEndpoint
+		}
+
+		if process.HealthCheckTimeout != 0 {
+			app.HealthCheckTimeout = process.HealthCheckTimeout
+		}
+	}
+}
+
 // Buildpack joings toegether the buildpacks in order as a CSV to be compatible
 // with buildpacks v3.
 func (app *Application) Buildpack() string {
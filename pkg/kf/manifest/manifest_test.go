@@ -62,6 +62,64 @@ applications:
 				},
 			},
 		},
+		"sidecars": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  sidecars:
+  - name: proxy
+    command: ./proxy --port 9090
+    memory: 64M
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name: "MY-APP",
+						Sidecars: []manifest.Sidecar{
+							{
+								Name:    "proxy",
+								Command: "./proxy --port 9090",
+								Memory:  "64M",
+							},
+						},
+					},
+				},
+			},
+		},
+		"processes": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  processes:
+  - type: web
+    instances: 2
+  - type: worker
+    command: bundle exec rake worker
+    instances: 3
+    memory: 256M
+    health-check-type: process
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name: "MY-APP",
+						Processes: []manifest.Process{
+							{
+								Type:      "web",
+								Instances: intPtr(2),
+							},
+							{
+								Type:            "worker",
+								Command:         "bundle exec rake worker",
+								Instances:       intPtr(3),
+								Memory:          "256M",
+								HealthCheckType: "process",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -150,6 +208,37 @@ func TestOverride(t *testing.T) {
 			override: manifest.Application{Env: map[string]string{"override": "override", "base": "override"}},
 			expected: manifest.Application{Env: map[string]string{"base": "override", "override": "override"}},
 		},
+		"web process applies to the app": {
+			base: manifest.Application{
+				Memory: "1G",
+				Processes: []manifest.Process{
+					{Type: "web", Command: "./web", Instances: intPtr(3), Memory: "512M"},
+				},
+			},
+			override: manifest.Application{},
+			expected: manifest.Application{
+				Command:   "./web",
+				Instances: intPtr(3),
+				Memory:    "512M",
+				Processes: []manifest.Process{
+					{Type: "web", Command: "./web", Instances: intPtr(3), Memory: "512M"},
+				},
+			},
+		},
+		"flags take priority over the web process": {
+			base: manifest.Application{
+				Processes: []manifest.Process{
+					{Type: "web", Instances: intPtr(3)},
+				},
+			},
+			override: manifest.Application{Instances: intPtr(5)},
+			expected: manifest.Application{
+				Instances: intPtr(5),
+				Processes: []manifest.Process{
+					{Type: "web", Instances: intPtr(3)},
+				},
+			},
+		},
 		"buildpacks are strict override": {
 			base:     manifest.Application{Buildpacks: []string{"java", "maven"}},
 			override: manifest.Application{Buildpacks: []string{"node", "npm"}},
@@ -167,6 +256,23 @@ func TestOverride(t *testing.T) {
 	}
 }
 
+func ExampleApplication_WorkerProcesses() {
+	app := manifest.Application{
+		Processes: []manifest.Process{
+			{Type: "web"},
+			{Type: "worker"},
+			{Type: "clock"},
+		},
+	}
+
+	for _, process := range app.WorkerProcesses() {
+		fmt.Println(process.Type)
+	}
+
+	// Output: worker
+	// clock
+}
+
 func ExampleApplication_Buildpack() {
 	app := manifest.Application{}
 	app.Buildpacks = []string{"java"}
@@ -178,3 +284,7 @@ func ExampleApplication_Buildpack() {
 	// Output: One: java
 	// Two: maven,java
 }
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -23,6 +23,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	services "github.com/google/kf/pkg/kf/services"
 	v1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	service_catalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	reflect "reflect"
 )
 
@@ -69,6 +70,26 @@ func (mr *FakeClientInterfaceMockRecorder) BrokerName(arg0 interface{}, arg1 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BrokerName", reflect.TypeOf((*FakeClientInterface)(nil).BrokerName), varargs...)
 }
 
+// CreateBroker mocks base method
+func (m *FakeClientInterface) CreateBroker(arg0 string, arg1 string, arg2 ...services.CreateBrokerOption) (service_catalog.Broker, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateBroker", varargs...)
+	ret0, _ := ret[0].(service_catalog.Broker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBroker indicates an expected call of CreateBroker
+func (mr *FakeClientInterfaceMockRecorder) CreateBroker(arg0 interface{}, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBroker", reflect.TypeOf((*FakeClientInterface)(nil).CreateBroker), varargs...)
+}
+
 // CreateService mocks base method
 func (m *FakeClientInterface) CreateService(arg0, arg1, arg2 string, arg3 ...services.CreateServiceOption) (*v1beta1.ServiceInstance, error) {
 	m.ctrl.T.Helper()
@@ -89,6 +110,25 @@ func (mr *FakeClientInterfaceMockRecorder) CreateService(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*FakeClientInterface)(nil).CreateService), varargs...)
 }
 
+// DeleteBroker mocks base method
+func (m *FakeClientInterface) DeleteBroker(arg0 string, arg1 ...services.DeleteBrokerOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteBroker", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBroker indicates an expected call of DeleteBroker
+func (mr *FakeClientInterfaceMockRecorder) DeleteBroker(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBroker", reflect.TypeOf((*FakeClientInterface)(nil).DeleteBroker), varargs...)
+}
+
 // DeleteService mocks base method
 func (m *FakeClientInterface) DeleteService(arg0 string, arg1 ...services.DeleteServiceOption) error {
 	m.ctrl.T.Helper()
@@ -128,6 +168,25 @@ func (mr *FakeClientInterfaceMockRecorder) GetService(arg0 interface{}, arg1 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*FakeClientInterface)(nil).GetService), varargs...)
 }
 
+// ListBrokers mocks base method
+func (m *FakeClientInterface) ListBrokers(arg0 ...services.ListBrokersOption) ([]service_catalog.Broker, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBrokers", varargs...)
+	ret0, _ := ret[0].([]service_catalog.Broker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrokers indicates an expected call of ListBrokers
+func (mr *FakeClientInterfaceMockRecorder) ListBrokers(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrokers", reflect.TypeOf((*FakeClientInterface)(nil).ListBrokers), arg0...)
+}
+
 // ListServices mocks base method
 func (m *FakeClientInterface) ListServices(arg0 ...services.ListServicesOption) (*v1beta1.ServiceInstanceList, error) {
 	m.ctrl.T.Helper()
@@ -165,3 +224,22 @@ func (mr *FakeClientInterfaceMockRecorder) Marketplace(arg0 ...interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Marketplace", reflect.TypeOf((*FakeClientInterface)(nil).Marketplace), arg0...)
 }
+
+// SyncBroker mocks base method
+func (m *FakeClientInterface) SyncBroker(arg0 string, arg1 ...services.SyncBrokerOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SyncBroker", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncBroker indicates an expected call of SyncBroker
+func (mr *FakeClientInterfaceMockRecorder) SyncBroker(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncBroker", reflect.TypeOf((*FakeClientInterface)(nil).SyncBroker), varargs...)
+}
@@ -336,3 +336,267 @@ func BrokerNameOptionDefaults() BrokerNameOptions {
 		WithBrokerNameNamespace("default"),
 	}
 }
+
+type createBrokerConfig struct {
+	// CredentialsSecret is the name of a secret in the namespace holding the broker's basic-auth username and password.
+	CredentialsSecret string
+	// Namespace is the Kubernetes namespace to use.
+	Namespace string
+	// SpaceScoped is register the broker only for the namespace rather than the whole cluster.
+	SpaceScoped bool
+}
+
+// CreateBrokerOption is a single option for configuring a createBrokerConfig
+type CreateBrokerOption func(*createBrokerConfig)
+
+// CreateBrokerOptions is a configuration set defining a createBrokerConfig
+type CreateBrokerOptions []CreateBrokerOption
+
+// toConfig applies all the options to a new createBrokerConfig and returns it.
+func (opts CreateBrokerOptions) toConfig() createBrokerConfig {
+	cfg := createBrokerConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new CreateBrokerOptions with the contents of other overriding
+// the values set in this CreateBrokerOptions.
+func (opts CreateBrokerOptions) Extend(other CreateBrokerOptions) CreateBrokerOptions {
+	var out CreateBrokerOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// CredentialsSecret returns the last set value for CredentialsSecret or the empty value
+// if not set.
+func (opts CreateBrokerOptions) CredentialsSecret() string {
+	return opts.toConfig().CredentialsSecret
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts CreateBrokerOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// SpaceScoped returns the last set value for SpaceScoped or the empty value
+// if not set.
+func (opts CreateBrokerOptions) SpaceScoped() bool {
+	return opts.toConfig().SpaceScoped
+}
+
+// WithCreateBrokerCredentialsSecret creates an Option that sets the name of a secret in the namespace holding the broker's basic-auth username and password.
+func WithCreateBrokerCredentialsSecret(val string) CreateBrokerOption {
+	return func(cfg *createBrokerConfig) {
+		cfg.CredentialsSecret = val
+	}
+}
+
+// WithCreateBrokerNamespace creates an Option that sets the Kubernetes namespace to use.
+func WithCreateBrokerNamespace(val string) CreateBrokerOption {
+	return func(cfg *createBrokerConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// WithCreateBrokerSpaceScoped creates an Option that sets register the broker only for the namespace rather than the whole cluster.
+func WithCreateBrokerSpaceScoped(val bool) CreateBrokerOption {
+	return func(cfg *createBrokerConfig) {
+		cfg.SpaceScoped = val
+	}
+}
+
+// CreateBrokerOptionDefaults gets the default values for CreateBroker.
+func CreateBrokerOptionDefaults() CreateBrokerOptions {
+	return CreateBrokerOptions{
+		WithCreateBrokerNamespace("default"),
+	}
+}
+
+type deleteBrokerConfig struct {
+	// Namespace is the Kubernetes namespace to use.
+	Namespace string
+	// SpaceScoped is the broker is registered only for the namespace rather than the whole cluster.
+	SpaceScoped bool
+}
+
+// DeleteBrokerOption is a single option for configuring a deleteBrokerConfig
+type DeleteBrokerOption func(*deleteBrokerConfig)
+
+// DeleteBrokerOptions is a configuration set defining a deleteBrokerConfig
+type DeleteBrokerOptions []DeleteBrokerOption
+
+// toConfig applies all the options to a new deleteBrokerConfig and returns it.
+func (opts DeleteBrokerOptions) toConfig() deleteBrokerConfig {
+	cfg := deleteBrokerConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new DeleteBrokerOptions with the contents of other overriding
+// the values set in this DeleteBrokerOptions.
+func (opts DeleteBrokerOptions) Extend(other DeleteBrokerOptions) DeleteBrokerOptions {
+	var out DeleteBrokerOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts DeleteBrokerOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// SpaceScoped returns the last set value for SpaceScoped or the empty value
+// if not set.
+func (opts DeleteBrokerOptions) SpaceScoped() bool {
+	return opts.toConfig().SpaceScoped
+}
+
+// WithDeleteBrokerNamespace creates an Option that sets the Kubernetes namespace to use.
+func WithDeleteBrokerNamespace(val string) DeleteBrokerOption {
+	return func(cfg *deleteBrokerConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// WithDeleteBrokerSpaceScoped creates an Option that sets the broker is registered only for the namespace rather than the whole cluster.
+func WithDeleteBrokerSpaceScoped(val bool) DeleteBrokerOption {
+	return func(cfg *deleteBrokerConfig) {
+		cfg.SpaceScoped = val
+	}
+}
+
+// DeleteBrokerOptionDefaults gets the default values for DeleteBroker.
+func DeleteBrokerOptionDefaults() DeleteBrokerOptions {
+	return DeleteBrokerOptions{
+		WithDeleteBrokerNamespace("default"),
+	}
+}
+
+type listBrokersConfig struct {
+	// Namespace is the Kubernetes namespace to use.
+	Namespace string
+}
+
+// ListBrokersOption is a single option for configuring a listBrokersConfig
+type ListBrokersOption func(*listBrokersConfig)
+
+// ListBrokersOptions is a configuration set defining a listBrokersConfig
+type ListBrokersOptions []ListBrokersOption
+
+// toConfig applies all the options to a new listBrokersConfig and returns it.
+func (opts ListBrokersOptions) toConfig() listBrokersConfig {
+	cfg := listBrokersConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new ListBrokersOptions with the contents of other overriding
+// the values set in this ListBrokersOptions.
+func (opts ListBrokersOptions) Extend(other ListBrokersOptions) ListBrokersOptions {
+	var out ListBrokersOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts ListBrokersOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// WithListBrokersNamespace creates an Option that sets the Kubernetes namespace to use.
+func WithListBrokersNamespace(val string) ListBrokersOption {
+	return func(cfg *listBrokersConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// ListBrokersOptionDefaults gets the default values for ListBrokers.
+func ListBrokersOptionDefaults() ListBrokersOptions {
+	return ListBrokersOptions{
+		WithListBrokersNamespace("default"),
+	}
+}
+
+type syncBrokerConfig struct {
+	// Namespace is the Kubernetes namespace to use.
+	Namespace string
+	// SpaceScoped is the broker is registered only for the namespace rather than the whole cluster.
+	SpaceScoped bool
+}
+
+// SyncBrokerOption is a single option for configuring a syncBrokerConfig
+type SyncBrokerOption func(*syncBrokerConfig)
+
+// SyncBrokerOptions is a configuration set defining a syncBrokerConfig
+type SyncBrokerOptions []SyncBrokerOption
+
+// toConfig applies all the options to a new syncBrokerConfig and returns it.
+func (opts SyncBrokerOptions) toConfig() syncBrokerConfig {
+	cfg := syncBrokerConfig{}
+
+	for _, v := range opts {
+		v(&cfg)
+	}
+
+	return cfg
+}
+
+// Extend creates a new SyncBrokerOptions with the contents of other overriding
+// the values set in this SyncBrokerOptions.
+func (opts SyncBrokerOptions) Extend(other SyncBrokerOptions) SyncBrokerOptions {
+	var out SyncBrokerOptions
+	out = append(out, opts...)
+	out = append(out, other...)
+	return out
+}
+
+// Namespace returns the last set value for Namespace or the empty value
+// if not set.
+func (opts SyncBrokerOptions) Namespace() string {
+	return opts.toConfig().Namespace
+}
+
+// SpaceScoped returns the last set value for SpaceScoped or the empty value
+// if not set.
+func (opts SyncBrokerOptions) SpaceScoped() bool {
+	return opts.toConfig().SpaceScoped
+}
+
+// WithSyncBrokerNamespace creates an Option that sets the Kubernetes namespace to use.
+func WithSyncBrokerNamespace(val string) SyncBrokerOption {
+	return func(cfg *syncBrokerConfig) {
+		cfg.Namespace = val
+	}
+}
+
+// WithSyncBrokerSpaceScoped creates an Option that sets the broker is registered only for the namespace rather than the whole cluster.
+func WithSyncBrokerSpaceScoped(val bool) SyncBrokerOption {
+	return func(cfg *syncBrokerConfig) {
+		cfg.SpaceScoped = val
+	}
+}
+
+// SyncBrokerOptionDefaults gets the default values for SyncBroker.
+func SyncBrokerOptionDefaults() SyncBrokerOptions {
+	return SyncBrokerOptions{
+		WithSyncBrokerNamespace("default"),
+	}
+}
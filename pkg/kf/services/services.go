@@ -48,8 +48,26 @@ type ClientInterface interface {
 
 	// BrokerName fetches the service broker name for a service.
 	BrokerName(service v1beta1.ServiceInstance, opts ...BrokerNameOption) (string, error)
+
+	// CreateBroker registers a service broker with the cluster or a single
+	// namespace.
+	CreateBroker(brokerName, url string, opts ...CreateBrokerOption) (servicecatalog.Broker, error)
+
+	// DeleteBroker removes a service broker.
+	DeleteBroker(brokerName string, opts ...DeleteBrokerOption) error
+
+	// ListBrokers lists the cluster service brokers and the service brokers
+	// in the namespace.
+	ListBrokers(opts ...ListBrokersOption) ([]servicecatalog.Broker, error)
+
+	// SyncBroker requests the broker's catalog be fetched again.
+	SyncBroker(brokerName string, opts ...SyncBrokerOption) error
 }
 
+// brokerSyncRetries is the number of times to retry requesting a catalog
+// refresh if the broker was modified concurrently.
+const brokerSyncRetries = 3
+
 // SClientFactory creates a Service Catalog client.
 type SClientFactory func(namespace string) servicecatalog.SvcatClient
 
@@ -148,3 +166,58 @@ func (c *Client) BrokerName(service v1beta1.ServiceInstance, opts ...BrokerNameO
 
 	return class.GetServiceBrokerName(), nil
 }
+
+// CreateBroker registers a service broker with the cluster or a single
+// namespace.
+func (c *Client) CreateBroker(brokerName, url string, opts ...CreateBrokerOption) (servicecatalog.Broker, error) {
+	cfg := CreateBrokerOptionDefaults().Extend(opts).toConfig()
+	svcat := c.createSvcatClient(cfg.Namespace)
+
+	// For cluster brokers, the namespace tells service catalog where the
+	// credentials secret lives.
+	return svcat.Register(brokerName, url, &servicecatalog.RegisterOptions{
+		BasicSecret: cfg.CredentialsSecret,
+		Namespace:   cfg.Namespace,
+	}, brokerScope(cfg.Namespace, cfg.SpaceScoped))
+}
+
+// DeleteBroker removes a service broker.
+func (c *Client) DeleteBroker(brokerName string, opts ...DeleteBrokerOption) error {
+	cfg := DeleteBrokerOptionDefaults().Extend(opts).toConfig()
+	svcat := c.createSvcatClient(cfg.Namespace)
+
+	return svcat.Deregister(brokerName, brokerScope(cfg.Namespace, cfg.SpaceScoped))
+}
+
+// ListBrokers lists the cluster service brokers and the service brokers in
+// the namespace.
+func (c *Client) ListBrokers(opts ...ListBrokersOption) ([]servicecatalog.Broker, error) {
+	cfg := ListBrokersOptionDefaults().Extend(opts).toConfig()
+	svcat := c.createSvcatClient(cfg.Namespace)
+
+	return svcat.RetrieveBrokers(servicecatalog.ScopeOptions{
+		Namespace: cfg.Namespace,
+		Scope:     servicecatalog.AllScope,
+	})
+}
+
+// SyncBroker requests the broker's catalog be fetched again.
+func (c *Client) SyncBroker(brokerName string, opts ...SyncBrokerOption) error {
+	cfg := SyncBrokerOptionDefaults().Extend(opts).toConfig()
+	svcat := c.createSvcatClient(cfg.Namespace)
+
+	return svcat.Sync(brokerName, *brokerScope(cfg.Namespace, cfg.SpaceScoped), brokerSyncRetries)
+}
+
+func brokerScope(namespace string, spaceScoped bool) *servicecatalog.ScopeOptions {
+	if spaceScoped {
+		return &servicecatalog.ScopeOptions{
+			Namespace: namespace,
+			Scope:     servicecatalog.NamespaceScope,
+		}
+	}
+
+	return &servicecatalog.ScopeOptions{
+		Scope: servicecatalog.ClusterScope,
+	}
+}
@@ -395,6 +395,237 @@ func TestClient_BrokerName(t *testing.T) {
 	}
 }
 
+func TestClient_CreateBroker(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Options     []CreateBrokerOption
+		RegisterErr error
+
+		ExpectScope servicecatalog.ScopeOptions
+		ExpectErr   error
+	}{
+		"cluster broker": {
+			Options: []CreateBrokerOption{
+				WithCreateBrokerNamespace("kf"),
+				WithCreateBrokerCredentialsSecret("some-secret"),
+			},
+			ExpectScope: servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope},
+		},
+		"space scoped broker": {
+			Options: []CreateBrokerOption{
+				WithCreateBrokerNamespace("some-namespace"),
+				WithCreateBrokerCredentialsSecret("some-secret"),
+				WithCreateBrokerSpaceScoped(true),
+			},
+			ExpectScope: servicecatalog.ScopeOptions{
+				Namespace: "some-namespace",
+				Scope:     servicecatalog.NamespaceScope,
+			},
+		},
+		"error in register": {
+			RegisterErr: errors.New("register-err"),
+			ExpectErr:   errors.New("register-err"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			expectedCfg := CreateBrokerOptionDefaults().Extend(tc.Options).toConfig()
+			fakeClient := &servicecatalogfakes.FakeSvcatClient{}
+
+			fakeClient.RegisterStub = func(brokerName, url string, opts *servicecatalog.RegisterOptions, scopeOpts *servicecatalog.ScopeOptions) (servicecatalog.Broker, error) {
+				testutil.AssertEqual(t, "brokerName", "some-broker", brokerName)
+				testutil.AssertEqual(t, "url", "https://broker.example.com", url)
+				testutil.AssertEqual(t, "opts.BasicSecret", expectedCfg.CredentialsSecret, opts.BasicSecret)
+				testutil.AssertEqual(t, "opts.Namespace", expectedCfg.Namespace, opts.Namespace)
+				if tc.RegisterErr == nil {
+					testutil.AssertEqual(t, "scopeOpts", tc.ExpectScope, *scopeOpts)
+				}
+
+				return &v1beta1.ClusterServiceBroker{}, tc.RegisterErr
+			}
+
+			client := NewClient(func(ns string) servicecatalog.SvcatClient {
+				testutil.AssertEqual(t, "namespace", expectedCfg.Namespace, ns)
+				return fakeClient
+			})
+
+			_, actualErr := client.CreateBroker("some-broker", "https://broker.example.com", tc.Options...)
+			if tc.ExpectErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectErr, actualErr)
+				return
+			}
+
+			testutil.AssertEqual(t, "calls to register", 1, fakeClient.RegisterCallCount())
+		})
+	}
+}
+
+func TestClient_DeleteBroker(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Options   []DeleteBrokerOption
+		ServerErr error
+
+		ExpectScope servicecatalog.ScopeOptions
+		ExpectErr   error
+	}{
+		"cluster broker": {
+			ExpectScope: servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope},
+		},
+		"space scoped broker": {
+			Options: []DeleteBrokerOption{
+				WithDeleteBrokerNamespace("some-namespace"),
+				WithDeleteBrokerSpaceScoped(true),
+			},
+			ExpectScope: servicecatalog.ScopeOptions{
+				Namespace: "some-namespace",
+				Scope:     servicecatalog.NamespaceScope,
+			},
+		},
+		"error in deregister": {
+			ServerErr:   errors.New("deregister-err"),
+			ExpectScope: servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope},
+			ExpectErr:   errors.New("deregister-err"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			fakeClient := &servicecatalogfakes.FakeSvcatClient{}
+
+			fakeClient.DeregisterStub = func(brokerName string, scopeOpts *servicecatalog.ScopeOptions) error {
+				testutil.AssertEqual(t, "brokerName", "some-broker", brokerName)
+				testutil.AssertEqual(t, "scopeOpts", tc.ExpectScope, *scopeOpts)
+
+				return tc.ServerErr
+			}
+
+			client := NewClient(func(ns string) servicecatalog.SvcatClient {
+				return fakeClient
+			})
+
+			actualErr := client.DeleteBroker("some-broker", tc.Options...)
+			if tc.ExpectErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectErr, actualErr)
+				return
+			}
+
+			testutil.AssertEqual(t, "calls to deregister", 1, fakeClient.DeregisterCallCount())
+		})
+	}
+}
+
+func TestClient_ListBrokers(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Options   []ListBrokersOption
+		ServerErr error
+
+		ExpectErr error
+	}{
+		"default values": {},
+		"custom values": {
+			Options: []ListBrokersOption{
+				WithListBrokersNamespace("custom-namespace"),
+			},
+		},
+		"error in retrieve": {
+			ServerErr: errors.New("retrieve-err"),
+			ExpectErr: errors.New("retrieve-err"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			expectedCfg := ListBrokersOptionDefaults().Extend(tc.Options).toConfig()
+			fakeClient := &servicecatalogfakes.FakeSvcatClient{}
+			brokers := []servicecatalog.Broker{&v1beta1.ClusterServiceBroker{}}
+
+			fakeClient.RetrieveBrokersStub = func(opts servicecatalog.ScopeOptions) ([]servicecatalog.Broker, error) {
+				testutil.AssertEqual(t, "opts", servicecatalog.ScopeOptions{
+					Namespace: expectedCfg.Namespace,
+					Scope:     servicecatalog.AllScope,
+				}, opts)
+
+				return brokers, tc.ServerErr
+			}
+
+			client := NewClient(func(ns string) servicecatalog.SvcatClient {
+				testutil.AssertEqual(t, "namespace", expectedCfg.Namespace, ns)
+				return fakeClient
+			})
+
+			actual, actualErr := client.ListBrokers(tc.Options...)
+			if tc.ExpectErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectErr, actualErr)
+				return
+			}
+
+			testutil.AssertEqual(t, "brokers", brokers, actual)
+		})
+	}
+}
+
+func TestClient_SyncBroker(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Options   []SyncBrokerOption
+		ServerErr error
+
+		ExpectScope servicecatalog.ScopeOptions
+		ExpectErr   error
+	}{
+		"cluster broker": {
+			ExpectScope: servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope},
+		},
+		"space scoped broker": {
+			Options: []SyncBrokerOption{
+				WithSyncBrokerNamespace("some-namespace"),
+				WithSyncBrokerSpaceScoped(true),
+			},
+			ExpectScope: servicecatalog.ScopeOptions{
+				Namespace: "some-namespace",
+				Scope:     servicecatalog.NamespaceScope,
+			},
+		},
+		"error in sync": {
+			ServerErr:   errors.New("sync-err"),
+			ExpectScope: servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope},
+			ExpectErr:   errors.New("sync-err"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			fakeClient := &servicecatalogfakes.FakeSvcatClient{}
+
+			fakeClient.SyncStub = func(brokerName string, scopeOpts servicecatalog.ScopeOptions, retries int) error {
+				testutil.AssertEqual(t, "brokerName", "some-broker", brokerName)
+				testutil.AssertEqual(t, "scopeOpts", tc.ExpectScope, scopeOpts)
+
+				return tc.ServerErr
+			}
+
+			client := NewClient(func(ns string) servicecatalog.SvcatClient {
+				return fakeClient
+			})
+
+			actualErr := client.SyncBroker("some-broker", tc.Options...)
+			if tc.ExpectErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectErr, actualErr)
+				return
+			}
+
+			testutil.AssertEqual(t, "calls to sync", 1, fakeClient.SyncCallCount())
+		})
+	}
+}
+
 // fakeClass implements servicecatalog.Class. There isn't a fake provided.
 type fakeClass struct {
 	servicecatalog.Class
@@ -0,0 +1,51 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	cv1alpha1 "github.com/google/kf/pkg/client/clientset/versioned/typed/kf/v1alpha1"
+)
+
+// ClientExtension holds additional functions that should be exposed by Client.
+type ClientExtension interface {
+	// Terminate cancels the Task with the given name. Tasks that have already
+	// completed are unaffected.
+	Terminate(namespace, name string) error
+}
+
+type tasksClient struct {
+	coreClient
+}
+
+// NewClient creates a new Task client.
+func NewClient(kclient cv1alpha1.TasksGetter) Client {
+	return &tasksClient{
+		coreClient: coreClient{
+			kclient:             kclient,
+			upsertMutate:        MutatorList{},
+			membershipValidator: AllPredicate(),
+		},
+	}
+}
+
+// Terminate cancels the Task with the given name. Tasks that have already
+// completed are unaffected.
+func (c *tasksClient) Terminate(namespace, name string) error {
+	return c.coreClient.Transform(namespace, name, func(task *v1alpha1.Task) error {
+		task.Spec.Terminated = true
+		return nil
+	})
+}
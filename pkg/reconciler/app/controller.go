@@ -19,20 +19,30 @@ import (
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	appinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/app"
+	domaininformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/domain"
 	routeinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/route"
 	routeclaiminformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/routeclaim"
 	sourceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/source"
 	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
+	managedpodinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/managedpod"
 	servicecatalogclient "github.com/google/kf/pkg/client/servicecatalog/injection/client"
 	servicebindinginformer "github.com/google/kf/pkg/client/servicecatalog/injection/informers/servicecatalog/v1beta1/servicebinding"
 	serviceinstanceinformer "github.com/google/kf/pkg/client/servicecatalog/injection/informers/servicecatalog/v1beta1/serviceinstance"
+	"github.com/google/kf/pkg/kf/cfutil"
 	"github.com/google/kf/pkg/reconciler"
+	kconfigurationinformer "github.com/knative/serving/pkg/client/injection/informers/serving/v1alpha1/configuration"
 	krevisioninformer "github.com/knative/serving/pkg/client/injection/informers/serving/v1alpha1/revision"
 	kserviceinformer "github.com/knative/serving/pkg/client/injection/informers/serving/v1alpha1/service"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	deploymentinformer "knative.dev/pkg/injection/informers/kubeinformers/appsv1/deployment"
 	secretinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/secret"
+	serviceinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/service"
 )
 
 // NewController creates a new controller capable of reconciling Kf Routes.
@@ -42,34 +52,45 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	// Get informers off context
 	knativeServiceInformer := kserviceinformer.Get(ctx)
 	knativeRevisionInformer := krevisioninformer.Get(ctx)
+	knativeConfigurationInformer := kconfigurationinformer.Get(ctx)
 	sourceInformer := sourceinformer.Get(ctx)
 	appInformer := appinformer.Get(ctx)
 	spaceInformer := spaceinformer.Get(ctx)
+	domainInformer := domaininformer.Get(ctx)
 	routeInformer := routeinformer.Get(ctx)
 	routeClaimInformer := routeclaiminformer.Get(ctx)
 	serviceBindingInformer := servicebindinginformer.Get(ctx)
 	serviceInstanceInformer := serviceinstanceinformer.Get(ctx)
 	secretInformer := secretinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
+	deploymentInformer := deploymentinformer.Get(ctx)
+	podInformer := managedpodinformer.Get(ctx)
 
 	serviceCatalogClient := servicecatalogclient.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
-		Base:                  reconciler.NewBase(ctx, cmw),
-		serviceCatalogClient:  serviceCatalogClient,
-		knativeServiceLister:  knativeServiceInformer.Lister(),
-		knativeRevisionLister: knativeRevisionInformer.Lister(),
-		sourceLister:          sourceInformer.Lister(),
-		appLister:             appInformer.Lister(),
-		secretLister:          secretInformer.Lister(),
-		spaceLister:           spaceInformer.Lister(),
-		routeLister:           routeInformer.Lister(),
-		routeClaimLister:      routeClaimInformer.Lister(),
-		serviceBindingLister:  serviceBindingInformer.Lister(),
-		serviceInstanceLister: serviceInstanceInformer.Lister(),
+		Base:                       reconciler.NewBase(ctx, cmw),
+		serviceCatalogClient:       serviceCatalogClient,
+		knativeServiceLister:       knativeServiceInformer.Lister(),
+		knativeRevisionLister:      knativeRevisionInformer.Lister(),
+		knativeConfigurationLister: knativeConfigurationInformer.Lister(),
+		sourceLister:               sourceInformer.Lister(),
+		appLister:                  appInformer.Lister(),
+		secretLister:               secretInformer.Lister(),
+		serviceLister:              serviceInformer.Lister(),
+		deploymentLister:           deploymentInformer.Lister(),
+		podLister:                  podInformer.Lister(),
+		spaceLister:                spaceInformer.Lister(),
+		domainLister:               domainInformer.Lister(),
+		routeLister:                routeInformer.Lister(),
+		routeClaimLister:           routeClaimInformer.Lister(),
+		serviceBindingLister:       serviceBindingInformer.Lister(),
+		serviceInstanceLister:      serviceInstanceInformer.Lister(),
 	}
 
 	impl := controller.NewImpl(c, logger, "Apps")
+	c.enqueueAfter = impl.EnqueueAfter
 
 	logger.Info("Setting up event handlers")
 
@@ -86,10 +107,141 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	serviceBindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	// Watch for changes to the Pods running Apps so their instances are kept
+	// up to date. The Pods are owned by Knative so they're matched by label,
+	// the informer only watches Pods managed by Kf.
+	podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterAppServerPods,
+		Handler:    controller.HandleAll(EnqueueAppOfPod(impl)),
+	})
+
+	// Watch for changes to the Secrets holding service credentials so Apps
+	// pick up rotated credentials.
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(EnqueueAppsOfServiceSecret(logger, impl, c)))
+
+	// Watch for changes to Domains because they decide which routes Apps
+	// can use.
+	domainInformer.Informer().AddEventHandler(controller.HandleAll(EnqueueAppsOfDomain(logger, impl, c)))
+
 	return impl
 }
+
+// EnqueueAppsOfDomain will Enqueue a key for each App with a route on the
+// Domain.
+func EnqueueAppsOfDomain(
+	logger *zap.SugaredLogger,
+	c *controller.Impl,
+	r *Reconciler,
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		domain, ok := obj.(*v1alpha1.Domain)
+		if !ok {
+			return
+		}
+
+		apps, err := r.appLister.List(labels.Everything())
+		if err != nil {
+			logger.Warnf("failed to list apps: %s", err)
+			return
+		}
+
+		for _, app := range apps {
+			for _, route := range app.Spec.Routes {
+				if route.Domain == domain.Name {
+					c.Enqueue(app)
+					break
+				}
+			}
+		}
+	}
+}
+
+// EnqueueAppsOfServiceSecret will Enqueue a key for each App that gets its
+// service credentials from the Secret. Secrets of brokered services belong to
+// a ServiceBinding owned by the App, Secrets of user-provided services are
+// found through the Apps' bindings.
+func EnqueueAppsOfServiceSecret(
+	logger *zap.SugaredLogger,
+	c *controller.Impl,
+	r *Reconciler,
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+
+		if instanceName, ok := secret.Labels[cfutil.UserProvidedServiceLabel]; ok {
+			apps, err := r.appLister.Apps(secret.Namespace).List(labels.Everything())
+			if err != nil {
+				logger.Warnf("failed to list apps: %s", err)
+				return
+			}
+
+			for _, app := range apps {
+				for _, binding := range app.Spec.ServiceBindings {
+					if binding.Instance == instanceName {
+						c.Enqueue(app)
+						break
+					}
+				}
+			}
+			return
+		}
+
+		owner := metav1.GetControllerOf(secret)
+		if owner == nil || owner.Kind != "ServiceBinding" {
+			return
+		}
+
+		binding, err := r.serviceBindingLister.ServiceBindings(secret.Namespace).Get(owner.Name)
+		if err != nil {
+			logger.Warnf("failed to get service binding: %s", err)
+			return
+		}
+
+		if app := metav1.GetControllerOf(binding); app != nil && app.Kind == "App" {
+			c.EnqueueKey(secret.Namespace + "/" + app.Name)
+		}
+	}
+}
+
+// FilterAppServerPods returns true for Pods running the web process of an
+// App.
+func FilterAppServerPods(obj interface{}) bool {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+
+	return pod.Labels[v1alpha1.ManagedByLabel] == "kf" &&
+		pod.Labels[v1alpha1.ComponentLabel] == "app-server" &&
+		pod.Labels[v1alpha1.NameLabel] != ""
+}
+
+// EnqueueAppOfPod will Enqueue a key for the App the Pod runs.
+func EnqueueAppOfPod(c *controller.Impl) func(obj interface{}) {
+	return func(obj interface{}) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return
+		}
+
+		c.EnqueueKey(pod.Namespace + "/" + pod.Labels[v1alpha1.NameLabel])
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
@@ -35,13 +36,17 @@ import (
 	servinglisters "github.com/knative/serving/pkg/client/listers/serving/v1alpha1"
 	servicecatalogv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	v1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	k8sappsv1 "k8s.io/kubernetes/pkg/apis/apps/v1"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/logging"
@@ -54,17 +59,26 @@ var (
 type Reconciler struct {
 	*reconciler.Base
 
-	serviceCatalogClient  servicecatalogclient.Interface
-	knativeServiceLister  servinglisters.ServiceLister
-	knativeRevisionLister servinglisters.RevisionLister
-	sourceLister          kflisters.SourceLister
-	appLister             kflisters.AppLister
-	spaceLister           kflisters.SpaceLister
-	routeLister           kflisters.RouteLister
-	secretLister          v1listers.SecretLister
-	routeClaimLister      kflisters.RouteClaimLister
-	serviceBindingLister  servicecataloglisters.ServiceBindingLister
-	serviceInstanceLister servicecataloglisters.ServiceInstanceLister
+	serviceCatalogClient       servicecatalogclient.Interface
+	knativeServiceLister       servinglisters.ServiceLister
+	knativeRevisionLister      servinglisters.RevisionLister
+	knativeConfigurationLister servinglisters.ConfigurationLister
+	sourceLister               kflisters.SourceLister
+	appLister                  kflisters.AppLister
+	spaceLister                kflisters.SpaceLister
+	domainLister               kflisters.DomainLister
+	routeLister                kflisters.RouteLister
+	secretLister               v1listers.SecretLister
+	serviceLister              v1listers.ServiceLister
+	deploymentLister           appsv1listers.DeploymentLister
+	podLister                  v1listers.PodLister
+	routeClaimLister           kflisters.RouteClaimLister
+	serviceBindingLister       servicecataloglisters.ServiceBindingLister
+	serviceInstanceLister      servicecataloglisters.ServiceInstanceLister
+
+	// enqueueAfter schedules an App to be reconciled again later, it's used to
+	// step through rollouts.
+	enqueueAfter func(obj interface{}, after time.Duration)
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -139,6 +153,13 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		app.Status.MarkSpaceUnhealthy("GettingSpace", err.Error())
 		return err
 	}
+
+	domains, err := r.domainLister.List(labels.Everything())
+	if err != nil {
+		app.Status.MarkSpaceUnhealthy("ListingDomains", err.Error())
+		return err
+	}
+	space = space.WithDomains(domains)
 	app.Status.MarkSpaceHealthy()
 
 	// reconcile source
@@ -190,6 +211,21 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		}
 		logger.Debug("reconciling Service Bindings")
 
+		// User-provided services don't go through the service catalog, their
+		// credentials are injected directly into VCAP_SERVICES.
+		var brokeredServiceBindings []servicecatalogv1beta1.ServiceBinding
+		for _, desired := range desiredServiceBindings {
+			userProvided, err := r.isUserProvidedService(desired.Namespace, desired.Spec.InstanceRef.Name)
+			if err != nil {
+				return condition.MarkReconciliationError("checking for user-provided services", err)
+			}
+
+			if !userProvided {
+				brokeredServiceBindings = append(brokeredServiceBindings, desired)
+			}
+		}
+		desiredServiceBindings = brokeredServiceBindings
+
 		// Delete Stale Service Bindings
 		existing, err := r.serviceBindingLister.
 			ServiceBindings(app.GetNamespace()).
@@ -271,8 +307,22 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 			return condition.MarkReconciliationError("updating existing", err)
 		}
 		app.Status.PropagateEnvVarSecretStatus(actual)
+
+		// Running instances only read the secret when they start so they're
+		// rolled when the credentials change.
+		previousHash := app.Status.ServiceCredentials.Hash
+		app.Status.PropagateServiceCredentialsHash(resources.ServiceCredentialsHash(actual))
+		if previousHash != "" && previousHash != app.Status.ServiceCredentials.Hash {
+			logger.Info("Service credentials changed, rolling instances")
+			r.Recorder.Event(app, v1.EventTypeNormal, v1alpha1.ServiceCredentialsChangedReason,
+				"Service credentials changed, rolling instances")
+		}
 	}
 
+	// currentRevision is the revision of the App's current template, it's
+	// empty until Knative creates it.
+	var currentRevision string
+
 	// reconcile serving
 	{
 		logger.Debug("reconciling Knative Serving")
@@ -320,6 +370,129 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		}
 
 		app.Status.PropagateKnativeServiceStatus(actual)
+
+		// Move traffic to the latest revision according to the strategy. If
+		// traffic moves, the status update queues another reconcile that
+		// writes it to the service.
+		{
+			logger.Debug("reconciling Rollout")
+			rolloutCondition := app.Status.RolloutCondition()
+
+			var latest *serving.Revision
+			if actual != nil && actual.Status.LatestCreatedRevisionName != "" {
+				latest, err = r.knativeRevisionLister.
+					Revisions(actual.GetNamespace()).
+					Get(actual.Status.LatestCreatedRevisionName)
+				if apierrs.IsNotFound(err) {
+					latest = nil
+				} else if err != nil {
+					return rolloutCondition.MarkReconciliationError("getting latest revision", err)
+				}
+			}
+
+			currentRevision, err = r.currentRevisionName(actual)
+			if err != nil {
+				return rolloutCondition.MarkReconciliationError("getting current revision", err)
+			}
+
+			if app.Spec.Instances.Stopped {
+				actual = nil
+			}
+
+			if requeue := app.Status.PropagateRolloutStatus(app.Spec.Strategy, actual, latest, time.Now()); requeue > 0 {
+				r.enqueueAfter(app, requeue)
+			}
+
+			// Record the revision in the App's history once it's stable so it
+			// can be rolled back to. The revision only matches the App's
+			// template if the service's latest generation was observed.
+			if actual != nil &&
+				latest != nil &&
+				latest.Name == app.Status.StableRevisionName &&
+				actual.Generation == actual.Status.ObservedGeneration {
+				app.Status.RecordRevision(resources.MakeAppRevision(app, latest), app.Spec.HistoryLimit())
+			}
+		}
+	}
+
+	// Instances
+	{
+		logger.Debug("reconciling Instances")
+		pods, err := r.podLister.
+			Pods(app.GetNamespace()).
+			List(labels.SelectorFromSet(app.ComponentLabels("app-server")))
+		if err != nil {
+			return err
+		}
+
+		app.Status.PropagateInstanceStatus(app.Spec.Instances, pods)
+
+		if err := r.reconcileInstancesHealth(app, pods, currentRevision); err != nil {
+			return err
+		}
+	}
+
+	// reconcile processes
+	{
+		logger.Debug("reconciling Processes")
+		condition := app.Status.ProcessesCondition()
+		desiredDeployments, err := resources.MakeDeployments(app, space)
+		if err != nil {
+			return condition.MarkTemplateError(err)
+		}
+
+		// Delete Stale Deployments
+		existing, err := r.deploymentLister.
+			Deployments(app.GetNamespace()).
+			List(resources.MakeDeploymentAppSelector(app))
+		if err != nil {
+			return condition.MarkReconciliationError("scanning for stale processes", err)
+		}
+
+		desiredNames := make(map[string]bool)
+		for _, desired := range desiredDeployments {
+			desiredNames[desired.Name] = true
+		}
+
+		for _, deployment := range existing {
+			if desiredNames[deployment.Name] || !metav1.IsControlledBy(deployment, app) {
+				continue
+			}
+
+			// Not found in desired, must be stale.
+			if err := r.KubeClientSet.
+				AppsV1().
+				Deployments(deployment.Namespace).
+				Delete(deployment.Name, &metav1.DeleteOptions{}); err != nil {
+				return condition.MarkReconciliationError("deleting existing process", err)
+			}
+		}
+
+		var actualDeployments []*appsv1.Deployment
+		for _, desired := range desiredDeployments {
+			actual, err := r.deploymentLister.
+				Deployments(desired.GetNamespace()).
+				Get(desired.Name)
+			if apierrs.IsNotFound(err) {
+				// Deployment doesn't exist, make one.
+				actual, err = r.KubeClientSet.
+					AppsV1().
+					Deployments(desired.GetNamespace()).
+					Create(&desired)
+				if err != nil {
+					return condition.MarkReconciliationError("creating", err)
+				}
+			} else if err != nil {
+				return condition.MarkReconciliationError("getting latest", err)
+			} else if !metav1.IsControlledBy(actual, app) {
+				return condition.MarkChildNotOwned(desired.Name)
+			} else if actual, err = r.reconcileDeployment(&desired, actual); err != nil {
+				return condition.MarkReconciliationError("updating existing", err)
+			}
+			actualDeployments = append(actualDeployments, actual)
+		}
+
+		app.Status.PropagateDeploymentsStatus(actualDeployments)
 	}
 
 	// Routes and RouteClaims
@@ -378,6 +551,42 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		}
 	}
 
+	// TCP Service reconciler
+	{
+		logger.Debug("reconciling TCP Service")
+		desired := resources.MakeTCPService(app)
+
+		actual, err := r.serviceLister.
+			Services(desired.GetNamespace()).
+			Get(desired.Name)
+		if apierrs.IsNotFound(err) {
+			if resources.HasTCPRoutes(app) {
+				// Service doesn't exist, make one.
+				_, err = r.KubeClientSet.
+					CoreV1().
+					Services(desired.GetNamespace()).
+					Create(desired)
+				if err != nil {
+					return condition.MarkReconciliationError("creating", err)
+				}
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if !metav1.IsControlledBy(actual, app) {
+			return condition.MarkChildNotOwned(desired.Name)
+		} else if !resources.HasTCPRoutes(app) {
+			// The last TCP route was unmapped.
+			if err := r.KubeClientSet.
+				CoreV1().
+				Services(desired.GetNamespace()).
+				Delete(desired.Name, &metav1.DeleteOptions{}); err != nil {
+				return condition.MarkReconciliationError("deleting existing TCP service", err)
+			}
+		} else if _, err = r.reconcileTCPService(desired, actual); err != nil {
+			return condition.MarkReconciliationError("updating existing", err)
+		}
+	}
+
 	// RouteClaim reconciler
 	{
 		logger.Debug("reconciling Route Claims")
@@ -406,6 +615,10 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 	// Making it to the bottom of the reconciler means we've synchronized.
 	app.Status.ObservedGeneration = app.Generation
 
+	if err := r.gcSources(ctx, app); err != nil {
+		return err
+	}
+
 	return r.gcRevisions(ctx, app)
 }
 
@@ -438,6 +651,62 @@ func (r *Reconciler) reconcileKnativeService(desired, actual *serving.Service) (
 	return r.ServingClientSet.ServingV1alpha1().Services(existing.Namespace).Update(existing)
 }
 
+func (r *Reconciler) reconcileDeployment(desired, actual *appsv1.Deployment) (*appsv1.Deployment, error) {
+	// The API server fills in the fields kf leaves empty (e.g. the strategy
+	// and the containers' termination message path), so the same defaults
+	// are applied to a copy before comparing. Otherwise every reconcile
+	// would update the Deployment.
+	defaulted := desired.DeepCopy()
+	k8sappsv1.SetObjectDefaults_Deployment(defaulted)
+
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(defaulted.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff deployment: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.KubeClientSet.AppsV1().Deployments(existing.Namespace).Update(existing)
+}
+
+func (r *Reconciler) reconcileTCPService(desired, actual *v1.Service) (*v1.Service, error) {
+	// The ClusterIP is assigned by the cluster and can't change, so it's
+	// kept from the existing Service.
+	desired = desired.DeepCopy()
+	desired.Spec.ClusterIP = actual.Spec.ClusterIP
+
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff service: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.KubeClientSet.CoreV1().Services(existing.Namespace).Update(existing)
+}
+
 func (r *Reconciler) reconcileRoute(desired, actual *v1alpha1.Route) (*v1alpha1.Route, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
@@ -464,6 +733,13 @@ func (r *Reconciler) reconcileRoute(desired, actual *v1alpha1.Route) (*v1alpha1.
 }
 
 func (r *Reconciler) reconcileRouteClaim(desired, actual *v1alpha1.RouteClaim) (*v1alpha1.RouteClaim, error) {
+	// Route services, HTTPS redirects and traffic policies are set on the
+	// claim by users rather than the App, so keep whatever is there.
+	desired = desired.DeepCopy()
+	desired.Spec.RouteService = actual.Spec.RouteService
+	desired.Spec.HTTPSRedirect = actual.Spec.HTTPSRedirect
+	desired.Spec.TrafficPolicy = actual.Spec.TrafficPolicy
+
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
 	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
@@ -510,6 +786,22 @@ func (r *Reconciler) reconcileSecret(desired, actual *v1.Secret) (*v1.Secret, er
 	return r.KubeClientSet.CoreV1().Secrets(existing.Namespace).Update(existing)
 }
 
+// isUserProvidedService checks if the named service instance is backed by a
+// user-provided service secret.
+func (r *Reconciler) isUserProvidedService(namespace, instanceName string) (bool, error) {
+	secret, err := r.secretLister.
+		Secrets(namespace).
+		Get(cfutil.UserProvidedServiceSecretName(instanceName))
+	switch {
+	case apierrs.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return cfutil.IsUserProvidedServiceSecret(secret), nil
+	}
+}
+
 func (r *Reconciler) reconcileServiceBinding(desired, actual *servicecatalogv1beta1.ServiceBinding) (*servicecatalogv1beta1.ServiceBinding, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
@@ -554,10 +846,38 @@ func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.App) (*
 	return r.KfClientSet.KfV1alpha1().Apps(existing.GetNamespace()).UpdateStatus(existing)
 }
 
+// gcSources deletes the Sources that are no longer needed by the App. Sources
+// of revisions in the App's history are kept so the history can show where
+// each image came from.
+func (r *Reconciler) gcSources(ctx context.Context, app *v1alpha1.App) error {
+	logger := logging.FromContext(ctx)
+
+	sources, err := r.sourceLister.
+		Sources(app.Namespace).
+		List(resources.MakeSourceAppSelector(app.Name))
+	if err != nil {
+		return err
+	}
+
+	for _, source := range resources.StaleSources(app, sources) {
+		logger.Infof("Garbage collecting Source %s...", source.Name)
+		if err := r.KfClientSet.
+			KfV1alpha1().
+			Sources(source.Namespace).
+			Delete(source.Name, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // gcRevisions is necessary because Knative won't scale down revisions
 // that have a `minScale` greater than 0. Therefore we are going to delete the
 // older revisions. The revisions are keeping pods around when app has been
-// scaled up. Therefore, if we don't GC the revisions, we leak pods.
+// scaled up. Therefore, if we don't GC the revisions, we leak pods. Old
+// revisions can still be rolled back to using the App's history, which
+// records their image and template.
 // TODO: Reevaluate once https://github.com/knative/serving/issues/4183 is
 // resolved.
 func (r *Reconciler) gcRevisions(ctx context.Context, app *v1alpha1.App) error {
@@ -604,6 +924,11 @@ func (r *Reconciler) gcRevisions(ctx context.Context, app *v1alpha1.App) error {
 
 	// delete everything after the latest generation
 	for _, rev := range revs[1:] {
+		// The stable revision keeps serving traffic during a rollout.
+		if rev.Name == app.Status.StableRevisionName {
+			continue
+		}
+
 		logger.Infof("Garbage collecting Revision %s...", rev.Name)
 		if err := revisionClient.Delete(rev.Name, &metav1.DeleteOptions{}); err != nil {
 			return err
@@ -611,3 +936,79 @@ func (r *Reconciler) gcRevisions(ctx context.Context, app *v1alpha1.App) error {
 	}
 	return nil
 }
+
+// currentRevisionName returns the revision Knative created for the
+// service's current template. It's empty while the service or its
+// configuration haven't observed the latest change, their
+// LatestCreatedRevisionName still names the previous revision then.
+func (r *Reconciler) currentRevisionName(service *serving.Service) (string, error) {
+	if service == nil || service.Generation != service.Status.ObservedGeneration {
+		return "", nil
+	}
+
+	config, err := r.knativeConfigurationLister.
+		Configurations(service.GetNamespace()).
+		Get(service.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	case config.Generation != config.Status.ObservedGeneration:
+		return "", nil
+	}
+
+	return config.Status.LatestCreatedRevisionName, nil
+}
+
+// reconcileInstancesHealth records why the instances of the App's current
+// revision are failing, if they are. Instances of older revisions aren't
+// judged, so a push that fixes a crash isn't failed by the crashing
+// instances it replaces. Events are only read while an instance is pending
+// so healthy Apps don't cost any API calls.
+func (r *Reconciler) reconcileInstancesHealth(app *v1alpha1.App, pods []*v1.Pod, currentRevision string) error {
+	if app.Spec.Instances.Stopped {
+		app.Status.PropagateInstancesHealth(nil)
+		return nil
+	}
+
+	if currentRevision == "" {
+		app.Status.MarkInstancesHealthUnknown()
+		return nil
+	}
+
+	var revisionPods []*v1.Pod
+	for _, pod := range pods {
+		if pod.Labels["serving.knative.dev/revision"] == currentRevision {
+			revisionPods = append(revisionPods, pod)
+		}
+	}
+
+	// Events are listed per Pod so the API server only returns the Events
+	// of the App's own instances.
+	var events []v1.Event
+	for _, pod := range revisionPods {
+		if pod.Status.Phase != v1.PodPending {
+			continue
+		}
+
+		list, err := r.KubeClientSet.
+			CoreV1().
+			Events(pod.Namespace).
+			List(metav1.ListOptions{
+				FieldSelector: fields.Set{
+					"involvedObject.kind": "Pod",
+					"involvedObject.name": pod.Name,
+					"involvedObject.uid":  string(pod.UID),
+					"type":                v1.EventTypeWarning,
+				}.String(),
+			})
+		if err != nil {
+			return err
+		}
+		events = append(events, list.Items...)
+	}
+
+	app.Status.PropagateInstancesHealth(v1alpha1.DiagnoseInstances(revisionPods, events))
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/internal/envutil"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/knative/serving/pkg/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// ProcessContainerName is the name of the container running a process.
+	// It matches the name Knative gives the App's container so logs can be
+	// read the same way.
+	ProcessContainerName = "user-container"
+
+	// processPort is the port processes are told to listen on through the PORT
+	// environment variable if they need to.
+	processPort = 8080
+)
+
+// DeploymentName gets the name of the Deployment running a process of the
+// App.
+func DeploymentName(app *v1alpha1.App, processType string) string {
+	return fmt.Sprintf("%s-%s", app.Name, processType)
+}
+
+// MakeDeploymentAppSelector creates a labels.Selector for listing all the
+// Deployments running processes for the App.
+func MakeDeploymentAppSelector(app *v1alpha1.App) labels.Selector {
+	return labels.NewSelector().Add(
+		mustRequirement(v1alpha1.NameLabel, selection.Equals, app.Name),
+		mustRequirement(v1alpha1.ComponentLabel, selection.Equals, "process"),
+	)
+}
+
+// MakeDeployments creates a Deployment for each of the App's additional
+// processes. The Deployments run the App's latest image without routes.
+func MakeDeployments(
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+) ([]appsv1.Deployment, error) {
+	var deployments []appsv1.Deployment
+	for _, process := range app.Spec.Processes {
+		deployment, err := MakeDeployment(app, space, &process)
+		if err != nil {
+			return nil, err
+		}
+
+		deployments = append(deployments, *deployment)
+	}
+
+	return deployments, nil
+}
+
+// MakeDeployment creates a Deployment for one of the App's processes.
+func MakeDeployment(
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	process *v1alpha1.AppSpecProcess,
+) (*appsv1.Deployment, error) {
+
+	image := app.Status.Image
+	if image == "" {
+		return nil, errors.New("waiting for source image in latestReadySource")
+	}
+
+	// don't modify the spec on the app
+	podSpec := app.Spec.Template.Spec.DeepCopy()
+
+	// At this point in the lifecycle there should be exactly one container
+	// if the webhhook is working but create one to avoid panics just in case.
+	if len(podSpec.Containers) == 0 {
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+	}
+
+	container := &podSpec.Containers[0]
+	container.Name = ProcessContainerName
+	container.Image = image
+
+	if process.Command != "" {
+		// The command is passed as a single argument so images built with
+		// buildpacks run it through their launcher.
+		container.Args = []string{process.Command}
+	}
+
+	if process.Memory != nil {
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = corev1.ResourceList{}
+		}
+		container.Resources.Requests[corev1.ResourceMemory] = *process.Memory
+	}
+
+	// Processes don't receive traffic so they don't get the web process's
+	// ports or port based health check.
+	container.Ports = nil
+	container.LivenessProbe = nil
+	container.ReadinessProbe = makeProcessProbe(process.HealthCheck)
+
+	// Execution environment variables come before others because they're built
+	// to be overridden.
+	container.Env = append(space.Spec.Execution.Env, container.Env...)
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "PORT",
+		Value: fmt.Sprintf("%d", processPort),
+	})
+	container.Env = envutil.DeduplicateEnvVars(container.Env)
+
+	// The instance's variables reference each other so
+	// they're added after the variables are sorted.
+	container.Env = append(container.Env, cfutil.InstanceEnv()...)
+
+	// Inject VCAP env vars from secret
+	container.EnvFrom = []corev1.EnvFromSource{
+		{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: KfInjectedEnvSecretName(app),
+				},
+			},
+		},
+	}
+
+	selector := map[string]string{
+		v1alpha1.NameLabel:        app.Name,
+		v1alpha1.ProcessTypeLabel: process.Type,
+	}
+
+	podLabels := resources.UnionMaps(app.ComponentLabels("process"), selector)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeploymentName(app, process.Type),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: resources.UnionMaps(app.GetLabels(), podLabels),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: processReplicas(app, process),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+					Annotations: resources.UnionMaps(
+						app.Status.ServiceCredentials.TemplateAnnotations(),
+						makeInstanceAnnotations(),
+					),
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
+
+// processReplicas gets the number of instances of the process to run. Like
+// the web process, all instances are scaled down when the App is stopped.
+func processReplicas(app *v1alpha1.App, process *v1alpha1.AppSpecProcess) *int32 {
+	replicas := int32(1)
+
+	switch {
+	case app.Spec.Instances.Stopped:
+		replicas = 0
+	case process.Instances != nil:
+		replicas = int32(*process.Instances)
+	}
+
+	return &replicas
+}
+
+// makeProcessProbe copies the process's health check and points any network
+// checks at the port the process was told to listen on.
+func makeProcessProbe(healthCheck *corev1.Probe) *corev1.Probe {
+	if healthCheck == nil {
+		return nil
+	}
+
+	probe := healthCheck.DeepCopy()
+	port := intstr.FromInt(processPort)
+
+	if probe.HTTPGet != nil && probe.HTTPGet.Port.IntValue() == 0 {
+		probe.HTTPGet.Port = port
+	}
+
+	if probe.TCPSocket != nil && probe.TCPSocket.Port.IntValue() == 0 {
+		probe.TCPSocket.Port = port
+	}
+
+	return probe
+}
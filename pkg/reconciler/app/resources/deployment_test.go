@@ -0,0 +1,183 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func ExampleDeploymentName() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	fmt.Println(DeploymentName(app, "worker"))
+
+	// Output: my-app-worker
+}
+
+func ExampleMakeDeploymentAppSelector() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	fmt.Println(MakeDeploymentAppSelector(app).String())
+
+	// Output: app.kubernetes.io/component=process,app.kubernetes.io/name=my-app
+}
+
+func processTestApp() *v1alpha1.App {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.AppSpec{
+			Template: v1alpha1.AppSpecTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Env:   []corev1.EnvVar{{Name: "APP", Value: "true"}},
+							Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{TCPSocket: &corev1.TCPSocketAction{}},
+							},
+						},
+						{Name: "proxy", Args: []string{"./proxy"}},
+					},
+				},
+			},
+		},
+	}
+	app.Status.Image = "some-image"
+
+	return app
+}
+
+func TestMakeDeployment(t *testing.T) {
+	three := 3
+	memory := resource.MustParse("512Mi")
+
+	space := &v1alpha1.Space{}
+	space.Spec.Execution.Env = []corev1.EnvVar{{Name: "SPACE", Value: "true"}}
+
+	app := processTestApp()
+	process := &v1alpha1.AppSpecProcess{
+		Type:      "worker",
+		Command:   "bundle exec sidekiq",
+		Instances: &three,
+		Memory:    &memory,
+	}
+
+	deployment, err := MakeDeployment(app, space, process)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "name", "my-app-worker", deployment.Name)
+	testutil.AssertEqual(t, "namespace", "my-space", deployment.Namespace)
+	testutil.AssertEqual(t, "owner", "my-app", deployment.OwnerReferences[0].Name)
+	testutil.AssertEqual(t, "replicas", int32(3), *deployment.Spec.Replicas)
+	testutil.AssertEqual(t, "selector", map[string]string{
+		v1alpha1.NameLabel:        "my-app",
+		v1alpha1.ProcessTypeLabel: "worker",
+	}, deployment.Spec.Selector.MatchLabels)
+	testutil.AssertEqual(t, "process label", "worker", deployment.Spec.Template.Labels[v1alpha1.ProcessTypeLabel])
+	testutil.AssertEqual(t, "instance index", v1alpha1.UnassignedInstanceIndex, deployment.Spec.Template.Annotations[v1alpha1.InstanceIndexAnnotation])
+
+	containers := deployment.Spec.Template.Spec.Containers
+	testutil.AssertEqual(t, "container count", 1, len(containers))
+
+	container := containers[0]
+	testutil.AssertEqual(t, "container name", ProcessContainerName, container.Name)
+	testutil.AssertEqual(t, "image", "some-image", container.Image)
+	testutil.AssertEqual(t, "args", []string{"bundle exec sidekiq"}, container.Args)
+	testutil.AssertEqual(t, "memory", memory, container.Resources.Requests[corev1.ResourceMemory])
+	testutil.AssertEqual(t, "ports", []corev1.ContainerPort(nil), container.Ports)
+	testutil.AssertEqual(t, "readiness probe", (*corev1.Probe)(nil), container.ReadinessProbe)
+	testutil.AssertEqual(t, "env", append([]corev1.EnvVar{
+		{Name: "APP", Value: "true"},
+		{Name: "PORT", Value: "8080"},
+		{Name: "SPACE", Value: "true"},
+	}, cfutil.InstanceEnv()...), container.Env)
+	testutil.AssertEqual(t, "envFrom", KfInjectedEnvSecretName(app), container.EnvFrom[0].SecretRef.Name)
+}
+
+func TestMakeDeployment_replicas(t *testing.T) {
+	two := 2
+
+	cases := map[string]struct {
+		stopped   bool
+		instances *int
+		want      int32
+	}{
+		"defaults to one": {
+			want: 1,
+		},
+		"uses instances": {
+			instances: &two,
+			want:      2,
+		},
+		"stopped app": {
+			stopped:   true,
+			instances: &two,
+			want:      0,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := processTestApp()
+			app.Spec.Instances.Stopped = tc.stopped
+
+			deployment, err := MakeDeployment(app, &v1alpha1.Space{}, &v1alpha1.AppSpecProcess{
+				Type:      "worker",
+				Instances: tc.instances,
+			})
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "replicas", tc.want, *deployment.Spec.Replicas)
+		})
+	}
+}
+
+func TestMakeDeployment_healthCheck(t *testing.T) {
+	app := processTestApp()
+
+	deployment, err := MakeDeployment(app, &v1alpha1.Space{}, &v1alpha1.AppSpecProcess{
+		Type: "worker",
+		HealthCheck: &corev1.Probe{
+			Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"}},
+		},
+	})
+	testutil.AssertNil(t, "err", err)
+
+	probe := deployment.Spec.Template.Spec.Containers[0].ReadinessProbe
+	testutil.AssertEqual(t, "path", "/healthz", probe.HTTPGet.Path)
+	testutil.AssertEqual(t, "port", intstr.FromInt(8080), probe.HTTPGet.Port)
+}
+
+func TestMakeDeployments_noImage(t *testing.T) {
+	app := processTestApp()
+	app.Status.Image = ""
+	app.Spec.Processes = []v1alpha1.AppSpecProcess{{Type: "worker"}}
+
+	_, err := MakeDeployments(app, &v1alpha1.Space{})
+	testutil.AssertErrorsEqual(t, fmt.Errorf("waiting for source image in latestReadySource"), err)
+}
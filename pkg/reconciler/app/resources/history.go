@@ -0,0 +1,40 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+)
+
+// MakeAppRevision creates the history entry for a revision of the App's
+// Knative Service. The revision must have been created from the App's current
+// template.
+func MakeAppRevision(app *v1alpha1.App, revision *serving.Revision) v1alpha1.AppRevision {
+	// Prefer the digest Knative resolved so rolling back deploys exactly the
+	// same image even if the tag was pushed over.
+	image := revision.Status.ImageDigest
+	if image == "" {
+		image = app.Status.Image
+	}
+
+	return v1alpha1.AppRevision{
+		Name:       revision.Name,
+		Image:      image,
+		SourceName: app.Status.LatestReadySourceName,
+		Template:   *app.Spec.Template.DeepCopy(),
+		DeployedAt: revision.CreationTimestamp,
+	}
+}
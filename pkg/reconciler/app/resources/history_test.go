@@ -0,0 +1,73 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMakeAppRevision(t *testing.T) {
+	t.Parallel()
+
+	created := metav1.NewTime(time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC))
+
+	app := &v1alpha1.App{}
+	app.Status.Image = "gcr.io/my-app:1"
+	app.Status.LatestReadySourceName = "my-app-1"
+	app.Spec.Template.Spec.Containers = []corev1.Container{{
+		Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+	}}
+
+	revision := &serving.Revision{}
+	revision.Name = "my-app-abcde"
+	revision.CreationTimestamp = created
+
+	cases := map[string]struct {
+		digest    string
+		wantImage string
+	}{
+		"digest known": {
+			digest:    "gcr.io/my-app@sha256:1234",
+			wantImage: "gcr.io/my-app@sha256:1234",
+		},
+		"digest unknown": {
+			wantImage: "gcr.io/my-app:1",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			rev := revision.DeepCopy()
+			rev.Status.ImageDigest = tc.digest
+
+			actual := MakeAppRevision(app, rev)
+
+			testutil.AssertEqual(t, "revision", v1alpha1.AppRevision{
+				Name:       "my-app-abcde",
+				Image:      tc.wantImage,
+				SourceName: "my-app-1",
+				Template:   app.Spec.Template,
+				DeployedAt: created,
+			}, actual)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
+	"github.com/google/kf/pkg/kf/cfutil"
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
 	servingv1beta1 "github.com/knative/serving/pkg/apis/serving/v1beta1"
 	"github.com/knative/serving/pkg/resources"
@@ -29,6 +30,15 @@ import (
 	"knative.dev/pkg/kmeta"
 )
 
+const (
+	// KnativeVisibilityLabel is the label Knative uses to decide which
+	// gateways a Service is exposed on.
+	KnativeVisibilityLabel = "serving.knative.dev/visibility"
+	// KnativeVisibilityClusterLocal exposes a Service only on the
+	// cluster-local gateway.
+	KnativeVisibilityClusterLocal = "cluster-local"
+)
+
 // KnativeServiceName gets the name of a Knative Service given the route.
 func KnativeServiceName(app *v1alpha1.App) string {
 	return app.Name
@@ -48,6 +58,12 @@ func MakeKnativeService(
 	// don't modify the spec on the app
 	podSpec := app.Spec.Template.Spec.DeepCopy()
 
+	// At this point in the lifecycle there should be exactly one container
+	// if the webhhook is working but create one to avoid panics just in case.
+	if len(podSpec.Containers) == 0 {
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+	}
+
 	// XXX: Add a dummy environment variable that reflects the UpdateRequests.
 	// This will cause knative to create a new revision of the service.
 	podSpec.Containers[0].Env = append(
@@ -58,19 +74,20 @@ func MakeKnativeService(
 		},
 	)
 
-	// At this point in the lifecycle there should be exactly one container
-	// if the webhhook is working but create one to avoid panics just in case.
-	if len(podSpec.Containers) == 0 {
-		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
-	}
-	podSpec.Containers[0].Image = image
+	container := &podSpec.Containers[0]
+	container.Image = image
+
 	// Execution environment variables come before others because they're built
 	// to be overridden.
-	podSpec.Containers[0].Env = append(space.Spec.Execution.Env, podSpec.Containers[0].Env...)
-	podSpec.Containers[0].Env = envutil.DeduplicateEnvVars(podSpec.Containers[0].Env)
+	container.Env = append(space.Spec.Execution.Env, container.Env...)
+	container.Env = envutil.DeduplicateEnvVars(container.Env)
+
+	// The instance's variables reference each other so
+	// they're added after the variables are sorted.
+	container.Env = append(container.Env, cfutil.InstanceEnv()...)
 
 	// Inject VCAP env vars from secret
-	podSpec.Containers[0].EnvFrom = []corev1.EnvFromSource{
+	container.EnvFrom = []corev1.EnvFromSource{
 		{
 			SecretRef: &corev1.SecretEnvSource{
 				LocalObjectReference: corev1.LocalObjectReference{
@@ -87,14 +104,22 @@ func MakeKnativeService(
 			OwnerReferences: []metav1.OwnerReference{
 				*kmeta.NewControllerRef(app),
 			},
-			Labels: resources.UnionMaps(app.GetLabels(), app.ComponentLabels("app-scaler")),
+			Labels: resources.UnionMaps(
+				app.GetLabels(),
+				app.ComponentLabels("app-scaler"),
+				makeVisibilityLabels(app, space),
+			),
 		},
 		Spec: serving.ServiceSpec{
 			ConfigurationSpec: serving.ConfigurationSpec{
 				Template: &serving.RevisionTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
-						Labels:      app.ComponentLabels("app-server"),
-						Annotations: app.Spec.Instances.ScalingAnnotations(),
+						Labels: app.ComponentLabels("app-server"),
+						Annotations: resources.UnionMaps(
+							app.Spec.Instances.ScalingAnnotations(),
+							app.Status.ServiceCredentials.TemplateAnnotations(),
+							makeInstanceAnnotations(),
+						),
 					},
 					Spec: serving.RevisionSpec{
 						RevisionSpec: servingv1beta1.RevisionSpec{
@@ -103,6 +128,72 @@ func MakeKnativeService(
 					},
 				},
 			},
+			RouteSpec: serving.RouteSpec{
+				Traffic: makeTraffic(app),
+			},
 		},
 	}, nil
 }
+
+// makeInstanceAnnotations starts Pods without an instance index, the Pod
+// admission webhook in pkg/webhook/instanceindex assigns one when the Pod is
+// created. The webhook's FailurePolicy is Ignore, so if it can't be reached
+// the Pod is still created and keeps the unassigned index (-1).
+func makeInstanceAnnotations() map[string]string {
+	return map[string]string{
+		v1alpha1.InstanceIndexAnnotation: v1alpha1.UnassignedInstanceIndex,
+	}
+}
+
+// makeVisibilityLabels keeps the Knative Service off the public gateway if
+// every route of the App is on one of the space's internal domains. Apps
+// without routes keep Knative's default visibility.
+func makeVisibilityLabels(app *v1alpha1.App, space *v1alpha1.Space) map[string]string {
+	if len(app.Spec.Routes) == 0 {
+		return nil
+	}
+
+	for _, route := range app.Spec.Routes {
+		if !space.Spec.Execution.IsInternalDomain(route.Domain) {
+			return nil
+		}
+	}
+
+	return map[string]string{
+		KnativeVisibilityLabel: KnativeVisibilityClusterLocal,
+	}
+}
+
+// makeTraffic pins traffic to the App's stable revision while a new revision
+// is rolled out with a strategy. The revision being rolled out receives the
+// percent of traffic the rollout has reached, it's pinned too so a newer
+// revision doesn't inherit that traffic. Without a strategy, or before any
+// revision is stable, Knative's default of sending everything to the latest
+// ready revision is used.
+func makeTraffic(app *v1alpha1.App) []serving.TrafficTarget {
+	if app.Spec.Strategy.Type == "" || app.Status.StableRevisionName == "" {
+		return nil
+	}
+
+	rollout := servingv1beta1.TrafficTarget{
+		Percent: app.Status.RolloutPercent,
+	}
+	if app.Status.RolloutPercent > 0 && app.Status.RolloutRevisionName != "" {
+		rollout.RevisionName = app.Status.RolloutRevisionName
+	} else {
+		latestRevision := true
+		rollout.LatestRevision = &latestRevision
+	}
+
+	return []serving.TrafficTarget{
+		{
+			TrafficTarget: servingv1beta1.TrafficTarget{
+				RevisionName: app.Status.StableRevisionName,
+				Percent:      100 - app.Status.RolloutPercent,
+			},
+		},
+		{
+			TrafficTarget: rollout,
+		},
+	}
+}
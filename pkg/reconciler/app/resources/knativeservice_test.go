@@ -0,0 +1,202 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/internal/envutil"
+	"github.com/google/kf/pkg/kf/cfutil"
+	"github.com/google/kf/pkg/kf/testutil"
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	servingv1beta1 "github.com/knative/serving/pkg/apis/serving/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMakeKnativeService_container(t *testing.T) {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.AppSpec{
+			Template: v1alpha1.AppSpecTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Env: []corev1.EnvVar{{Name: "APP", Value: "true"}}},
+					},
+				},
+			},
+		},
+	}
+	app.Status.Image = "some-image"
+
+	space := &v1alpha1.Space{}
+	space.Spec.Execution.Env = []corev1.EnvVar{{Name: "SPACE", Value: "true"}}
+
+	service, err := MakeKnativeService(app, space)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "instance index", v1alpha1.UnassignedInstanceIndex, service.Spec.Template.Annotations[v1alpha1.InstanceIndexAnnotation])
+
+	containers := service.Spec.Template.Spec.Containers
+	testutil.AssertEqual(t, "container count", 1, len(containers))
+
+	container := containers[0]
+	testutil.AssertEqual(t, "image", "some-image", container.Image)
+	testutil.AssertEqual(t, "env count", 3+len(cfutil.InstanceEnv()), len(container.Env))
+	testutil.AssertEqual(t, "space env", "true", envutil.EnvVarsToMap(container.Env)["SPACE"])
+	testutil.AssertEqual(t, "envFrom", KfInjectedEnvSecretName(app), container.EnvFrom[0].SecretRef.Name)
+}
+
+func TestMakeKnativeService_visibility(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "example.com", Default: true},
+		{Domain: "apps.internal", Internal: true},
+	}
+
+	cases := map[string]struct {
+		routes []v1alpha1.RouteSpecFields
+		want   string
+	}{
+		"no routes": {},
+		"public routes": {
+			routes: []v1alpha1.RouteSpecFields{
+				{Hostname: "my-app", Domain: "example.com"},
+			},
+		},
+		"public and internal routes": {
+			routes: []v1alpha1.RouteSpecFields{
+				{Hostname: "my-app", Domain: "example.com"},
+				{Hostname: "my-app", Domain: "apps.internal"},
+			},
+		},
+		"internal routes": {
+			routes: []v1alpha1.RouteSpecFields{
+				{Hostname: "my-app", Domain: "apps.internal"},
+				{Hostname: "other", Domain: "apps.internal"},
+			},
+			want: KnativeVisibilityClusterLocal,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{}
+			app.Name = "my-app"
+			app.Spec.Routes = tc.routes
+			app.Status.Image = "some-image"
+
+			service, err := MakeKnativeService(app, space)
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "visibility", tc.want, service.Labels[KnativeVisibilityLabel])
+		})
+	}
+}
+
+func TestMakeKnativeService_serviceCredentials(t *testing.T) {
+	changed := metav1.Now()
+
+	cases := map[string]struct {
+		credentials v1alpha1.AppServiceCredentialsStatus
+		want        string
+	}{
+		"never changed": {
+			credentials: v1alpha1.AppServiceCredentialsStatus{Hash: "abc"},
+		},
+		"changed": {
+			credentials: v1alpha1.AppServiceCredentialsStatus{Hash: "def", LastChanged: &changed},
+			want:        "def",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{}
+			app.Name = "my-app"
+			app.Status.Image = "some-image"
+			app.Status.ServiceCredentials = tc.credentials
+
+			service, err := MakeKnativeService(app, &v1alpha1.Space{})
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "hash", tc.want, service.Spec.Template.Annotations[v1alpha1.ServiceCredentialsHashAnnotation])
+		})
+	}
+}
+
+func TestMakeKnativeService_traffic(t *testing.T) {
+	latestRevision := true
+
+	cases := map[string]struct {
+		strategy string
+		stable   string
+		percent  int
+		rollout  string
+		want     []serving.TrafficTarget
+	}{
+		"no strategy": {
+			stable: "rev-1",
+		},
+		"no stable revision": {
+			strategy: v1alpha1.RollingStrategy,
+		},
+		"pinned to stable revision": {
+			strategy: v1alpha1.BlueGreenStrategy,
+			stable:   "rev-1",
+			want: []serving.TrafficTarget{
+				{TrafficTarget: servingv1beta1.TrafficTarget{RevisionName: "rev-1", Percent: 100}},
+				{TrafficTarget: servingv1beta1.TrafficTarget{LatestRevision: &latestRevision, Percent: 0}},
+			},
+		},
+		"rolling out": {
+			strategy: v1alpha1.RollingStrategy,
+			stable:   "rev-1",
+			percent:  25,
+			want: []serving.TrafficTarget{
+				{TrafficTarget: servingv1beta1.TrafficTarget{RevisionName: "rev-1", Percent: 75}},
+				{TrafficTarget: servingv1beta1.TrafficTarget{LatestRevision: &latestRevision, Percent: 25}},
+			},
+		},
+		"rolling out pinned revision": {
+			strategy: v1alpha1.RollingStrategy,
+			stable:   "rev-1",
+			percent:  25,
+			rollout:  "rev-2",
+			want: []serving.TrafficTarget{
+				{TrafficTarget: servingv1beta1.TrafficTarget{RevisionName: "rev-1", Percent: 75}},
+				{TrafficTarget: servingv1beta1.TrafficTarget{RevisionName: "rev-2", Percent: 25}},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{}
+			app.Name = "my-app"
+			app.Spec.Strategy.Type = tc.strategy
+			app.Status.Image = "some-image"
+			app.Status.StableRevisionName = tc.stable
+			app.Status.RolloutPercent = tc.percent
+			app.Status.RolloutRevisionName = tc.rollout
+
+			service, err := MakeKnativeService(app, &v1alpha1.Space{})
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "traffic", tc.want, service.Spec.Traffic)
+		})
+	}
+}
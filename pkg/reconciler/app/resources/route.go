@@ -15,6 +15,7 @@
 package resources
 
 import (
+	"fmt"
 	"hash/crc64"
 	"path"
 	"regexp"
@@ -35,13 +36,19 @@ var (
 // MakeRouteLabels creates Labels that can be used to tie a Route to a
 // VirtualService.
 func MakeRouteLabels(spec v1alpha1.RouteSpecFields) map[string]string {
-	return map[string]string{
+	l := map[string]string{
 		v1alpha1.ManagedByLabel: "kf",
 		v1alpha1.ComponentLabel: "route",
 		v1alpha1.RouteHostname:  spec.Hostname,
 		v1alpha1.RouteDomain:    spec.Domain,
 		v1alpha1.RoutePath:      toBase36(path.Join("/", spec.Path)),
 	}
+
+	if spec.Port != 0 {
+		l[v1alpha1.RoutePort] = strconv.Itoa(int(spec.Port))
+	}
+
+	return l
 }
 
 // MakeRouteAppLabels creates Labels that can be used to lookup the Route for
@@ -69,12 +76,27 @@ func mustRequirement(key string, op selection.Operator, val string) labels.Requi
 	return *r
 }
 
+// portRequirement matches Routes with the same port as spec. HTTP routes
+// don't have the port label at all, so they're matched by its absence.
+func portRequirement(spec v1alpha1.RouteSpecFields) labels.Requirement {
+	if spec.Port != 0 {
+		return mustRequirement(v1alpha1.RoutePort, selection.Equals, strconv.Itoa(int(spec.Port)))
+	}
+
+	r, err := labels.NewRequirement(v1alpha1.RoutePort, selection.DoesNotExist, nil)
+	if err != nil {
+		panic(err)
+	}
+	return *r
+}
+
 // MakeRouteSelector creates a labels.Selector for listing all the
 // corresponding Routes excluding Path.
 func MakeRouteSelectorNoPath(spec v1alpha1.RouteSpecFields) labels.Selector {
 	return labels.NewSelector().Add(
 		mustRequirement(v1alpha1.RouteHostname, selection.Equals, spec.Hostname),
 		mustRequirement(v1alpha1.RouteDomain, selection.Equals, spec.Domain),
+		portRequirement(spec),
 	)
 }
 
@@ -85,6 +107,7 @@ func MakeRouteSelector(spec v1alpha1.RouteSpecFields) labels.Selector {
 		mustRequirement(v1alpha1.RouteHostname, selection.Equals, spec.Hostname),
 		mustRequirement(v1alpha1.RouteDomain, selection.Equals, spec.Domain),
 		mustRequirement(v1alpha1.RoutePath, selection.Equals, toBase36(path.Join("/", spec.Path))),
+		portRequirement(spec),
 	)
 }
 
@@ -113,14 +136,19 @@ func MakeRoutes(
 		appRoute := appRoute.DeepCopy()
 		appRoute.SetSpaceDefaults(space)
 
+		// Ports can only be reserved on TCP domains and routes on TCP
+		// domains are only reachable through their port.
+		tcpDomain := space.Spec.Execution.IsTCPDomain(appRoute.Domain)
+		switch {
+		case appRoute.Port != 0 && !tcpDomain:
+			return nil, nil, fmt.Errorf("route %s has a port but %s isn't a TCP domain", appRoute, appRoute.Domain)
+		case appRoute.Port == 0 && tcpDomain:
+			return nil, nil, fmt.Errorf("route %s is on TCP domain %s but doesn't have a port", appRoute, appRoute.Domain)
+		}
+
 		routes = append(routes, v1alpha1.Route{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: v1alpha1.GenerateRouteName(
-					appRoute.Hostname,
-					appRoute.Domain,
-					appRoute.Path,
-					app.Name,
-				),
+				Name:      v1alpha1.GenerateRouteNameFromSpec(*appRoute, app.Name),
 				Namespace: space.Name,
 				Labels: UnionMaps(
 					app.GetLabels(),
@@ -138,19 +166,19 @@ func MakeRoutes(
 			},
 		})
 
-		// Claim route
+		// Claim route. Weights and matches are specific to the App, so
+		// they're left off of the shared claim.
+		claimFields := *appRoute.DeepCopy()
+		claimFields.Weight = nil
+		claimFields.Match = nil
 		claims = append(claims, v1alpha1.RouteClaim{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: MakeRouteLabels(*appRoute),
-				Name: v1alpha1.GenerateRouteClaimName(
-					appRoute.Hostname,
-					appRoute.Domain,
-					appRoute.Path,
-				),
+				Labels:    MakeRouteLabels(*appRoute),
+				Name:      v1alpha1.GenerateRouteNameFromSpec(*appRoute, ""),
 				Namespace: space.Name,
 			},
 			Spec: v1alpha1.RouteClaimSpec{
-				RouteSpecFields: *appRoute,
+				RouteSpecFields: claimFields,
 			},
 		})
 	}
@@ -15,6 +15,7 @@
 package resources
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -27,6 +28,9 @@ import (
 func TestMakeRoutes(t *testing.T) {
 	t.Parallel()
 
+	weight := 10
+	match := &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}
+
 	for tn, tc := range map[string]struct {
 		app    v1alpha1.App
 		space  v1alpha1.Space
@@ -72,6 +76,38 @@ func TestMakeRoutes(t *testing.T) {
 				testutil.AssertEqual(t, "route.Spec.Path", "/some-path", claims[0].Spec.Path)
 			},
 		},
+		"weight is kept on the route but not the claim": {
+			app: v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "some-name",
+				},
+				Spec: v1alpha1.AppSpec{
+					Routes: []v1alpha1.RouteSpecFields{
+						{Hostname: "some-hostname", Domain: "example.com", Weight: &weight},
+					},
+				},
+			},
+			assert: func(t *testing.T, routes []v1alpha1.Route, claims []v1alpha1.RouteClaim) {
+				testutil.AssertEqual(t, "route.Spec.Weight", &weight, routes[0].Spec.Weight)
+				testutil.AssertEqual(t, "claim.Spec.Weight", (*int)(nil), claims[0].Spec.Weight)
+			},
+		},
+		"match is kept on the route but not the claim": {
+			app: v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "some-name",
+				},
+				Spec: v1alpha1.AppSpec{
+					Routes: []v1alpha1.RouteSpecFields{
+						{Hostname: "some-hostname", Domain: "example.com", Match: match},
+					},
+				},
+			},
+			assert: func(t *testing.T, routes []v1alpha1.Route, claims []v1alpha1.RouteClaim) {
+				testutil.AssertEqual(t, "route.Spec.Match", match, routes[0].Spec.Match)
+				testutil.AssertEqual(t, "claim.Spec.Match", (*v1alpha1.RouteMatch)(nil), claims[0].Spec.Match)
+			},
+		},
 		"no domain, uses space default": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -151,6 +187,59 @@ func TestMakeRoutes(t *testing.T) {
 	}
 }
 
+func TestMakeRoutes_tcp(t *testing.T) {
+	t.Parallel()
+
+	space := v1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-space"},
+		Spec: v1alpha1.SpaceSpec{
+			Execution: v1alpha1.SpaceSpecExecution{
+				Domains: []v1alpha1.SpaceDomain{
+					{Domain: "example.com", Default: true},
+					{Domain: "tcp.example.com", TCP: true},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range map[string]struct {
+		route   v1alpha1.RouteSpecFields
+		wantErr error
+	}{
+		"port on TCP domain": {
+			route: v1alpha1.RouteSpecFields{Domain: "tcp.example.com", Port: 1234},
+		},
+		"port on HTTP domain": {
+			route:   v1alpha1.RouteSpecFields{Domain: "example.com", Port: 1234},
+			wantErr: errors.New("route example.com:1234 has a port but example.com isn't a TCP domain"),
+		},
+		"TCP domain without port": {
+			route:   v1alpha1.RouteSpecFields{Domain: "tcp.example.com"},
+			wantErr: errors.New("route tcp.example.com/ is on TCP domain tcp.example.com but doesn't have a port"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			app := v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-app"},
+				Spec: v1alpha1.AppSpec{
+					Routes: []v1alpha1.RouteSpecFields{tc.route},
+				},
+			}
+
+			routes, claims, err := MakeRoutes(&app, &space)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "route name", v1alpha1.GenerateTCPRouteName("tcp.example.com", 1234, "some-app"), routes[0].Name)
+			testutil.AssertEqual(t, "claim name", v1alpha1.GenerateTCPRouteClaimName("tcp.example.com", 1234), claims[0].Name)
+			testutil.AssertEqual(t, "port label", "1234", routes[0].Labels[v1alpha1.RoutePort])
+			testutil.AssertEqual(t, "claim port", int32(1234), claims[0].Spec.Port)
+		})
+	}
+}
+
 func ExampleMakeRouteLabels() {
 	l := MakeRouteLabels(v1alpha1.RouteSpecFields{
 		Hostname: "some-hostname",
@@ -199,6 +288,30 @@ func TestMakeRouteSelector(t *testing.T) {
 
 	testutil.AssertEqual(t, "matches", true, s.Matches(good))
 	testutil.AssertEqual(t, "doesn't match", false, s.Matches(bad))
+
+	tcp := labels.Merge(good, labels.Set{v1alpha1.RoutePort: "1234"})
+	testutil.AssertEqual(t, "doesn't match TCP routes", false, s.Matches(tcp))
+}
+
+func TestMakeRouteSelector_tcp(t *testing.T) {
+	t.Parallel()
+
+	s := MakeRouteSelector(v1alpha1.RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   1234,
+	})
+
+	good := MakeRouteLabels(v1alpha1.RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   1234,
+	})
+	bad := MakeRouteLabels(v1alpha1.RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   4321,
+	})
+
+	testutil.AssertEqual(t, "matches", true, s.Matches(labels.Set(good)))
+	testutil.AssertEqual(t, "doesn't match", false, s.Matches(labels.Set(bad)))
 }
 
 func ExampleUnionMaps() {
@@ -15,6 +15,8 @@
 package resources
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -34,7 +36,7 @@ func KfInjectedEnvSecretName(app *v1alpha1.App) string {
 // MakeKfInjectedEnvSecret creates a Secret containing the env vars for the given application.
 func MakeKfInjectedEnvSecret(app *v1alpha1.App, space *v1alpha1.Space, serviceBindings []servicecatalogv1beta1.ServiceBinding, systemEnvInjector cfutil.SystemEnvInjector) (*v1.Secret, error) {
 
-	computedEnv, err := systemEnvInjector.ComputeSystemEnv(app, serviceBindings)
+	computedEnv, err := systemEnvInjector.ComputeSystemEnv(app, space, serviceBindings)
 	if err != nil {
 		return nil, err
 	}
@@ -58,3 +60,12 @@ func MakeKfInjectedEnvSecret(app *v1alpha1.App, space *v1alpha1.Space, serviceBi
 
 	return secret, nil
 }
+
+// ServiceCredentialsHash gets a hash of the service credentials in the App's
+// injected env secret. Only VCAP_SERVICES is hashed, the rest of the secret
+// changes with things like the App's routes that don't need the App's
+// instances to be rolled.
+func ServiceCredentialsHash(secret *v1.Secret) string {
+	sum := sha256.Sum256(secret.Data[cfutil.VcapServicesEnvVarName])
+	return hex.EncodeToString(sum[:])
+}
@@ -53,7 +53,7 @@ func TestMakeKfInjectedEnvSecret_happyPath(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	fakeInjector := cfutilfake.NewFakeSystemEnvInjector(ctrl)
-	fakeInjector.EXPECT().ComputeSystemEnv(gomock.Any(), gomock.Any()).Return(envVars, nil)
+	fakeInjector.EXPECT().ComputeSystemEnv(gomock.Any(), gomock.Any(), gomock.Any()).Return(envVars, nil)
 
 	app := v1alpha1.App{
 		ObjectMeta: metav1.ObjectMeta{
@@ -115,3 +115,21 @@ func TestMakeKfInjectedEnvSecret_happyPath(t *testing.T) {
 		string(secret.Data[vcapServices.Name]),
 	)
 }
+
+func TestServiceCredentialsHash(t *testing.T) {
+	secret := &v1.Secret{
+		Data: map[string][]byte{
+			"VCAP_APPLICATION": []byte(`{"application_uris":["a.example.com"]}`),
+			"VCAP_SERVICES":    []byte(`{"mysql":[{"credentials":{"password":"a"}}]}`),
+		},
+	}
+	hash := ServiceCredentialsHash(secret)
+
+	routeChanged := secret.DeepCopy()
+	routeChanged.Data["VCAP_APPLICATION"] = []byte(`{"application_uris":["b.example.com"]}`)
+	testutil.AssertEqual(t, "route changed", hash, ServiceCredentialsHash(routeChanged))
+
+	credentialsChanged := secret.DeepCopy()
+	credentialsChanged.Data["VCAP_SERVICES"] = []byte(`{"mysql":[{"credentials":{"password":"b"}}]}`)
+	testutil.AssertEqual(t, "credentials changed", false, hash == ServiceCredentialsHash(credentialsChanged))
+}
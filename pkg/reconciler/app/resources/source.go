@@ -21,6 +21,9 @@ import (
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/knative/serving/pkg/resources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/kmeta"
 )
 
@@ -67,3 +70,38 @@ func MakeSource(app *v1alpha1.App, space *v1alpha1.Space) (*v1alpha1.Source, err
 		Spec: *source,
 	}, nil
 }
+
+// MakeSourceAppSelector creates a labels.Selector for listing all the Sources
+// built for the given App.
+func MakeSourceAppSelector(appName string) labels.Selector {
+	return labels.NewSelector().Add(
+		mustRequirement(v1alpha1.NameLabel, selection.Equals, appName),
+		mustRequirement(v1alpha1.ComponentLabel, selection.Equals, buildComponentName),
+	)
+}
+
+// StaleSources returns the App's Sources that can be deleted. Sources are kept
+// while they're being built, deployed or referenced by a revision in the
+// App's history.
+func StaleSources(app *v1alpha1.App, sources []*v1alpha1.Source) []*v1alpha1.Source {
+	keep := sets.NewString(
+		MakeSourceName(app),
+		app.Status.LatestCreatedSourceName,
+		app.Status.LatestReadySourceName,
+	)
+
+	for _, revision := range app.Status.History {
+		keep.Insert(revision.SourceName)
+	}
+
+	var stale []*v1alpha1.Source
+	for _, source := range sources {
+		if keep.Has(source.Name) || !metav1.IsControlledBy(source, app) {
+			continue
+		}
+
+		stale = append(stale, source)
+	}
+
+	return stale
+}
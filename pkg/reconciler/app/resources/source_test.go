@@ -21,6 +21,7 @@ import (
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
 )
 
 func ExampleBuildpackBuildImageDestination() {
@@ -171,3 +172,50 @@ func boolPtr(b bool) *bool {
 	tmp := &b
 	return tmp
 }
+
+func TestStaleSources(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "myapp"
+	app.UID = "app-uid"
+	app.Spec.Source.UpdateRequests = 5
+	app.Status.LatestCreatedSourceName = "myapp-4"
+	app.Status.LatestReadySourceName = "myapp-3"
+	app.Status.History = []v1alpha1.AppRevision{
+		{Revision: 1, SourceName: "myapp-1"},
+		{Revision: 2, SourceName: "myapp-3"},
+	}
+
+	owned := func(name string) *v1alpha1.Source {
+		source := &v1alpha1.Source{}
+		source.Name = name
+		source.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(app)}
+		return source
+	}
+
+	notOwned := &v1alpha1.Source{}
+	notOwned.Name = "myapp-0"
+
+	sources := []*v1alpha1.Source{
+		notOwned,
+		owned("myapp-1"),
+		owned("myapp-2"),
+		owned("myapp-3"),
+		owned("myapp-4"),
+		owned("myapp-5"),
+	}
+
+	var names []string
+	for _, source := range StaleSources(app, sources) {
+		names = append(names, source.Name)
+	}
+
+	testutil.AssertEqual(t, "stale sources", []string{"myapp-2"}, names)
+}
+
+func ExampleMakeSourceAppSelector() {
+	fmt.Println(MakeSourceAppSelector("myapp").String())
+
+	// Output: app.kubernetes.io/component=build,app.kubernetes.io/name=myapp
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// TCPServicePort is the port TCP routes send traffic to on the App's TCP
+	// Service.
+	TCPServicePort = 8080
+
+	// defaultContainerPort is the port Knative tells the App to listen on if
+	// the App doesn't set one.
+	defaultContainerPort = 8080
+)
+
+// TCPServiceName gets the name of the Service TCP routes to the App send
+// their traffic to.
+func TCPServiceName(appName string) string {
+	return fmt.Sprintf("%s-tcp", appName)
+}
+
+// HasTCPRoutes returns true if any of the App's routes reserve a port.
+func HasTCPRoutes(app *v1alpha1.App) bool {
+	for _, route := range app.Spec.Routes {
+		if route.Port != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MakeTCPService creates a Service that sends traffic straight to the App's
+// Pods. Knative only proxies HTTP, so TCP routes have to skip it. Because
+// the traffic doesn't go through Knative it can't scale the App up from
+// zero.
+func MakeTCPService(app *v1alpha1.App) *corev1.Service {
+	containerPort := int32(defaultContainerPort)
+	if containers := app.Spec.Template.Spec.Containers; len(containers) > 0 && len(containers[0].Ports) > 0 {
+		containerPort = containers[0].Ports[0].ContainerPort
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TCPServiceName(app.Name),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: resources.UnionMaps(app.GetLabels(), app.ComponentLabels("tcp-service")),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: app.ComponentLabels("app-server"),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "tcp",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       TCPServicePort,
+					TargetPort: intstr.FromInt(int(containerPort)),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func ExampleTCPServiceName() {
+	fmt.Println(TCPServiceName("my-app"))
+
+	// Output: my-app-tcp
+}
+
+func ExampleHasTCPRoutes() {
+	app := &v1alpha1.App{}
+	app.Spec.Routes = []v1alpha1.RouteSpecFields{
+		{Hostname: "my-app", Domain: "example.com"},
+	}
+	fmt.Println("HTTP only:", HasTCPRoutes(app))
+
+	app.Spec.Routes = append(app.Spec.Routes, v1alpha1.RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   1234,
+	})
+	fmt.Println("With TCP:", HasTCPRoutes(app))
+
+	// Output: HTTP only: false
+	// With TCP: true
+}
+
+func TestMakeTCPService(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		containers []corev1.Container
+		wantTarget intstr.IntOrString
+	}{
+		"default port": {
+			wantTarget: intstr.FromInt(8080),
+		},
+		"container port": {
+			containers: []corev1.Container{
+				{Ports: []corev1.ContainerPort{{ContainerPort: 9000}}},
+			},
+			wantTarget: intstr.FromInt(9000),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-app",
+					Namespace: "my-space",
+				},
+			}
+			app.Spec.Template.Spec.Containers = tc.containers
+
+			svc := MakeTCPService(app)
+
+			testutil.AssertEqual(t, "name", "my-app-tcp", svc.Name)
+			testutil.AssertEqual(t, "namespace", "my-space", svc.Namespace)
+			testutil.AssertEqual(t, "owner", "my-app", svc.OwnerReferences[0].Name)
+			testutil.AssertEqual(t, "selector", app.ComponentLabels("app-server"), svc.Spec.Selector)
+			testutil.AssertEqual(t, "port", int32(TCPServicePort), svc.Spec.Ports[0].Port)
+			testutil.AssertEqual(t, "target port", tc.wantTarget, svc.Spec.Ports[0].TargetPort)
+		})
+	}
+}
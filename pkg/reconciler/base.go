@@ -26,7 +26,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	v1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 	sharedclientset "knative.dev/pkg/client/clientset/versioned"
 	sharedclient "knative.dev/pkg/client/injection/client"
 	"knative.dev/pkg/configmap"
@@ -36,6 +38,9 @@ import (
 	"knative.dev/pkg/logging/logkey"
 )
 
+// controllerAgentName is the source of the Events the reconcilers record.
+const controllerAgentName = "kf-controller"
+
 // Base implements the core controller logic, given a Reconciler.
 type Base struct {
 	// KubeClientSet allows us to talk to the k8s for core APIs
@@ -56,6 +61,10 @@ type Base struct {
 	// NamespaceLister allows us to list Namespaces. We use this to check for
 	// terminating namespaces.
 	NamespaceLister v1listers.NamespaceLister
+
+	// Recorder records Events about the objects being reconciled so they
+	// show up when users describe them.
+	Recorder record.EventRecorder
 }
 
 // NewBase instantiates a new instance of Base implementing
@@ -64,6 +73,15 @@ func NewBase(ctx context.Context, cmw configmap.Watcher) *Base {
 	kubeClient := kubeclient.Get(ctx)
 	nsInformer := namespaceinformer.Get(ctx)
 
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: kubeClient.CoreV1().Events(""),
+	})
+	go func() {
+		<-ctx.Done()
+		eventBroadcaster.Shutdown()
+	}()
+
 	base := &Base{
 		KubeClientSet:    kubeClient,
 		SharedClientSet:  sharedclient.Get(ctx),
@@ -72,6 +90,10 @@ func NewBase(ctx context.Context, cmw configmap.Watcher) *Base {
 		ConfigMapWatcher: cmw,
 
 		NamespaceLister: nsInformer.Lister(),
+		Recorder: eventBroadcaster.NewRecorder(
+			scheme.Scheme,
+			corev1.EventSource{Component: controllerAgentName},
+		),
 	}
 
 	return base
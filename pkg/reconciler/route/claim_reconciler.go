@@ -0,0 +1,73 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"context"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// ClaimReconciler reconciles RouteClaims. Claims are only reconciled so they
+// can be removed from the VirtualService of their host before they're
+// deleted, everything else is done by the Route reconciler.
+type ClaimReconciler struct {
+	*Reconciler
+}
+
+// Check that our ClaimReconciler implements controller.Reconciler
+var _ controller.Reconciler = (*ClaimReconciler)(nil)
+
+// Reconcile is called by Kubernetes.
+func (r *ClaimReconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("namespace", namespace))
+	logger := logging.FromContext(ctx)
+
+	claim, err := r.routeClaimLister.
+		RouteClaims(namespace).
+		Get(name)
+
+	switch {
+	case apierrs.IsNotFound(err):
+		logger.Errorf("RouteClaim %q no longer exists\n", name)
+		return nil
+
+	case err != nil:
+		return err
+
+	case claim.GetDeletionTimestamp() == nil:
+		return r.setRouteClaimFinalizer(claim, true)
+
+	case !sets.NewString(claim.Finalizers...).Has(v1alpha1.RouteFinalizer):
+		return nil
+	}
+
+	logger.Infof("RouteClaim %q is being deleted\n", name)
+	if err := r.finalizeHost(ctx, namespace, claim.Spec.RouteSpecFields); err != nil {
+		return err
+	}
+
+	return r.setRouteClaimFinalizer(claim, false)
+}
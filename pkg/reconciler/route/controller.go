@@ -16,20 +16,37 @@ package route
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	domaininformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/domain"
 	routeinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/route"
+	routeclaiminformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/routeclaim"
+	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
 	"github.com/google/kf/pkg/reconciler"
 	appresources "github.com/google/kf/pkg/reconciler/app/resources"
+	"github.com/google/kf/pkg/reconciler/route/resources"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	networking "knative.dev/pkg/apis/istio/v1alpha3"
+	gatewayinformer "knative.dev/pkg/client/injection/informers/istio/v1alpha3/gateway"
 	virtualserviceinformer "knative.dev/pkg/client/injection/informers/istio/v1alpha3/virtualservice"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	secretinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/secret"
+	serviceinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/service"
+	"knative.dev/pkg/logging"
 )
 
+// OrphanSweepPeriod is how often VirtualServices left behind by deleted
+// Routes are cleaned up.
+const OrphanSweepPeriod = 10 * time.Minute
+
 // NewController creates a new controller capable of reconciling Kf Routes.
 func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	logger := reconciler.NewControllerLogger(ctx, "routes.kf.dev")
@@ -37,13 +54,13 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	// Get informers off context
 	vsInformer := virtualserviceinformer.Get(ctx)
 	routeInformer := routeinformer.Get(ctx)
+	routeClaimInformer := routeclaiminformer.Get(ctx)
+	spaceInformer := spaceinformer.Get(ctx)
+	domainInformer := domaininformer.Get(ctx)
+	gatewayInformer := gatewayinformer.Get(ctx)
 
 	// Create reconciler
-	c := &Reconciler{
-		Base:                 reconciler.NewBase(ctx, cmw),
-		routeLister:          routeInformer.Lister(),
-		virtualServiceLister: vsInformer.Lister(),
-	}
+	c := newReconciler(ctx, cmw)
 
 	impl := controller.NewImpl(c, logger, "Routes")
 
@@ -58,9 +75,117 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(EnqueueRoutesOfVirtualService(logger, impl, c)),
 	})
 
+	// Watch for any changes to Gateways in the kf namespace.
+	gatewayInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterGatewayWithNamespace(v1alpha1.KfNamespace),
+		Handler:    controller.HandleAll(EnqueueRoutesOfGateway(logger, impl, c)),
+	})
+
+	// Watch for changes to Spaces because their domains decide if a Route is
+	// internal.
+	spaceInformer.Informer().AddEventHandler(controller.HandleAll(EnqueueRoutesOfSpace(logger, impl, c)))
+
+	// Watch for changes to Domains because they can also decide if a Route
+	// is internal.
+	domainInformer.Informer().AddEventHandler(controller.HandleAll(EnqueueRoutesOfDomain(logger, impl, c)))
+
+	// Watch for changes to RouteClaims because route services are bound to
+	// them and they settle conflicts between spaces.
+	routeClaimInformer.Informer().AddEventHandler(controller.HandleAll(EnqueueRoutesOfRouteClaim(logger, impl, c)))
+
+	// Watch for changes to the cluster-wide default backend, it's used by
+	// Routes in every space that doesn't set its own.
+	cmw.Watch(resources.DefaultsConfigName, func(*corev1.ConfigMap) {
+		routes, err := c.routeLister.List(labels.Everything())
+		if err != nil {
+			logger.Warnf("failed to list routes: %s", err)
+			return
+		}
+
+		for _, route := range routes {
+			impl.Enqueue(route)
+		}
+	})
+
+	// Kubernetes doesn't garbage collect the VirtualServices of Routes in
+	// other namespaces, so any that were missed by the finalizer are swept
+	// up periodically along with unused self-signed certificates, which are
+	// also renewed before they expire. The sweep waits for the informers to
+	// sync, otherwise everything would look orphaned.
+	go func() {
+		if !cache.WaitForCacheSync(
+			ctx.Done(),
+			routeInformer.Informer().HasSynced,
+			routeClaimInformer.Informer().HasSynced,
+			vsInformer.Informer().HasSynced,
+			secretinformer.Get(ctx).Informer().HasSynced,
+		) {
+			logger.Warn("Informers didn't sync, not sweeping orphaned VirtualServices")
+			return
+		}
+
+		wait.Until(func() {
+			if err := c.sweepOrphans(ctx); err != nil {
+				logger.Warnw("Failed to sweep orphaned VirtualServices", zap.Error(err))
+			}
+		}, OrphanSweepPeriod, ctx.Done())
+	}()
+
 	return impl
 }
 
+// NewClaimController creates a new controller that finalizes Kf RouteClaims.
+func NewClaimController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	logger := reconciler.NewControllerLogger(ctx, "routeclaims.kf.dev")
+
+	// Get informers off context
+	routeClaimInformer := routeclaiminformer.Get(ctx)
+
+	// Create reconciler
+	c := &ClaimReconciler{
+		Reconciler: newReconciler(ctx, cmw),
+	}
+
+	impl := controller.NewImpl(c, logger, "RouteClaims")
+
+	logger.Info("Setting up event handlers")
+
+	routeClaimInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	return impl
+}
+
+// newReconciler creates a Reconciler with listers from the informers on the
+// context. The Reconciler keeps its cluster-wide default backend up to date
+// with the config-defaults ConfigMap.
+func newReconciler(ctx context.Context, cmw configmap.Watcher) *Reconciler {
+	logger := logging.FromContext(ctx)
+
+	r := &Reconciler{
+		Base:                 reconciler.NewBase(ctx, cmw),
+		routeLister:          routeinformer.Get(ctx).Lister(),
+		routeClaimLister:     routeclaiminformer.Get(ctx).Lister(),
+		spaceLister:          spaceinformer.Get(ctx).Lister(),
+		domainLister:         domaininformer.Get(ctx).Lister(),
+		virtualServiceLister: virtualserviceinformer.Get(ctx).Lister(),
+		gatewayLister:        gatewayinformer.Get(ctx).Lister(),
+		secretLister:         secretinformer.Get(ctx).Lister(),
+		serviceLister:        serviceinformer.Get(ctx).Lister(),
+	}
+
+	cmw.Watch(resources.DefaultsConfigName, func(cm *corev1.ConfigMap) {
+		backend, err := resources.DefaultBackendFromConfigMap(cm)
+		if err != nil {
+			logger.Warnw("Failed to read the default backend, keeping the previous one", zap.Error(err))
+			return
+		}
+
+		r.setDefaultBackend(backend)
+	})
+
+	return r
+}
+
 // FilterVSWithNamespace makes it simple to create FilterFunc's for use with
 // cache.FilteringResourceEventHandler that filter based on a namespace and if
 // the type is a VirtualService.
@@ -76,6 +201,20 @@ func FilterVSWithNamespace(namespace string) func(obj interface{}) bool {
 	}
 }
 
+// FilterGatewayWithNamespace creates a FilterFunc for Gateways in the
+// namespace, see FilterVSWithNamespace.
+func FilterGatewayWithNamespace(namespace string) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if object, ok := obj.(metav1.Object); ok {
+			if namespace == object.GetNamespace() {
+				_, ok := obj.(*networking.Gateway)
+				return ok
+			}
+		}
+		return false
+	}
+}
+
 // EnqueueRoutesOfVirtualService will find the corresponding routes for the
 // VirtualService.  It will Enqueue a key for each one. We aren't able to use
 // EnqueueControllerOf (as other components do), because a VirtualService is
@@ -92,11 +231,15 @@ func EnqueueRoutesOfVirtualService(
 			return
 		}
 
+		// Only VirtualServices for TCP routes have a port.
+		port, _ := strconv.Atoi(vs.Annotations["port"])
+
 		routes, err := r.routeLister.
 			Routes(vs.Annotations["space"]).
 			List(appresources.MakeRouteSelectorNoPath(v1alpha1.RouteSpecFields{
 				Domain:   vs.Annotations["domain"],
 				Hostname: vs.Annotations["hostname"],
+				Port:     int32(port),
 			}))
 		if err != nil {
 			logger.Warnf("failed to list corresponding routes: %s", err)
@@ -108,3 +251,113 @@ func EnqueueRoutesOfVirtualService(
 		}
 	}
 }
+
+// EnqueueRoutesOfGateway will Enqueue a key for each Route on the host the
+// Gateway serves HTTPS for.
+func EnqueueRoutesOfGateway(
+	logger *zap.SugaredLogger,
+	c *controller.Impl,
+	r *Reconciler,
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		gateway, ok := obj.(*networking.Gateway)
+		if !ok {
+			return
+		}
+
+		routes, err := r.routeLister.
+			Routes(gateway.Annotations["space"]).
+			List(appresources.MakeRouteSelectorNoPath(v1alpha1.RouteSpecFields{
+				Domain:   gateway.Annotations["domain"],
+				Hostname: gateway.Annotations["hostname"],
+			}))
+		if err != nil {
+			logger.Warnf("failed to list corresponding routes: %s", err)
+			return
+		}
+
+		for _, route := range routes {
+			c.Enqueue(route)
+		}
+	}
+}
+
+// EnqueueRoutesOfSpace will Enqueue a key for each Route in the Space.
+func EnqueueRoutesOfSpace(
+	logger *zap.SugaredLogger,
+	c *controller.Impl,
+	r *Reconciler,
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		space, ok := obj.(*v1alpha1.Space)
+		if !ok {
+			return
+		}
+
+		routes, err := r.routeLister.
+			Routes(space.Name).
+			List(labels.Everything())
+		if err != nil {
+			logger.Warnf("failed to list routes in space: %s", err)
+			return
+		}
+
+		for _, route := range routes {
+			c.Enqueue(route)
+		}
+	}
+}
+
+// EnqueueRoutesOfDomain will Enqueue a key for each Route on the Domain in
+// any Space.
+func EnqueueRoutesOfDomain(
+	logger *zap.SugaredLogger,
+	c *controller.Impl,
+	r *Reconciler,
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		domain, ok := obj.(*v1alpha1.Domain)
+		if !ok {
+			return
+		}
+
+		routes, err := r.routeLister.List(labels.SelectorFromSet(labels.Set{
+			v1alpha1.RouteDomain: domain.Name,
+		}))
+		if err != nil {
+			logger.Warnf("failed to list routes on domain: %s", err)
+			return
+		}
+
+		for _, route := range routes {
+			c.Enqueue(route)
+		}
+	}
+}
+
+// EnqueueRoutesOfRouteClaim will Enqueue a key for each Route with the same
+// Hostname+Domain as the RouteClaim. Routes in every space are enqueued
+// because claims decide which space holds the host.
+func EnqueueRoutesOfRouteClaim(
+	logger *zap.SugaredLogger,
+	c *controller.Impl,
+	r *Reconciler,
+) func(obj interface{}) {
+	return func(obj interface{}) {
+		claim, ok := obj.(*v1alpha1.RouteClaim)
+		if !ok {
+			return
+		}
+
+		routes, err := r.routeLister.
+			List(appresources.MakeRouteSelectorNoPath(claim.Spec.RouteSpecFields))
+		if err != nil {
+			logger.Warnf("failed to list routes of claim: %s", err)
+			return
+		}
+
+		for _, route := range routes {
+			c.Enqueue(route)
+		}
+	}
+}
@@ -16,8 +16,11 @@ package route
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"sort"
+	"reflect"
+	"sync"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
@@ -25,9 +28,16 @@ import (
 	"github.com/google/kf/pkg/reconciler"
 	appresources "github.com/google/kf/pkg/reconciler/app/resources"
 	"github.com/google/kf/pkg/reconciler/route/resources"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	networking "knative.dev/pkg/apis/istio/v1alpha3"
 	istiolisters "knative.dev/pkg/client/listers/istio/v1alpha3"
@@ -42,7 +52,18 @@ type Reconciler struct {
 
 	// listers index properties about resources
 	routeLister          kflisters.RouteLister
+	routeClaimLister     kflisters.RouteClaimLister
+	spaceLister          kflisters.SpaceLister
+	domainLister         kflisters.DomainLister
 	virtualServiceLister istiolisters.VirtualServiceLister
+	gatewayLister        istiolisters.GatewayLister
+	secretLister         corev1listers.SecretLister
+	serviceLister        corev1listers.ServiceLister
+
+	// defaultBackend is the cluster-wide default backend from the
+	// config-defaults ConfigMap, it's updated while the Reconciler runs.
+	defaultBackendLock sync.RWMutex
+	defaultBackend     *v1alpha1.RouteDefaultBackend
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -82,8 +103,8 @@ func (r *Reconciler) reconcileRoute(
 		return err
 
 	case original.GetDeletionTimestamp() != nil:
-		logger.Errorf("Route %q is being deleted\n", name)
-		return nil
+		logger.Infof("Route %q is being deleted\n", name)
+		return r.finalizeRoute(ctx, original)
 	}
 
 	if r.IsNamespaceTerminating(namespace) {
@@ -91,11 +112,30 @@ func (r *Reconciler) reconcileRoute(
 		return nil
 	}
 
+	// Make sure the Route is removed from its VirtualService before it's
+	// deleted.
+	if err := r.setRouteFinalizer(original, true); err != nil {
+		return err
+	}
+
 	// Don't modify the informers copy
 	toReconcile := original.DeepCopy()
 
-	// Reconcile this copy of the route.
-	return r.ApplyChanges(ctx, toReconcile)
+	// Reconcile this copy of the route and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile)
+	if equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+
+	} else if _, uErr := r.updateStatus(namespace, toReconcile); uErr != nil {
+		logger.Warnw("Failed to update Route status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
 }
 
 // ApplyChanges updates the linked resources in the cluster with the current
@@ -106,21 +146,81 @@ func (r *Reconciler) ApplyChanges(
 ) error {
 	logger := logging.FromContext(ctx)
 	origRoute.SetDefaults(ctx)
+	origRoute.Status.InitializeConditions()
+
+	// Check claims
+	{
+		logger.Debug("checking RouteClaims")
+		// The VirtualService is shared by every space so only the space that
+		// claimed the host first may change it.
+		claims, err := r.routeClaimLister.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		claimingSpace := v1alpha1.ClaimingSpace(origRoute.Spec.RouteSpecFields, liveRouteClaims(claims))
+		if claimingSpace != "" && claimingSpace != origRoute.GetNamespace() {
+			origRoute.Status.MarkConflictsWithSpace(claimingSpace)
+			return nil
+		}
+
+		// TCP routes share one gateway so their port must also be free in
+		// the other domains of the space. The webhook rejects conflicts, but
+		// claims made at the same time can slip through.
+		if owner := v1alpha1.PortConflict(
+			origRoute.GetNamespace(),
+			origRoute.Spec.RouteSpecFields,
+			liveRouteClaims(claims),
+		); owner != nil {
+			origRoute.Status.MarkPortConflict(origRoute.Spec.Port, owner)
+			return nil
+		}
+
+		origRoute.Status.MarkClaimed()
+	}
+
+	spaceDomain, err := r.lookupSpaceDomain(origRoute.GetNamespace(), origRoute.Spec.Domain)
+	if err != nil {
+		return err
+	}
+
+	defaultBackend, err := r.lookupDefaultBackend(origRoute.GetNamespace())
+	if err != nil {
+		return err
+	}
 
 	// Sync VirtualService
 	{
 		logger.Debug("reconciling VirtualService")
-		// Fetch routes with the same Hostname+Domain+Path.
-		routes, err := r.routeLister.
-			Routes(origRoute.GetNamespace()).
-			List(appresources.MakeRouteSelector(origRoute.Spec.RouteSpecFields))
+		condition := origRoute.Status.VirtualServiceCondition()
+		// Fetch routes with the same Hostname+Domain+Path, or Domain+Port for
+		// TCP routes. The claims hold the route services bound to them.
+		routes, claims, err := r.listLive(
+			origRoute.GetNamespace(),
+			appresources.MakeRouteSelector(origRoute.Spec.RouteSpecFields),
+		)
 		if err != nil {
 			return err
 		}
 
-		desired, err := resources.MakeVirtualService(routes)
+		if origRoute.Spec.Port == 0 && !hasUnmatchedApp(routes) {
+			backendType := v1alpha1.RouteDefaultBackendUnavailable
+			if defaultBackend != nil {
+				backendType = defaultBackend.Type
+			}
+			origRoute.Status.MarkNoAppBound(backendType)
+		} else {
+			origRoute.Status.MarkAppBound()
+		}
+
+		routeServiceKey, err := r.lookupRouteServiceKey(claims)
 		if err != nil {
-			return err
+			return condition.MarkReconciliationError("getting route service key", err)
+		}
+
+		desired, err := resources.MakeVirtualService(routes, claims, spaceDomain, defaultBackend, routeServiceKey)
+		if err != nil {
+			return condition.MarkTemplateError(err)
 		}
 
 		actual, err := r.virtualServiceLister.
@@ -134,23 +234,714 @@ func (r *Reconciler) ApplyChanges(
 				Create(desired)
 
 			if err != nil {
-				return err
+				return condition.MarkReconciliationError("creating", err)
 			}
 		} else if err != nil {
-			return err
+			return condition.MarkReconciliationError("getting latest", err)
 		} else if actual.GetDeletionTimestamp() != nil {
 			return nil
 		} else if actual, err = r.reconcile(
 			desired,
 			actual,
 		); err != nil {
+			return condition.MarkReconciliationError("updating existing", err)
+		}
+
+		origRoute.Status.PropagateVirtualServiceStatus(actual)
+
+		if err := r.reconcileTLS(ctx, origRoute.GetNamespace(), origRoute.Spec.RouteSpecFields, spaceDomain); err != nil {
+			return err
+		}
+	}
+
+	if origRoute.Spec.Port != 0 {
+		if err := r.reconcileTCPGateway(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// finalizeRoute removes the Route from the VirtualService of its host and
+// then lets it be deleted.
+func (r *Reconciler) finalizeRoute(ctx context.Context, route *v1alpha1.Route) error {
+	if !sets.NewString(route.Finalizers...).Has(v1alpha1.RouteFinalizer) {
+		return nil
+	}
+
+	if err := r.finalizeHost(ctx, route.GetNamespace(), route.Spec.RouteSpecFields); err != nil {
+		return err
+	}
+
+	return r.setRouteFinalizer(route, false)
+}
+
+// finalizeHost removes the Routes and RouteClaims that are being deleted
+// from the VirtualService and Gateway of their host. Unlike ApplyChanges, the
+// VirtualService is rebuilt from the remaining Routes rather than merged so
+// the deleted ones stop receiving traffic, and it's deleted once none are
+// left.
+func (r *Reconciler) finalizeHost(
+	ctx context.Context,
+	namespace string,
+	fields v1alpha1.RouteSpecFields,
+) error {
+	logger := logging.FromContext(ctx)
+
+	routes, claims, err := r.listLive(namespace, appresources.MakeRouteSelectorNoPath(fields))
+	if err != nil {
+		return err
+	}
+
+	// The Space may already be gone if nothing is left on the host.
+	var (
+		spaceDomain    v1alpha1.SpaceDomain
+		defaultBackend *v1alpha1.RouteDefaultBackend
+	)
+	if len(routes) > 0 {
+		if spaceDomain, err = r.lookupSpaceDomain(namespace, fields.Domain); err != nil {
+			return err
+		}
+
+		if defaultBackend, err = r.lookupDefaultBackend(namespace); err != nil {
+			return err
+		}
+	}
+
+	// Sync VirtualService
+	{
+		logger.Debug("finalizing VirtualService")
+		actual, err := r.virtualServiceLister.
+			VirtualServices(v1alpha1.KfNamespace).
+			Get(v1alpha1.GenerateVirtualServiceName(fields))
+		switch {
+		case errors.IsNotFound(err):
+			// Nothing to clean up.
+		case err != nil:
 			return err
+		case actual.Annotations["space"] != namespace:
+			// The host belongs to another space, leave it alone.
+			return nil
+		case actual.GetDeletionTimestamp() != nil:
+			// Already being cleaned up.
+		case len(routes) == 0:
+			if err := r.SharedClientSet.
+				Networking().
+				VirtualServices(actual.GetNamespace()).
+				Delete(actual.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		default:
+			routeServiceKey, err := r.lookupRouteServiceKey(claims)
+			if err != nil {
+				return err
+			}
+
+			desired, err := resources.MakeVirtualService(routes, claims, spaceDomain, defaultBackend, routeServiceKey)
+			if err != nil {
+				return err
+			}
+
+			if _, err := r.replaceVirtualService(desired, actual); err != nil {
+				return err
+			}
 		}
 	}
 
+	if err := r.reconcileTLS(ctx, namespace, fields, spaceDomain); err != nil {
+		return err
+	}
+
+	if fields.Port != 0 {
+		return r.reconcileTCPGateway(ctx)
+	}
+
 	return nil
 }
 
+// lookupSpaceDomain gets the settings of the domain in the space, including
+// the ones set on the cluster's Domains.
+func (r *Reconciler) lookupSpaceDomain(namespace, domain string) (v1alpha1.SpaceDomain, error) {
+	space, err := r.spaceLister.Get(namespace)
+	if err != nil {
+		return v1alpha1.SpaceDomain{}, err
+	}
+
+	domains, err := r.domainLister.List(labels.Everything())
+	if err != nil {
+		return v1alpha1.SpaceDomain{}, err
+	}
+
+	spaceDomain, _ := space.WithDomains(domains).Spec.Execution.LookupDomain(domain)
+	return spaceDomain, nil
+}
+
+// lookupDefaultBackend gets the default backend of the space, falling back
+// to the cluster-wide one.
+func (r *Reconciler) lookupDefaultBackend(namespace string) (*v1alpha1.RouteDefaultBackend, error) {
+	space, err := r.spaceLister.Get(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	r.defaultBackendLock.RLock()
+	defer r.defaultBackendLock.RUnlock()
+
+	return resources.SelectDefaultBackend(space, r.defaultBackend), nil
+}
+
+// lookupRouteServiceKey gets the key route service tokens are made with,
+// the Secret holding it is created the first time a route service is bound.
+// nil is returned if none of the claims have a route service.
+func (r *Reconciler) lookupRouteServiceKey(claims []*v1alpha1.RouteClaim) ([]byte, error) {
+	needed := false
+	for _, claim := range claims {
+		if claim.Spec.RouteService != nil {
+			needed = true
+			break
+		}
+	}
+
+	if !needed {
+		return nil, nil
+	}
+
+	secret, err := r.secretLister.
+		Secrets(v1alpha1.KfNamespace).
+		Get(resources.RouteServiceKeySecretName)
+	if errors.IsNotFound(err) {
+		desired, err := resources.MakeRouteServiceKeySecret()
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err = r.KubeClientSet.
+			CoreV1().
+			Secrets(desired.GetNamespace()).
+			Create(desired)
+		if errors.IsAlreadyExists(err) {
+			// Another worker won the race, use its key.
+			secret, err = r.KubeClientSet.
+				CoreV1().
+				Secrets(desired.GetNamespace()).
+				Get(desired.Name, metav1.GetOptions{})
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return secret.Data[resources.RouteServiceKeySecretKey], nil
+}
+
+// setDefaultBackend updates the cluster-wide default backend.
+func (r *Reconciler) setDefaultBackend(backend *v1alpha1.RouteDefaultBackend) {
+	r.defaultBackendLock.Lock()
+	defer r.defaultBackendLock.Unlock()
+
+	r.defaultBackend = backend
+}
+
+// hasUnmatchedApp returns true if one of the Routes binds an App that
+// receives requests regardless of their headers and cookies.
+func hasUnmatchedApp(routes []*v1alpha1.Route) bool {
+	for _, route := range routes {
+		if route.Spec.AppName != "" && route.Spec.Match == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listLive lists the Routes matching the selector and the RouteClaims in the
+// namespace, leaving out the ones that are being deleted.
+func (r *Reconciler) listLive(
+	namespace string,
+	selector labels.Selector,
+) ([]*v1alpha1.Route, []*v1alpha1.RouteClaim, error) {
+	routes, err := r.routeLister.
+		Routes(namespace).
+		List(selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claims, err := r.routeClaimLister.
+		RouteClaims(namespace).
+		List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return liveRoutes(routes), liveRouteClaims(claims), nil
+}
+
+// liveRoutes filters out Routes that are only kept around by their
+// finalizer.
+func liveRoutes(routes []*v1alpha1.Route) []*v1alpha1.Route {
+	var live []*v1alpha1.Route
+	for _, route := range routes {
+		if route.GetDeletionTimestamp() == nil {
+			live = append(live, route)
+		}
+	}
+
+	return live
+}
+
+// liveRouteClaims filters out RouteClaims that are only kept around by their
+// finalizer.
+func liveRouteClaims(claims []*v1alpha1.RouteClaim) []*v1alpha1.RouteClaim {
+	var live []*v1alpha1.RouteClaim
+	for _, claim := range claims {
+		if claim.GetDeletionTimestamp() == nil {
+			live = append(live, claim)
+		}
+	}
+
+	return live
+}
+
+// sweepOrphans deletes VirtualServices, and the Gateways next to them, that
+// no longer have a Route. They're left behind when Routes are deleted
+// without running their finalizer, e.g. if they were made before Routes had
+// one. Self-signed certificates are swept too, see sweepCertificates.
+func (r *Reconciler) sweepOrphans(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+
+	routes, err := r.routeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	virtualServices, err := r.virtualServiceLister.
+		VirtualServices(v1alpha1.KfNamespace).
+		List(labels.SelectorFromSet(labels.Set{
+			v1alpha1.ManagedByLabel: "kf",
+			v1alpha1.ComponentLabel: "virtualservice",
+		}))
+	if err != nil {
+		return err
+	}
+
+	for _, vs := range virtualServices {
+		if vs.GetDeletionTimestamp() != nil ||
+			len(v1alpha1.LiveRouteReferences(vs.OwnerReferences, routes)) > 0 {
+			continue
+		}
+
+		logger.Infof("deleting orphaned VirtualService %q", vs.Name)
+		if err := r.SharedClientSet.
+			Networking().
+			VirtualServices(vs.GetNamespace()).
+			Delete(vs.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		if err := r.SharedClientSet.
+			Networking().
+			Gateways(vs.GetNamespace()).
+			Delete(vs.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return r.sweepCertificates(ctx, routes, time.Now())
+}
+
+// sweepCertificates renews self-signed certificates that are about to expire
+// and deletes the ones no Route or RouteClaim uses anymore. The Secrets live
+// in the IngressNamespace so Kubernetes can't garbage collect them through
+// owner references.
+func (r *Reconciler) sweepCertificates(ctx context.Context, routes []*v1alpha1.Route, now time.Time) error {
+	logger := logging.FromContext(ctx)
+
+	claims, err := r.routeClaimLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	domains := sets.NewString()
+	for _, route := range liveRoutes(routes) {
+		domains.Insert(route.Spec.Domain)
+	}
+	for _, claim := range liveRouteClaims(claims) {
+		domains.Insert(claim.Spec.Domain)
+	}
+
+	secrets, err := r.secretLister.
+		Secrets(resources.IngressNamespace).
+		List(labels.SelectorFromSet(labels.Set{
+			v1alpha1.ManagedByLabel: "kf",
+			v1alpha1.ComponentLabel: "certificate",
+		}))
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		domain := secret.Labels[v1alpha1.RouteDomain]
+		switch {
+		case secret.GetDeletionTimestamp() != nil:
+			continue
+		case !domains.Has(domain):
+			logger.Infof("deleting orphaned certificate %q", secret.Name)
+			if err := r.KubeClientSet.
+				CoreV1().
+				Secrets(secret.GetNamespace()).
+				Delete(secret.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		case resources.SelfSignedNeedsRenewal(secret, now):
+			logger.Infof("renewing certificate %q", secret.Name)
+			if err := r.reconcileSelfSignedSecret(domain, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setRouteFinalizer adds or removes RouteFinalizer on the Route.
+func (r *Reconciler) setRouteFinalizer(route *v1alpha1.Route, want bool) error {
+	patch, err := finalizersPatch(route, want)
+	if err != nil || patch == nil {
+		return err
+	}
+
+	_, err = r.KfClientSet.
+		KfV1alpha1().
+		Routes(route.GetNamespace()).
+		Patch(route.Name, types.MergePatchType, patch)
+	return err
+}
+
+// setRouteClaimFinalizer adds or removes RouteFinalizer on the RouteClaim.
+func (r *Reconciler) setRouteClaimFinalizer(claim *v1alpha1.RouteClaim, want bool) error {
+	patch, err := finalizersPatch(claim, want)
+	if err != nil || patch == nil {
+		return err
+	}
+
+	_, err = r.KfClientSet.
+		KfV1alpha1().
+		RouteClaims(claim.GetNamespace()).
+		Patch(claim.Name, types.MergePatchType, patch)
+	return err
+}
+
+// finalizersPatch creates a merge patch that adds or removes RouteFinalizer
+// on the object, or nil if the finalizers are already as wanted. The
+// resourceVersion makes the patch fail if the object changed in the
+// meantime.
+func finalizersPatch(obj metav1.Object, want bool) ([]byte, error) {
+	finalizers := sets.NewString(obj.GetFinalizers()...)
+	if finalizers.Has(v1alpha1.RouteFinalizer) == want {
+		return nil, nil
+	}
+
+	if want {
+		finalizers.Insert(v1alpha1.RouteFinalizer)
+	} else {
+		finalizers.Delete(v1alpha1.RouteFinalizer)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      finalizers.List(),
+			"resourceVersion": obj.GetResourceVersion(),
+		},
+	})
+}
+
+// reconcileTLS syncs the Gateway serving HTTPS for the host and,
+// for development domains, the self-signed certificate it uses.
+func (r *Reconciler) reconcileTLS(
+	ctx context.Context,
+	namespace string,
+	fields v1alpha1.RouteSpecFields,
+	spaceDomain v1alpha1.SpaceDomain,
+) error {
+	logger := logging.FromContext(ctx)
+
+	// Sync self-signed certificate
+	if spaceDomain.TLS != nil && spaceDomain.TLS.SelfSigned {
+		logger.Debug("reconciling self-signed certificate")
+		if err := r.reconcileSelfSignedSecret(spaceDomain.Domain, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	// Sync Gateway
+	{
+		logger.Debug("reconciling Gateway")
+		// The Gateway serves the whole host so every path on it is needed.
+		routes, claims, err := r.listLive(namespace, appresources.MakeRouteSelectorNoPath(fields))
+		if err != nil {
+			return err
+		}
+
+		name := v1alpha1.GenerateVirtualServiceName(fields)
+		actual, err := r.gatewayLister.
+			Gateways(v1alpha1.KfNamespace).
+			Get(name)
+
+		desired := resources.MakeGateway(routes, claims, spaceDomain)
+		switch {
+		case desired == nil && errors.IsNotFound(err):
+			// Nothing to serve and nothing to clean up.
+		case err != nil && !errors.IsNotFound(err):
+			return err
+		case desired == nil:
+			// The domain no longer has TLS.
+			if actual.GetDeletionTimestamp() != nil {
+				return nil
+			}
+
+			return r.SharedClientSet.
+				Networking().
+				Gateways(actual.GetNamespace()).
+				Delete(actual.Name, &metav1.DeleteOptions{})
+		case errors.IsNotFound(err):
+			// Gateway doesn't exist, make one.
+			if _, err := r.SharedClientSet.
+				Networking().
+				Gateways(desired.GetNamespace()).
+				Create(desired); err != nil {
+				return err
+			}
+		case actual.GetDeletionTimestamp() != nil:
+			return nil
+		default:
+			if _, err := r.reconcileGateway(desired, actual); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcileSelfSignedSecret makes the self-signed certificate for the
+// domain. The certificate is only regenerated when it's about to expire so
+// clients that chose to trust it keep working until then.
+func (r *Reconciler) reconcileSelfSignedSecret(domain string, now time.Time) error {
+	desired, err := resources.MakeSelfSignedSecret(domain, now)
+	if err != nil {
+		return err
+	}
+
+	actual, err := r.secretLister.
+		Secrets(desired.GetNamespace()).
+		Get(desired.Name)
+	switch {
+	case errors.IsNotFound(err):
+		if _, err := r.KubeClientSet.
+			CoreV1().
+			Secrets(desired.GetNamespace()).
+			Create(desired); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	case err != nil:
+		return err
+	case !resources.SelfSignedNeedsRenewal(actual, now):
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.Type = desired.Type
+	existing.Data = desired.Data
+
+	_, err = r.KubeClientSet.
+		CoreV1().
+		Secrets(existing.GetNamespace()).
+		Update(existing)
+	return err
+}
+
+// reconcileTCPGateway syncs the Gateway and ingress Service that listen on
+// the ports reserved by TCP routes in every space. Both are removed once no
+// ports are reserved.
+func (r *Reconciler) reconcileTCPGateway(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+
+	claims, err := r.routeClaimLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	ports := resources.TCPRoutePorts(claims)
+
+	// Sync Gateway
+	{
+		logger.Debug("reconciling TCP Gateway")
+		desired := resources.MakeTCPGateway(ports)
+		actual, err := r.gatewayLister.
+			Gateways(v1alpha1.KfNamespace).
+			Get(resources.TCPGatewayName)
+
+		switch {
+		case desired == nil && errors.IsNotFound(err):
+			// Nothing to serve and nothing to clean up.
+		case err != nil && !errors.IsNotFound(err):
+			return err
+		case desired == nil:
+			if err := r.SharedClientSet.
+				Networking().
+				Gateways(actual.GetNamespace()).
+				Delete(actual.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		case errors.IsNotFound(err):
+			if _, err := r.SharedClientSet.
+				Networking().
+				Gateways(desired.GetNamespace()).
+				Create(desired); err != nil && !errors.IsAlreadyExists(err) {
+				return err
+			}
+		case actual.GetDeletionTimestamp() != nil:
+			return nil
+		default:
+			if _, err := r.reconcileGateway(desired, actual); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Sync Service
+	{
+		logger.Debug("reconciling TCP gateway Service")
+		desired := resources.MakeTCPGatewayService(ports)
+		actual, err := r.serviceLister.
+			Services(resources.IngressNamespace).
+			Get(resources.TCPGatewayName)
+
+		switch {
+		case desired == nil && errors.IsNotFound(err):
+			// Nothing to expose and nothing to clean up.
+		case err != nil && !errors.IsNotFound(err):
+			return err
+		case desired == nil:
+			if err := r.KubeClientSet.
+				CoreV1().
+				Services(actual.GetNamespace()).
+				Delete(actual.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		case errors.IsNotFound(err):
+			if _, err := r.KubeClientSet.
+				CoreV1().
+				Services(desired.GetNamespace()).
+				Create(desired); err != nil && !errors.IsAlreadyExists(err) {
+				return err
+			}
+		case actual.GetDeletionTimestamp() != nil:
+			return nil
+		case !equality.Semantic.DeepEqual(desired.Spec.Ports, actual.Spec.Ports) ||
+			!equality.Semantic.DeepEqual(desired.Labels, actual.Labels):
+			// Keep the fields the API server fills in, like the cluster IP
+			// and node ports.
+			existing := actual.DeepCopy()
+			existing.Labels = desired.Labels
+			existing.Spec.Type = desired.Spec.Type
+			existing.Spec.Selector = desired.Spec.Selector
+			existing.Spec.Ports = mergeServicePorts(desired.Spec.Ports, actual.Spec.Ports)
+
+			if _, err := r.KubeClientSet.
+				CoreV1().
+				Services(existing.GetNamespace()).
+				Update(existing); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeServicePorts returns the desired ports with the node ports the API
+// server assigned to the actual ones, so existing ports keep their node
+// port.
+func mergeServicePorts(desired, actual []corev1.ServicePort) []corev1.ServicePort {
+	nodePorts := map[int32]int32{}
+	for _, port := range actual {
+		nodePorts[port.Port] = port.NodePort
+	}
+
+	var merged []corev1.ServicePort
+	for _, port := range desired {
+		port.NodePort = nodePorts[port.Port]
+		merged = append(merged, port)
+	}
+
+	return merged
+}
+
+func (r *Reconciler) reconcileGateway(
+	desired *networking.Gateway,
+	actual *networking.Gateway,
+) (*networking.Gateway, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.OwnerReferences, actual.OwnerReferences)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff Gateway: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.ObjectMeta.Annotations = desired.ObjectMeta.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec = desired.Spec
+
+	return r.SharedClientSet.
+		Networking().
+		Gateways(existing.GetNamespace()).
+		Update(existing)
+}
+
+// replaceVirtualService overwrites the routing of the VirtualService with the
+// desired one, see finalizeHost.
+func (r *Reconciler) replaceVirtualService(
+	desired *networking.VirtualService,
+	actual *networking.VirtualService,
+) (*networking.VirtualService, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.OwnerReferences, actual.OwnerReferences)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.ObjectMeta.Annotations = desired.ObjectMeta.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec = desired.Spec
+
+	return r.SharedClientSet.
+		Networking().
+		VirtualServices(existing.GetNamespace()).
+		Update(existing)
+}
+
 func (r *Reconciler) reconcile(
 	desired *networking.VirtualService,
 	actual *networking.VirtualService,
@@ -174,22 +965,44 @@ func (r *Reconciler) reconcile(
 	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
 	existing.ObjectMeta.Annotations = desired.ObjectMeta.Annotations
 
+	// The gateways change if the route's domain becomes internal or public.
+	existing.Spec.Gateways = desired.Spec.Gateways
+
 	// Merge new OwnerReferences and HTTPRoutes
 	existing.OwnerReferences = algorithms.Merge(
 		v1alpha1.OwnerReferences(existing.OwnerReferences),
 		v1alpha1.OwnerReferences(desired.OwnerReferences),
 	).(v1alpha1.OwnerReferences)
 
-	existing.Spec.HTTP = algorithms.Merge(
-		v1alpha1.HTTPRoutes(existing.Spec.HTTP),
-		v1alpha1.HTTPRoutes(desired.Spec.HTTP),
-	).(v1alpha1.HTTPRoutes)
+	// TCP routes are rebuilt from every Route on the port so they replace
+	// the existing ones.
+	existing.Spec.TCP = desired.Spec.TCP
 
-	// Sort by reverse to defer to the longest matchers.
-	sort.Sort(sort.Reverse(v1alpha1.HTTPRoutes(existing.Spec.HTTP)))
+	// The desired HTTPRoutes are built from every Route on the path so they
+	// replace the path's existing ones, merging them would keep the routing
+	// of matches that were removed.
+	existing.Spec.HTTP = resources.ReplacePathHTTPRoutes(existing.Spec.HTTP, desired.Spec.HTTP)
 
 	return r.SharedClientSet.
 		Networking().
 		VirtualServices(existing.GetNamespace()).
 		Update(existing)
 }
+
+func (r *Reconciler) updateStatus(namespace string, desired *v1alpha1.Route) (*v1alpha1.Route, error) {
+	actual, err := r.routeLister.Routes(namespace).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().Routes(namespace).UpdateStatus(existing)
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMakeSelfSignedSecret(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2019, time.September, 1, 0, 0, 0, 0, time.UTC)
+	secret, err := resources.MakeSelfSignedSecret("example.com", now)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "namespace", resources.IngressNamespace, secret.Namespace)
+	testutil.AssertEqual(t, "type", corev1.SecretTypeTLS, secret.Type)
+
+	pair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	testutil.AssertNil(t, "key pair err", err)
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	testutil.AssertNil(t, "parse err", err)
+
+	testutil.AssertEqual(t, "DNSNames", []string{"example.com", "*.example.com"}, cert.DNSNames)
+	testutil.AssertEqual(t, "NotAfter", now.Add(resources.SelfSignedValidity), cert.NotAfter)
+	testutil.AssertNil(t, "verify err", cert.VerifyHostname("some-host.example.com"))
+}
+
+func TestSelfSignedNeedsRenewal(t *testing.T) {
+	t.Parallel()
+
+	issued := time.Date(2019, time.September, 1, 0, 0, 0, 0, time.UTC)
+	secret, err := resources.MakeSelfSignedSecret("example.com", issued)
+	testutil.AssertNil(t, "err", err)
+
+	expires := issued.Add(resources.SelfSignedValidity)
+
+	cases := map[string]struct {
+		secret *corev1.Secret
+		now    time.Time
+		want   bool
+	}{
+		"new": {
+			secret: secret,
+			now:    issued,
+			want:   false,
+		},
+		"just before the renewal window": {
+			secret: secret,
+			now:    expires.Add(-resources.SelfSignedRenewBefore - time.Second),
+			want:   false,
+		},
+		"in the renewal window": {
+			secret: secret,
+			now:    expires.Add(-resources.SelfSignedRenewBefore),
+			want:   true,
+		},
+		"expired": {
+			secret: secret,
+			now:    expires.Add(time.Hour),
+			want:   true,
+		},
+		"unreadable certificate": {
+			secret: &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: []byte("garbage")}},
+			now:    issued,
+			want:   true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "needs renewal", tc.want, resources.SelfSignedNeedsRenewal(tc.secret, tc.now))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultsConfigName is the ConfigMap in the kf namespace that holds the
+	// cluster-wide defaults.
+	DefaultsConfigName = "config-defaults"
+
+	// DefaultBackendKey and DefaultBackendRedirectURLKey set the cluster-wide
+	// default backend in DefaultsConfigName.
+	DefaultBackendKey            = "route-default-backend"
+	DefaultBackendRedirectURLKey = "route-default-backend-redirect-url"
+
+	// DefaultBackendServiceName is the Service in the kf namespace that
+	// serves the "no app mapped" page.
+	DefaultBackendServiceName = "kf-default-backend"
+	DefaultBackendServicePort = 80
+
+	// DefaultBackendRouteHeader and DefaultBackendSpaceHeader tell the page
+	// which route and space the request was for.
+	DefaultBackendRouteHeader = "X-Kf-Route"
+	DefaultBackendSpaceHeader = "X-Kf-Space"
+)
+
+// DefaultBackendFromConfigMap reads the cluster-wide default backend from
+// the config-defaults ConfigMap. It returns nil if the ConfigMap doesn't set
+// one.
+func DefaultBackendFromConfigMap(cm *corev1.ConfigMap) (*v1alpha1.RouteDefaultBackend, error) {
+	if cm == nil || cm.Data[DefaultBackendKey] == "" {
+		return nil, nil
+	}
+
+	backend := &v1alpha1.RouteDefaultBackend{
+		Type:        v1alpha1.RouteDefaultBackendType(cm.Data[DefaultBackendKey]),
+		RedirectURL: cm.Data[DefaultBackendRedirectURLKey],
+	}
+
+	if err := backend.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %s", DefaultBackendKey, DefaultsConfigName, err)
+	}
+
+	return backend, nil
+}
+
+// SelectDefaultBackend returns the default backend of the space, falling
+// back to the cluster-wide one.
+func SelectDefaultBackend(space *v1alpha1.Space, cluster *v1alpha1.RouteDefaultBackend) *v1alpha1.RouteDefaultBackend {
+	if space != nil && space.Spec.Execution.DefaultBackend != nil {
+		return space.Spec.Execution.DefaultBackend
+	}
+
+	return cluster
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDefaultBackendFromConfigMap(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		data    map[string]string
+		want    *v1alpha1.RouteDefaultBackend
+		wantErr error
+	}{
+		"not set": {
+			data: map[string]string{"_example": "route-default-backend: page"},
+		},
+		"page": {
+			data: map[string]string{resources.DefaultBackendKey: "page"},
+			want: &v1alpha1.RouteDefaultBackend{Type: v1alpha1.RouteDefaultBackendPage},
+		},
+		"redirect": {
+			data: map[string]string{
+				resources.DefaultBackendKey:            "redirect",
+				resources.DefaultBackendRedirectURLKey: "https://example.com/not-found",
+			},
+			want: &v1alpha1.RouteDefaultBackend{
+				Type:        v1alpha1.RouteDefaultBackendRedirect,
+				RedirectURL: "https://example.com/not-found",
+			},
+		},
+		"redirect without URL": {
+			data:    map[string]string{resources.DefaultBackendKey: "redirect"},
+			wantErr: errors.New("invalid route-default-backend in config-defaults: missing field(s): redirectURL"),
+		},
+		"unknown type": {
+			data:    map[string]string{resources.DefaultBackendKey: "teapot"},
+			wantErr: errors.New("invalid route-default-backend in config-defaults: invalid value: teapot: type"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			got, err := resources.DefaultBackendFromConfigMap(&corev1.ConfigMap{Data: tc.data})
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "default backend", tc.want, got)
+		})
+	}
+}
+
+func TestSelectDefaultBackend(t *testing.T) {
+	t.Parallel()
+
+	cluster := &v1alpha1.RouteDefaultBackend{Type: v1alpha1.RouteDefaultBackendPage}
+	spaceBackend := &v1alpha1.RouteDefaultBackend{
+		Type:        v1alpha1.RouteDefaultBackendRedirect,
+		RedirectURL: "https://example.com",
+	}
+
+	space := &v1alpha1.Space{}
+	testutil.AssertEqual(t, "cluster backend", cluster, resources.SelectDefaultBackend(space, cluster))
+
+	space.Spec.Execution.DefaultBackend = spaceBackend
+	testutil.AssertEqual(t, "space backend", spaceBackend, resources.SelectDefaultBackend(space, cluster))
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+)
+
+const (
+	// IngressNamespace is the namespace of the Istio ingress gateway. Secrets
+	// holding TLS certificates must be in it to be used by the gateway.
+	IngressNamespace = "istio-system"
+
+	// ComponentGateway is the value of the ComponentLabel on Gateways made by
+	// MakeGateway.
+	ComponentGateway = "gateway"
+)
+
+// TLSSecretName returns the name of the Secret holding the certificate for
+// the domain. Self-signed certificates are stored in a Secret named after
+// the domain.
+func TLSSecretName(spaceDomain v1alpha1.SpaceDomain) string {
+	if spaceDomain.TLS == nil {
+		return ""
+	}
+
+	if spaceDomain.TLS.SelfSigned {
+		return v1alpha1.GenerateName("kf", spaceDomain.Domain, "tls")
+	}
+
+	return spaceDomain.TLS.SecretName
+}
+
+// MakeGateway creates a Gateway that serves HTTPS for the host of the routes
+// using the certificate of their domain. The Gateway shares the name of the
+// routes' VirtualService, which is attached to it.
+//
+// If any of the claims on the host asks for an HTTPS redirect, the Gateway
+// also redirects plain HTTP requests for the host. Claims for other hosts
+// are ignored.
+//
+// nil is returned if the domain doesn't have TLS or the routes carry TCP
+// traffic.
+func MakeGateway(routes []*v1alpha1.Route, claims []*v1alpha1.RouteClaim, spaceDomain v1alpha1.SpaceDomain) *networking.Gateway {
+	if len(routes) == 0 || spaceDomain.TLS == nil || spaceDomain.Internal || spaceDomain.TCP {
+		return nil
+	}
+
+	spec := routes[0].Spec.RouteSpecFields
+	if spec.Port != 0 {
+		return nil
+	}
+
+	hostDomain := spec.Domain
+	if spec.Hostname != "" {
+		hostDomain = spec.Hostname + "." + spec.Domain
+	}
+
+	servers := []networking.Server{
+		{
+			Port: networking.Port{
+				Number:   443,
+				Name:     "https",
+				Protocol: networking.ProtocolHTTPS,
+			},
+			Hosts: []string{hostDomain},
+			TLS: &networking.TLSOptions{
+				Mode:           networking.TLSModeSimple,
+				CredentialName: TLSSecretName(spaceDomain),
+			},
+		},
+	}
+
+	for _, claim := range claims {
+		if !claim.Spec.HTTPSRedirect ||
+			claim.Spec.Hostname != spec.Hostname ||
+			claim.Spec.Domain != spec.Domain ||
+			claim.Spec.Port != 0 {
+			continue
+		}
+
+		servers = append(servers, networking.Server{
+			Port: networking.Port{
+				Number:   80,
+				Name:     "http",
+				Protocol: networking.ProtocolHTTP,
+			},
+			Hosts: []string{hostDomain},
+			TLS: &networking.TLSOptions{
+				HTTPSRedirect: true,
+			},
+		})
+		break
+	}
+
+	labels := MakeVirtualServiceLabels(spec)
+	labels[v1alpha1.ComponentLabel] = ComponentGateway
+
+	return &networking.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "Gateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            v1alpha1.GenerateVirtualServiceName(spec),
+			Namespace:       v1alpha1.KfNamespace,
+			OwnerReferences: makeOwnerReferences(routes),
+			Labels:          labels,
+			Annotations: map[string]string{
+				"domain":   spec.Domain,
+				"hostname": spec.Hostname,
+				"space":    routes[0].Namespace,
+			},
+		},
+		Spec: networking.GatewaySpec{
+			Selector: map[string]string{
+				"istio": "ingressgateway",
+			},
+			Servers: servers,
+		},
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+)
+
+func TestMakeGateway(t *testing.T) {
+	t.Parallel()
+
+	route := &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-route",
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "some-host",
+				Domain:   "example.com",
+			},
+		},
+	}
+
+	claim := func(redirect bool) *v1alpha1.RouteClaim {
+		return &v1alpha1.RouteClaim{
+			Spec: v1alpha1.RouteClaimSpec{
+				RouteSpecFields: route.Spec.RouteSpecFields,
+				HTTPSRedirect:   redirect,
+			},
+		}
+	}
+
+	secretDomain := v1alpha1.SpaceDomain{
+		Domain: "example.com",
+		TLS:    &v1alpha1.DomainTLS{SecretName: "example-com-tls"},
+	}
+
+	for tn, tc := range map[string]struct {
+		routes []*v1alpha1.Route
+		claims []*v1alpha1.RouteClaim
+		domain v1alpha1.SpaceDomain
+		assert func(t *testing.T, g *networking.Gateway)
+	}{
+		"no TLS": {
+			routes: []*v1alpha1.Route{route},
+			domain: v1alpha1.SpaceDomain{Domain: "example.com"},
+			assert: func(t *testing.T, g *networking.Gateway) {
+				testutil.AssertEqual(t, "gateway", (*networking.Gateway)(nil), g)
+			},
+		},
+		"no routes": {
+			domain: secretDomain,
+			assert: func(t *testing.T, g *networking.Gateway) {
+				testutil.AssertEqual(t, "gateway", (*networking.Gateway)(nil), g)
+			},
+		},
+		"TLS secret": {
+			routes: []*v1alpha1.Route{route},
+			claims: []*v1alpha1.RouteClaim{claim(false)},
+			domain: secretDomain,
+			assert: func(t *testing.T, g *networking.Gateway) {
+				testutil.AssertEqual(t, "name", v1alpha1.GenerateVirtualServiceName(route.Spec.RouteSpecFields), g.Name)
+				testutil.AssertEqual(t, "namespace", v1alpha1.KfNamespace, g.Namespace)
+				testutil.AssertEqual(t, "servers", []networking.Server{
+					{
+						Port:  networking.Port{Number: 443, Name: "https", Protocol: networking.ProtocolHTTPS},
+						Hosts: []string{"some-host.example.com"},
+						TLS: &networking.TLSOptions{
+							Mode:           networking.TLSModeSimple,
+							CredentialName: "example-com-tls",
+						},
+					},
+				}, g.Spec.Servers)
+			},
+		},
+		"self-signed": {
+			routes: []*v1alpha1.Route{route},
+			domain: v1alpha1.SpaceDomain{Domain: "example.com", TLS: &v1alpha1.DomainTLS{SelfSigned: true}},
+			assert: func(t *testing.T, g *networking.Gateway) {
+				testutil.AssertEqual(t, "credential", v1alpha1.GenerateName("kf", "example.com", "tls"), g.Spec.Servers[0].TLS.CredentialName)
+			},
+		},
+		"HTTPS redirect": {
+			routes: []*v1alpha1.Route{route},
+			claims: []*v1alpha1.RouteClaim{claim(false), claim(true), claim(true)},
+			domain: secretDomain,
+			assert: func(t *testing.T, g *networking.Gateway) {
+				testutil.AssertEqual(t, "servers len", 2, len(g.Spec.Servers))
+				testutil.AssertEqual(t, "redirect", networking.Server{
+					Port:  networking.Port{Number: 80, Name: "http", Protocol: networking.ProtocolHTTP},
+					Hosts: []string{"some-host.example.com"},
+					TLS:   &networking.TLSOptions{HTTPSRedirect: true},
+				}, g.Spec.Servers[1])
+			},
+		},
+		"HTTPS redirect on other host": {
+			routes: []*v1alpha1.Route{route},
+			claims: []*v1alpha1.RouteClaim{
+				{Spec: v1alpha1.RouteClaimSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{Hostname: "other-host", Domain: "example.com"},
+					HTTPSRedirect:   true,
+				}},
+			},
+			domain: secretDomain,
+			assert: func(t *testing.T, g *networking.Gateway) {
+				testutil.AssertEqual(t, "servers len", 1, len(g.Spec.Servers))
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			tc.assert(t, resources.MakeGateway(tc.routes, tc.claims, tc.domain))
+		})
+	}
+}
+
+func ExampleTLSSecretName() {
+	fmt.Println("None:", resources.TLSSecretName(v1alpha1.SpaceDomain{Domain: "example.com"}))
+	fmt.Println("Secret:", resources.TLSSecretName(v1alpha1.SpaceDomain{
+		Domain: "example.com",
+		TLS:    &v1alpha1.DomainTLS{SecretName: "my-cert"},
+	}))
+
+	// Output: None:
+	// Secret: my-cert
+}
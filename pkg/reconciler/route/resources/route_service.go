@@ -0,0 +1,177 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/routeservice"
+	"github.com/knative/serving/pkg/network"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	istio "knative.dev/pkg/apis/istio/common/v1alpha1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+)
+
+const (
+	// RouteServiceProxyServiceName is the Service in the kf namespace that
+	// sends requests through route services, see the routeservice package.
+	RouteServiceProxyServiceName = "kf-route-service-proxy"
+	RouteServiceProxyServicePort = 80
+
+	// RouteServiceKeySecretName is the Secret in the kf namespace holding the
+	// key route service signatures and tokens are made with. The proxy
+	// mounts it.
+	RouteServiceKeySecretName = "kf-route-service-key"
+	RouteServiceKeySecretKey  = "key"
+
+	// routeServiceKeySize is the number of random bytes in a new key.
+	routeServiceKeySize = 32
+)
+
+// MakeRouteServiceKeySecret creates the Secret holding a new random key for
+// route services.
+func MakeRouteServiceKeySecret() (*corev1.Secret, error) {
+	key := make([]byte, routeServiceKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate route service key: %s", err)
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RouteServiceKeySecretName,
+			Namespace: v1alpha1.KfNamespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			RouteServiceKeySecretKey: key,
+		},
+	}, nil
+}
+
+// buildRouteServiceHTTPRoutes sends the traffic matched by appRoutes to the
+// route service proxy instead. The proxy sends requests to the claim's route
+// service and checks the signature of the requests it forwards back. Checked
+// requests are sent back through the gateway with the claim's token, those
+// match the verified copy of appRoutes and reach the Apps.
+func buildRouteServiceHTTPRoutes(
+	claim *v1alpha1.RouteClaim,
+	appRoutes []networking.HTTPRoute,
+	gatewayHost string,
+	key []byte,
+) ([]networking.HTTPRoute, error) {
+	binding := claim.Spec.RouteService
+
+	serviceURL, err := url.Parse(binding.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid route service URL: %s", err)
+	}
+
+	if (serviceURL.Scheme != "http" && serviceURL.Scheme != "https") || serviceURL.Host == "" {
+		return nil, fmt.Errorf("invalid route service URL: %q must be an http or https URL", binding.URL)
+	}
+
+	if len(key) == 0 {
+		return nil, errors.New("the route service key is missing")
+	}
+
+	token := routeservice.Token(key, string(claim.UID), binding.URL)
+
+	metadata, err := json.Marshal(map[string]string{
+		"space":            claim.Namespace,
+		"service_instance": binding.InstanceName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var httpRoutes []networking.HTTPRoute
+	for _, appRoute := range appRoutes {
+		var (
+			verifiedRoute = *appRoute.DeepCopy()
+			verifiedMatch []networking.HTTPMatchRequest
+		)
+
+		for _, match := range verifiedRoute.Match {
+			headers := map[string]istio.StringMatch{}
+			for name, headerMatch := range match.Headers {
+				headers[name] = headerMatch
+			}
+			headers[strings.ToLower(routeservice.TokenHeader)] = istio.StringMatch{Exact: token}
+
+			match.Headers = headers
+			verifiedMatch = append(verifiedMatch, match)
+		}
+		verifiedRoute.Match = verifiedMatch
+
+		// The token must not leak to the Apps.
+		for i := range verifiedRoute.Route {
+			destination := &verifiedRoute.Route[i]
+			if destination.Headers == nil {
+				destination.Headers = &networking.Headers{}
+			}
+			if destination.Headers.Request == nil {
+				destination.Headers.Request = &networking.HeaderOperations{}
+			}
+			destination.Headers.Request.Remove = append(
+				destination.Headers.Request.Remove,
+				routeservice.TokenHeader,
+				routeservice.HopHeader,
+			)
+		}
+
+		httpRoutes = append(httpRoutes, verifiedRoute, networking.HTTPRoute{
+			Match: appRoute.Match,
+			Route: []networking.HTTPRouteDestination{
+				{
+					Destination: networking.Destination{
+						Host: network.GetServiceHostname(RouteServiceProxyServiceName, v1alpha1.KfNamespace),
+						Port: networking.PortSelector{
+							Number: RouteServiceProxyServicePort,
+						},
+					},
+					Weight: 100,
+					Headers: &networking.Headers{
+						Request: &networking.HeaderOperations{
+							Set: map[string]string{
+								routeservice.URLHeader:           binding.URL,
+								routeservice.TokenHeader:         token,
+								routeservice.GatewayHeader:       gatewayHost,
+								routeservice.ProxyMetadataHeader: base64.StdEncoding.EncodeToString(metadata),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return httpRoutes, nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+)
+
+func TestMakeRouteServiceKeySecret(t *testing.T) {
+	t.Parallel()
+
+	a, err := resources.MakeRouteServiceKeySecret()
+	testutil.AssertNil(t, "err", err)
+
+	b, err := resources.MakeRouteServiceKeySecret()
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "name", resources.RouteServiceKeySecretName, a.Name)
+	testutil.AssertEqual(t, "namespace", "kf", a.Namespace)
+	testutil.AssertEqual(t, "key length", 32, len(a.Data[resources.RouteServiceKeySecretKey]))
+	testutil.AssertEqual(t, "keys differ", false, string(a.Data[resources.RouteServiceKeySecretKey]) == string(b.Data[resources.RouteServiceKeySecretKey]))
+}
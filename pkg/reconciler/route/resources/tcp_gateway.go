@@ -0,0 +1,146 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+)
+
+const (
+	// TCPGatewayName is the name of the Gateway in the kf namespace that
+	// listens on the ports of TCP routes, see KfTCPGateway, and of the
+	// Service in the IngressNamespace that exposes those ports.
+	TCPGatewayName = "kf-tcp-gateway"
+
+	// ComponentTCPGateway is the value of the ComponentLabel on the
+	// resources made by MakeTCPGateway and MakeTCPGatewayService.
+	ComponentTCPGateway = "tcp-gateway"
+)
+
+// ingressGatewaySelector selects the Pods of Istio's ingress gateway, TCP
+// routes are served by the same Pods as HTTP routes.
+var ingressGatewaySelector = map[string]string{
+	"istio": "ingressgateway",
+}
+
+// TCPRoutePorts gets the ports reserved by the claims in ascending order.
+// Claims that are being deleted no longer hold their port.
+func TCPRoutePorts(claims []*v1alpha1.RouteClaim) []int32 {
+	seen := map[int32]bool{}
+	var ports []int32
+	for _, claim := range claims {
+		port := claim.Spec.Port
+		if port == 0 || seen[port] || claim.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		seen[port] = true
+		ports = append(ports, port)
+	}
+
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports
+}
+
+// MakeTCPGateway creates the Gateway that listens on the ports of the TCP
+// routes. The VirtualServices of TCP routes are attached to it. nil is
+// returned if there are no ports because Gateways need a server.
+func MakeTCPGateway(ports []int32) *networking.Gateway {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	var servers []networking.Server
+	for _, port := range ports {
+		servers = append(servers, networking.Server{
+			Port: networking.Port{
+				Number:   int(port),
+				Name:     tcpPortName(port),
+				Protocol: networking.ProtocolTCP,
+			},
+			Hosts: []string{"*"},
+		})
+	}
+
+	return &networking.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "Gateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TCPGatewayName,
+			Namespace: v1alpha1.KfNamespace,
+			Labels:    makeTCPGatewayLabels(),
+		},
+		Spec: networking.GatewaySpec{
+			Selector: ingressGatewaySelector,
+			Servers:  servers,
+		},
+	}
+}
+
+// MakeTCPGatewayService creates the LoadBalancer Service that sends
+// connections to the ports of TCP routes to the ingress gateway. nil is
+// returned if there are no ports.
+func MakeTCPGatewayService(ports []int32) *corev1.Service {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	var servicePorts []corev1.ServicePort
+	for _, port := range ports {
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       tcpPortName(port),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt(int(port)),
+		})
+	}
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TCPGatewayName,
+			Namespace: IngressNamespace,
+			Labels:    makeTCPGatewayLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: ingressGatewaySelector,
+			Ports:    servicePorts,
+		},
+	}
+}
+
+func makeTCPGatewayLabels() map[string]string {
+	return map[string]string{
+		v1alpha1.ManagedByLabel: "kf",
+		v1alpha1.ComponentLabel: ComponentTCPGateway,
+	}
+}
+
+func tcpPortName(port int32) string {
+	return fmt.Sprintf("tcp-%d", port)
+}
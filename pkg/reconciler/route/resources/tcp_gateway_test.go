@@ -0,0 +1,92 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+)
+
+func TestTCPRoutePorts(t *testing.T) {
+	t.Parallel()
+
+	claim := func(port int32, deleted bool) *v1alpha1.RouteClaim {
+		c := &v1alpha1.RouteClaim{}
+		c.Spec.Domain = "tcp.example.com"
+		c.Spec.Port = port
+		if deleted {
+			now := metav1.Now()
+			c.DeletionTimestamp = &now
+		}
+		return c
+	}
+
+	ports := resources.TCPRoutePorts([]*v1alpha1.RouteClaim{
+		claim(2000, false),
+		claim(0, false),
+		claim(1024, false),
+		claim(2000, false),
+		claim(3000, true),
+	})
+
+	testutil.AssertEqual(t, "ports", []int32{1024, 2000}, ports)
+}
+
+func TestMakeTCPGateway(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "no ports", (*networking.Gateway)(nil), resources.MakeTCPGateway(nil))
+
+	gateway := resources.MakeTCPGateway([]int32{1024, 2000})
+	testutil.AssertEqual(t, "name", "kf-tcp-gateway", gateway.Name)
+	testutil.AssertEqual(t, "namespace", "kf", gateway.Namespace)
+	testutil.AssertEqual(t, "selector", map[string]string{"istio": "ingressgateway"}, gateway.Spec.Selector)
+	testutil.AssertEqual(t, "servers", []networking.Server{
+		{
+			Port:  networking.Port{Number: 1024, Name: "tcp-1024", Protocol: networking.ProtocolTCP},
+			Hosts: []string{"*"},
+		},
+		{
+			Port:  networking.Port{Number: 2000, Name: "tcp-2000", Protocol: networking.ProtocolTCP},
+			Hosts: []string{"*"},
+		},
+	}, gateway.Spec.Servers)
+}
+
+func TestMakeTCPGatewayService(t *testing.T) {
+	t.Parallel()
+
+	testutil.AssertEqual(t, "no ports", (*corev1.Service)(nil), resources.MakeTCPGatewayService(nil))
+
+	service := resources.MakeTCPGatewayService([]int32{1024})
+	testutil.AssertEqual(t, "name", "kf-tcp-gateway", service.Name)
+	testutil.AssertEqual(t, "namespace", resources.IngressNamespace, service.Namespace)
+	testutil.AssertEqual(t, "type", corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+	testutil.AssertEqual(t, "ports", []corev1.ServicePort{
+		{
+			Name:       "tcp-1024",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       1024,
+			TargetPort: intstr.FromInt(1024),
+		},
+	}, service.Spec.Ports)
+}
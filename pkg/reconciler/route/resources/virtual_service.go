@@ -18,13 +18,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/algorithms"
+	appresources "github.com/google/kf/pkg/reconciler/app/resources"
 	"github.com/gorilla/mux"
 	"github.com/knative/serving/pkg/network"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	istio "knative.dev/pkg/apis/istio/common/v1alpha1"
 	networking "knative.dev/pkg/apis/istio/v1alpha3"
 	"knative.dev/pkg/kmeta"
@@ -34,25 +41,81 @@ const (
 	ManagedByLabel        = "app.kubernetes.io/managed-by"
 	KnativeIngressGateway = "knative-ingress-gateway.knative-serving.svc.cluster.local"
 	GatewayHost           = "istio-ingressgateway.istio-system.svc.cluster.local"
+
+	// KnativeClusterLocalGateway and ClusterLocalGatewayHost are used instead
+	// of the public gateway for routes on internal domains.
+	KnativeClusterLocalGateway = "cluster-local-gateway.knative-serving.svc.cluster.local"
+	ClusterLocalGatewayHost    = "cluster-local-gateway.istio-system.svc.cluster.local"
+
+	// KfTCPGateway carries the traffic of TCP routes. It listens on the
+	// ports reserved by TCP routes, see MakeTCPGateway.
+	KfTCPGateway = TCPGatewayName + ".kf.svc.cluster.local"
+
+	// MeshGateway is Istio's reserved name for the sidecars of every Pod in
+	// the mesh.
+	MeshGateway = "mesh"
+
+	// DefaultPerTryTimeout is used for retries when a traffic policy doesn't
+	// set a timeout.
+	DefaultPerTryTimeout = "15s"
 )
 
 // MakeVirtualServiceLabels creates Labels that can be used to tie a
 // VirtualService to a Route.
 func MakeVirtualServiceLabels(spec v1alpha1.RouteSpecFields) map[string]string {
-	return map[string]string{
+	l := map[string]string{
 		v1alpha1.ManagedByLabel: "kf",
 		v1alpha1.ComponentLabel: "virtualservice",
 		v1alpha1.RouteHostname:  spec.Hostname,
 		v1alpha1.RouteDomain:    spec.Domain,
 	}
+
+	if spec.Port != 0 {
+		l[v1alpha1.RoutePort] = strconv.Itoa(int(spec.Port))
+	}
+
+	return l
 }
 
 // MakeVirtualService creates a VirtualService from a Route object.
-func MakeVirtualService(routes []*v1alpha1.Route) (*networking.VirtualService, error) {
+//
+// The claims are the RouteClaims in the routes' space. If the claim for a
+// path has a route service bound, traffic is sent through it by the route
+// service proxy before it reaches the Apps. The routeServiceKey is the key
+// in the RouteServiceKeySecretName Secret, it's only needed if a route
+// service is bound.
+//
+// The claim for a path can also carry a traffic policy, its timeout,
+// retries, CORS policy and header changes are applied to the path's
+// HTTPRoutes.
+//
+// The domain holds the space's settings for the routes' domain. Routes on
+// internal domains are only attached to the cluster-local gateway and the
+// sidecars in the mesh so they can't be reached from outside the cluster.
+// Routes on domains with TLS are also attached to the Gateway made by
+// MakeGateway, which serves HTTPS for the host.
+//
+// Requests to a path that no App receives are served by the default
+// backend, see buildDefaultBackendHTTPRoute. A nil default backend responds
+// with a 503.
+//
+// Routes with a port get a VirtualService of their own that forwards raw TCP
+// traffic from the port to the Apps, see makeTCPVirtualService.
+func MakeVirtualService(
+	routes []*v1alpha1.Route,
+	claims []*v1alpha1.RouteClaim,
+	spaceDomain v1alpha1.SpaceDomain,
+	defaultBackend *v1alpha1.RouteDefaultBackend,
+	routeServiceKey []byte,
+) (*networking.VirtualService, error) {
 	if len(routes) == 0 {
 		return nil, errors.New("routes must not be empty")
 	}
 
+	if routes[0].Spec.Port != 0 {
+		return makeTCPVirtualService(routes), nil
+	}
+
 	namespace := routes[0].Namespace
 	hostname := routes[0].Spec.RouteSpecFields.Hostname
 	domain := routes[0].Spec.RouteSpecFields.Domain
@@ -63,48 +126,81 @@ func MakeVirtualService(routes []*v1alpha1.Route) (*networking.VirtualService, e
 		hostDomain = hostname + "." + domain
 	}
 
+	name := v1alpha1.GenerateVirtualServiceName(routes[0].Spec.RouteSpecFields)
+
+	gateways := []string{KnativeIngressGateway}
+	gatewayHost := GatewayHost
+	switch {
+	case spaceDomain.Internal:
+		gateways = []string{KnativeClusterLocalGateway, MeshGateway}
+		gatewayHost = ClusterLocalGatewayHost
+	case spaceDomain.TLS != nil:
+		gateways = append(gateways, network.GetServiceHostname(name, v1alpha1.KfNamespace))
+	}
+
 	var (
-		ownerRefs  []metav1.OwnerReference
-		appNames   []string
+		urlPaths   []string
+		pathApps   = map[string][]v1alpha1.RouteSpec{}
 		httpRoutes []networking.HTTPRoute
 	)
 	for _, route := range routes {
-		// Each route will own the VirtualService. Therefore none of them can
-		// be a controller.
-		ownerRef := *kmeta.NewControllerRef(route)
-		ownerRef.Controller = nil
-		ownerRef.BlockOwnerDeletion = nil
-		ownerRefs = append(ownerRefs, ownerRef)
-		urlPath := route.Spec.RouteSpecFields.Path
+		urlPath := path.Join("/", route.Spec.RouteSpecFields.Path)
 
-		// AppNames
+		if _, ok := pathApps[urlPath]; !ok {
+			urlPaths = append(urlPaths, urlPath)
+			pathApps[urlPath] = nil
+		}
+
+		// Apps bound to the path
 		if route.Spec.AppName != "" {
-			appNames = append(appNames, route.Spec.AppName)
+			pathApps[urlPath] = append(pathApps[urlPath], route.Spec)
 		}
+	}
 
-		httpRoute, err := buildHTTPRoute(namespace, urlPath, appNames)
+	for _, urlPath := range urlPaths {
+		httpRoute, err := buildHTTPRoute(namespace, hostDomain, urlPath, pathApps[urlPath], gatewayHost, defaultBackend)
 		if err != nil {
 			return nil, err
 		}
 
+		for _, claim := range claims {
+			if claim.Spec.Hostname != hostname ||
+				claim.Spec.Domain != domain ||
+				path.Join("/", claim.Spec.Path) != urlPath ||
+				len(pathApps[urlPath]) == 0 {
+				continue
+			}
+
+			if claim.Spec.RouteService != nil {
+				httpRoute, err = buildRouteServiceHTTPRoutes(claim, httpRoute, gatewayHost, routeServiceKey)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			applyTrafficPolicy(claim.Spec.TrafficPolicy, httpRoute)
+		}
+
 		httpRoutes = algorithms.Merge(
 			v1alpha1.HTTPRoutes(httpRoutes),
 			v1alpha1.HTTPRoutes(httpRoute),
 		).(v1alpha1.HTTPRoutes)
 	}
 
+	// Sort by reverse to defer to the longest matchers, this puts requests
+	// checked by the route service proxy ahead of the ones that still need
+	// to go through it.
+	sort.Sort(sort.Reverse(v1alpha1.HTTPRoutes(httpRoutes)))
+
 	return &networking.VirtualService{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "networking.istio.io/v1alpha3",
 			Kind:       "VirtualService",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: v1alpha1.GenerateName(
-				hostname,
-				domain,
-			),
+			Name:            name,
 			Namespace:       v1alpha1.KfNamespace,
-			OwnerReferences: ownerRefs,
+			OwnerReferences: makeOwnerReferences(routes),
 			Labels:          labels,
 			Annotations: map[string]string{
 				"domain":   domain,
@@ -113,15 +209,151 @@ func MakeVirtualService(routes []*v1alpha1.Route) (*networking.VirtualService, e
 			},
 		},
 		Spec: networking.VirtualServiceSpec{
-			Gateways: []string{KnativeIngressGateway},
+			Gateways: gateways,
 			Hosts:    []string{hostDomain},
 			HTTP:     httpRoutes,
 		},
 	}, nil
 }
 
-func buildHTTPRoute(namespace, urlPath string, appNames []string) ([]networking.HTTPRoute, error) {
+// ReplacePathHTTPRoutes replaces the HTTPRoutes in existing that match the
+// same URIs as the desired ones. The desired HTTPRoutes are rebuilt from
+// every Route on their path, so any existing HTTPRoute for the path that
+// isn't desired anymore (e.g. the Route's match changed) is dropped. The
+// HTTPRoutes of other paths on the host are kept.
+func ReplacePathHTTPRoutes(existing, desired []networking.HTTPRoute) []networking.HTTPRoute {
+	desiredURIs := sets.NewString()
+	for _, httpRoute := range desired {
+		desiredURIs.Insert(httpRouteURIs(httpRoute)...)
+	}
+
+	var httpRoutes []networking.HTTPRoute
+	for _, httpRoute := range existing {
+		if !desiredURIs.HasAny(httpRouteURIs(httpRoute)...) {
+			httpRoutes = append(httpRoutes, httpRoute)
+		}
+	}
+	httpRoutes = append(httpRoutes, desired...)
+
+	// Sort by reverse to defer to the longest matchers.
+	sort.Sort(sort.Reverse(v1alpha1.HTTPRoutes(httpRoutes)))
+
+	return httpRoutes
+}
+
+func httpRouteURIs(httpRoute networking.HTTPRoute) []string {
+	var uris []string
+	for _, match := range httpRoute.Match {
+		if match.URI != nil {
+			uris = append(uris, fmt.Sprintf("%+v", *match.URI))
+		}
+	}
+	return uris
+}
+
+// makeTCPVirtualService creates a VirtualService for Routes that share a TCP
+// port. Connections to the port on the TCP gateway are split between the
+// Apps bound to the port. If no Apps are bound, the port doesn't accept
+// connections.
+func makeTCPVirtualService(routes []*v1alpha1.Route) *networking.VirtualService {
+	namespace := routes[0].Namespace
+	spec := routes[0].Spec.RouteSpecFields
+
+	var (
+		appNames []string
+		weights  []*int
+	)
+	for _, route := range routes {
+		if route.Spec.AppName == "" {
+			continue
+		}
+
+		appNames = append(appNames, route.Spec.AppName)
+		weights = append(weights, route.Spec.Weight)
+	}
+
+	var tcpRoutes []networking.TCPRoute
+	if len(appNames) > 0 {
+		var destinations []networking.HTTPRouteDestination
+		for i, weight := range splitWeights(weights) {
+			if weight == 0 {
+				continue
+			}
+
+			destinations = append(destinations, networking.HTTPRouteDestination{
+				Destination: networking.Destination{
+					Host: network.GetServiceHostname(appresources.TCPServiceName(appNames[i]), namespace),
+					Port: networking.PortSelector{
+						Number: appresources.TCPServicePort,
+					},
+				},
+				Weight: weight,
+			})
+		}
+
+		tcpRoutes = []networking.TCPRoute{
+			{
+				Match: []networking.L4MatchAttributes{
+					{Port: int(spec.Port)},
+				},
+				Route: destinations,
+			},
+		}
+	}
+
+	return &networking.VirtualService{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "VirtualService",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            v1alpha1.GenerateVirtualServiceName(spec),
+			Namespace:       v1alpha1.KfNamespace,
+			OwnerReferences: makeOwnerReferences(routes),
+			Labels:          MakeVirtualServiceLabels(spec),
+			Annotations: map[string]string{
+				"domain":   spec.Domain,
+				"hostname": "",
+				"port":     strconv.Itoa(int(spec.Port)),
+				"space":    namespace,
+			},
+		},
+		Spec: networking.VirtualServiceSpec{
+			Gateways: []string{KfTCPGateway},
+			Hosts:    []string{spec.Domain},
+			TCP:      tcpRoutes,
+		},
+	}
+}
+
+// makeOwnerReferences makes each route an owner of the VirtualService.
+// Therefore none of them can be a controller.
+func makeOwnerReferences(routes []*v1alpha1.Route) []metav1.OwnerReference {
+	var ownerRefs []metav1.OwnerReference
+	for _, route := range routes {
+		ownerRef := *kmeta.NewControllerRef(route)
+		ownerRef.Controller = nil
+		ownerRef.BlockOwnerDeletion = nil
+		ownerRefs = append(ownerRefs, ownerRef)
+	}
+
+	return ownerRefs
+}
+
+// buildHTTPRoute routes the requests for the path to the Apps bound to it.
+// Apps bound with a match get an HTTPRoute of their own for the requests
+// with their headers and cookies, the rest of the requests go to the Apps
+// bound without one, or the default backend if there aren't any.
+func buildHTTPRoute(
+	namespace string,
+	hostDomain string,
+	urlPath string,
+	apps []v1alpha1.RouteSpec,
+	gatewayHost string,
+	defaultBackend *v1alpha1.RouteDefaultBackend,
+) ([]networking.HTTPRoute, error) {
 	var pathMatchers []networking.HTTPMatchRequest
+	routeAddress := hostDomain + path.Join("/", urlPath)
 
 	urlPath = path.Join("/", urlPath, "/")
 	regexpPath, err := buildPathRegex(urlPath)
@@ -137,36 +369,320 @@ func buildHTTPRoute(namespace, urlPath string, appNames []string) ([]networking.
 		})
 	}
 
+	var (
+		defaultApps  []v1alpha1.RouteSpec
+		matchKeys    []string
+		matchedApps  = map[string][]v1alpha1.RouteSpec{}
+		matchHeaders = map[string]map[string]istio.StringMatch{}
+	)
+	for _, app := range apps {
+		if app.Match == nil {
+			defaultApps = append(defaultApps, app)
+			continue
+		}
+
+		headers := buildMatchHeaders(app.Match)
+		key := fmt.Sprint(headers)
+		if _, ok := matchedApps[key]; !ok {
+			matchKeys = append(matchKeys, key)
+			matchHeaders[key] = headers
+		}
+		matchedApps[key] = append(matchedApps[key], app)
+	}
+
 	var httpRoutes []networking.HTTPRoute
+	for _, key := range matchKeys {
+		var matchers []networking.HTTPMatchRequest
+		for _, matcher := range pathMatchers {
+			matcher.Headers = matchHeaders[key]
+			matchers = append(matchers, matcher)
+		}
 
-	for _, appName := range appNames {
-		httpRoutes = append(httpRoutes, networking.HTTPRoute{
-			Match: pathMatchers,
-			Route: buildRouteDestination(),
-			Rewrite: &networking.HTTPRewrite{
-				Authority: network.GetServiceHostname(appName, namespace),
-			},
-		})
+		httpRoutes = append(httpRoutes, buildAppsHTTPRoute(namespace, matchers, matchedApps[key], gatewayHost))
 	}
 
-	// If there aren't any services bound to the route, we just want to
-	// serve a 503.
-	if len(httpRoutes) == 0 {
-		return []networking.HTTPRoute{
-			{
-				Match: pathMatchers,
-				Route: buildRouteDestination(),
-				Fault: &networking.HTTPFaultInjection{
-					Abort: &networking.InjectAbort{
-						Percent:    100,
-						HTTPStatus: http.StatusServiceUnavailable,
+	if len(defaultApps) == 0 {
+		return append(httpRoutes, buildDefaultBackendHTTPRoute(
+			namespace,
+			routeAddress,
+			pathMatchers,
+			gatewayHost,
+			defaultBackend,
+		)), nil
+	}
+
+	return append(httpRoutes, buildAppsHTTPRoute(namespace, pathMatchers, defaultApps, gatewayHost)), nil
+}
+
+// buildDefaultBackendHTTPRoute serves the requests matched by the matchers
+// when no App receives them. The page backend is told which route and space
+// the request was for through headers, redirects keep the request's scheme.
+func buildDefaultBackendHTTPRoute(
+	namespace string,
+	routeAddress string,
+	matchers []networking.HTTPMatchRequest,
+	gatewayHost string,
+	backend *v1alpha1.RouteDefaultBackend,
+) networking.HTTPRoute {
+	backendType := v1alpha1.RouteDefaultBackendUnavailable
+	if backend != nil {
+		backendType = backend.Type
+	}
+
+	switch backendType {
+	case v1alpha1.RouteDefaultBackendPage:
+		return networking.HTTPRoute{
+			Match: matchers,
+			Route: []networking.HTTPRouteDestination{
+				{
+					Destination: networking.Destination{
+						Host: network.GetServiceHostname(DefaultBackendServiceName, v1alpha1.KfNamespace),
+						Port: networking.PortSelector{
+							Number: DefaultBackendServicePort,
+						},
+					},
+					Weight: 100,
+					Headers: &networking.Headers{
+						Request: &networking.HeaderOperations{
+							Set: map[string]string{
+								DefaultBackendRouteHeader: routeAddress,
+								DefaultBackendSpaceHeader: namespace,
+							},
+						},
 					},
 				},
 			},
-		}, nil
+		}
+
+	case v1alpha1.RouteDefaultBackendRedirect:
+		// The URL was validated with the Space or the config-defaults
+		// ConfigMap, so it can be parsed.
+		redirectURL, _ := url.Parse(backend.RedirectURL)
+
+		return networking.HTTPRoute{
+			Match: matchers,
+			Redirect: &networking.HTTPRedirect{
+				URI:       redirectURL.RequestURI(),
+				Authority: redirectURL.Host,
+			},
+		}
+
+	default:
+		return networking.HTTPRoute{
+			Match: matchers,
+			Route: buildRouteDestination(gatewayHost),
+			Fault: &networking.HTTPFaultInjection{
+				Abort: &networking.InjectAbort{
+					Percent:    100,
+					HTTPStatus: http.StatusServiceUnavailable,
+				},
+			},
+		}
+	}
+}
+
+// buildAppsHTTPRoute sends the requests matched by the matchers to the Apps.
+func buildAppsHTTPRoute(namespace string, matchers []networking.HTTPMatchRequest, apps []v1alpha1.RouteSpec, gatewayHost string) networking.HTTPRoute {
+	if len(apps) == 1 {
+		return networking.HTTPRoute{
+			Match: matchers,
+			Route: buildRouteDestination(gatewayHost),
+			Rewrite: &networking.HTTPRewrite{
+				Authority: network.GetServiceHostname(apps[0].AppName, namespace),
+			},
+		}
+	}
+
+	// Multiple Apps share the path, so the traffic is split between them.
+	// The authority can only be rewritten for the whole HTTPRoute and Envoy
+	// won't set the Host header per destination, so each destination goes
+	// straight to its App's cluster-local Service instead of the gateway.
+	var weights []*int
+	for _, app := range apps {
+		weights = append(weights, app.Weight)
+	}
+
+	var destinations []networking.HTTPRouteDestination
+	for i, weight := range splitWeights(weights) {
+		if weight == 0 {
+			continue
+		}
+
+		destinations = append(destinations, networking.HTTPRouteDestination{
+			Destination: networking.Destination{
+				Host: network.GetServiceHostname(apps[i].AppName, namespace),
+			},
+			Weight: weight,
+		})
+	}
+
+	return networking.HTTPRoute{
+		Match: matchers,
+		Route: destinations,
+	}
+}
+
+// buildMatchHeaders converts a RouteMatch into Istio header matchers. Istio
+// only matches lowercase header names, and cookies are matched through the
+// Cookie header.
+func buildMatchHeaders(match *v1alpha1.RouteMatch) map[string]istio.StringMatch {
+	headers := map[string]istio.StringMatch{}
+	for name, value := range match.Headers {
+		headers[strings.ToLower(name)] = istio.StringMatch{Exact: value}
+	}
+
+	for name, value := range match.Cookies {
+		headers["cookie"] = istio.StringMatch{
+			Regex: fmt.Sprintf(`^(.*?;\s*)?(%s=%s)(;.*)?$`, regexp.QuoteMeta(name), regexp.QuoteMeta(value)),
+		}
+	}
+
+	return headers
+}
+
+// applyTrafficPolicy sets the policy's timeout, retries, CORS policy and
+// header changes on each of the HTTPRoutes. Headers are added to every
+// destination next to the ones kf already sets.
+func applyTrafficPolicy(policy *v1alpha1.RouteTrafficPolicy, httpRoutes []networking.HTTPRoute) {
+	if policy == nil {
+		return
+	}
+
+	for i := range httpRoutes {
+		httpRoute := &httpRoutes[i]
+
+		if policy.TimeoutSeconds != nil {
+			httpRoute.Timeout = formatSeconds(*policy.TimeoutSeconds)
+		}
+
+		if policy.Retries != nil {
+			perTryTimeout := DefaultPerTryTimeout
+			switch {
+			case policy.PerTryTimeoutSeconds != nil:
+				perTryTimeout = formatSeconds(*policy.PerTryTimeoutSeconds)
+			case policy.TimeoutSeconds != nil:
+				perTryTimeout = formatSeconds(*policy.TimeoutSeconds)
+			}
+
+			httpRoute.Retries = &networking.HTTPRetry{
+				Attempts:      int(*policy.Retries),
+				PerTryTimeout: perTryTimeout,
+			}
+		}
+
+		if cors := policy.CORS; cors != nil {
+			httpRoute.CorsPolicy = &networking.CorsPolicy{
+				AllowOrigin:   cors.AllowOrigins,
+				AllowMethods:  cors.AllowMethods,
+				AllowHeaders:  cors.AllowHeaders,
+				ExposeHeaders: cors.ExposeHeaders,
+			}
+
+			if cors.MaxAgeSeconds != nil {
+				httpRoute.CorsPolicy.MaxAge = formatSeconds(*cors.MaxAgeSeconds)
+			}
+		}
+
+		for j := range httpRoute.Route {
+			destination := &httpRoute.Route[j]
+			if policy.RequestHeaders == nil && policy.ResponseHeaders == nil {
+				continue
+			}
+
+			if destination.Headers == nil {
+				destination.Headers = &networking.Headers{}
+			}
+
+			destination.Headers.Request = mergeHeaderOperations(destination.Headers.Request, policy.RequestHeaders)
+			destination.Headers.Response = mergeHeaderOperations(destination.Headers.Response, policy.ResponseHeaders)
+		}
+	}
+}
+
+// mergeHeaderOperations adds the headers the user asked to add or remove to
+// the operations kf already does on a destination.
+func mergeHeaderOperations(ops *networking.HeaderOperations, userOps *v1alpha1.RouteHeaderOperations) *networking.HeaderOperations {
+	if userOps == nil || (len(userOps.Add) == 0 && len(userOps.Remove) == 0) {
+		return ops
+	}
+
+	if ops == nil {
+		ops = &networking.HeaderOperations{}
+	}
+
+	for name, value := range userOps.Add {
+		if ops.Add == nil {
+			ops.Add = map[string]string{}
+		}
+		ops.Add[name] = value
+	}
+
+	ops.Remove = append(ops.Remove, userOps.Remove...)
+
+	return ops
+}
+
+func formatSeconds(seconds int32) string {
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// splitWeights converts the weights of the Apps bound to a path into
+// percentages that add up to 100. Apps without a weight split whatever the
+// weighted Apps leave over evenly. If the result doesn't add up to 100, it is
+// scaled proportionally.
+func splitWeights(weights []*int) []int {
+	var (
+		raw        = make([]int, len(weights))
+		total      int
+		unweighted int
+	)
+
+	for i, w := range weights {
+		if w == nil {
+			unweighted++
+			continue
+		}
+		raw[i] = *w
+		total += *w
+	}
+
+	if unweighted > 0 && total < 100 {
+		share := (100 - total) / unweighted
+		for i, w := range weights {
+			if w == nil {
+				raw[i] = share
+				total += share
+			}
+		}
+	}
+
+	// Nothing asked for traffic, so split it evenly.
+	if total == 0 {
+		for i := range raw {
+			raw[i] = 1
+		}
+		total = len(raw)
+	}
+
+	var (
+		split = make([]int, len(raw))
+		sum   int
+	)
+	for i, w := range raw {
+		split[i] = w * 100 / total
+		sum += split[i]
+	}
+
+	// Hand out any remainder from rounding down to the Apps that asked for
+	// traffic, in order.
+	for i := 0; sum < 100; i = (i + 1) % len(raw) {
+		if raw[i] > 0 {
+			split[i]++
+			sum++
+		}
 	}
 
-	return httpRoutes, nil
+	return split
 }
 
 func buildPathRegex(path string) (string, error) {
@@ -182,11 +698,11 @@ func buildPathRegex(path string) (string, error) {
 	return p + `(/.*)?`, nil
 }
 
-func buildRouteDestination() []networking.HTTPRouteDestination {
+func buildRouteDestination(gatewayHost string) []networking.HTTPRouteDestination {
 	return []networking.HTTPRouteDestination{
 		{
 			Destination: networking.Destination{
-				Host: GatewayHost,
+				Host: gatewayHost,
 			},
 			Weight: 100,
 		},
@@ -18,11 +18,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/testutil"
 	"github.com/google/kf/pkg/reconciler/route/resources"
+	"github.com/google/kf/pkg/routeservice"
 	"github.com/knative/serving/pkg/network"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	istio "knative.dev/pkg/apis/istio/common/v1alpha1"
@@ -33,9 +36,58 @@ import (
 func TestMakeVirtualService(t *testing.T) {
 	t.Parallel()
 
+	weightedRoute := func(appName string, weight *int) *v1alpha1.Route {
+		return &v1alpha1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{
+					Hostname: "some-host",
+					Domain:   "example.com",
+					Path:     "/some-path",
+					Weight:   weight,
+				},
+				AppName: appName,
+			},
+		}
+	}
+
+	matchedRoute := func(appName string, match *v1alpha1.RouteMatch) *v1alpha1.Route {
+		route := weightedRoute(appName, nil)
+		route.Spec.Match = match
+		return route
+	}
+
+	assertWeights := func(t *testing.T, v *networking.VirtualService, want map[string]int) {
+		t.Helper()
+
+		testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+		testutil.AssertEqual(t, "HTTP Rewrite", (*networking.HTTPRewrite)(nil), v.Spec.HTTP[0].Rewrite)
+
+		got := map[string]int{}
+		for _, dest := range v.Spec.HTTP[0].Route {
+			got[dest.Destination.Host] = dest.Weight
+		}
+
+		wantHosts := map[string]int{}
+		for app, weight := range want {
+			wantHosts[network.GetServiceHostname(app, "some-namespace")] = weight
+		}
+		testutil.AssertEqual(t, "weights", wantHosts, got)
+	}
+
+	ninety, ten, zero := 90, 10, 0
+	thirty, three := int32(30), int32(3)
+
 	for tn, tc := range map[string]struct {
-		Routes []*v1alpha1.Route
-		Assert func(t *testing.T, v *networking.VirtualService, err error)
+		Routes         []*v1alpha1.Route
+		Claims         []*v1alpha1.RouteClaim
+		Domain         v1alpha1.SpaceDomain
+		DefaultBackend *v1alpha1.RouteDefaultBackend
+		// RouteServiceKey defaults to a fixed key if nil.
+		RouteServiceKey []byte
+		Assert          func(t *testing.T, v *networking.VirtualService, err error)
 	}{
 		"empty list of routes": {
 			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
@@ -195,6 +247,63 @@ func TestMakeVirtualService(t *testing.T) {
 				testutil.AssertEqual(t, "HTTP Match", "^/some-path(/.*)?", v.Spec.HTTP[0].Match[0].URI.Regex)
 			},
 		},
+		"splits traffic between apps by weight": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", &ninety),
+				weightedRoute("app-2", &ten),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				assertWeights(t, v, map[string]int{"app-1": 90, "app-2": 10})
+
+				// Envoy doesn't let destinations set the Host header.
+				for _, dest := range v.Spec.HTTP[0].Route {
+					testutil.AssertEqual(t, "Headers", (*networking.Headers)(nil), dest.Headers)
+				}
+			},
+		},
+		"apps without weights share what is left": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", &ten),
+				weightedRoute("app-2", nil),
+				weightedRoute("app-3", nil),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				assertWeights(t, v, map[string]int{"app-1": 10, "app-2": 45, "app-3": 45})
+			},
+		},
+		"apps without weights split evenly": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", nil),
+				weightedRoute("app-2", nil),
+				weightedRoute("app-3", nil),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				assertWeights(t, v, map[string]int{"app-1": 34, "app-2": 33, "app-3": 33})
+			},
+		},
+		"weights are scaled to 100": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", &ten),
+				weightedRoute("app-2", &ten),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				assertWeights(t, v, map[string]int{"app-1": 50, "app-2": 50})
+			},
+		},
+		"apps with a zero weight don't get traffic": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", &zero),
+				weightedRoute("app-2", nil),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				assertWeights(t, v, map[string]int{"app-2": 100})
+			},
+		},
 		"Hosts with subdomain": {
 			Routes: []*v1alpha1.Route{
 				{
@@ -213,6 +322,412 @@ func TestMakeVirtualService(t *testing.T) {
 				testutil.AssertEqual(t, "Hosts", []string{"some-host.example.com"}, v.Spec.Hosts)
 			},
 		},
+		"public gateway": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.KnativeIngressGateway}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "Destination Host", resources.GatewayHost, v.Spec.HTTP[0].Route[0].Destination.Host)
+			},
+		},
+		"internal domain": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", &ninety),
+				weightedRoute("other-app", &ten),
+			},
+			Domain: v1alpha1.SpaceDomain{Internal: true},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.KnativeClusterLocalGateway, resources.MeshGateway}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "Hosts", []string{"some-host.example.com"}, v.Spec.Hosts)
+				for _, dest := range v.Spec.HTTP[0].Route {
+					testutil.AssertEqual(t, "Destination Host", resources.ClusterLocalGatewayHost, dest.Destination.Host)
+				}
+			},
+		},
+		"internal domain without apps": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("", nil),
+			},
+			Domain: v1alpha1.SpaceDomain{Internal: true},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Destination Host", resources.ClusterLocalGatewayHost, v.Spec.HTTP[0].Route[0].Destination.Host)
+			},
+		},
+		"TLS domain": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Domain: v1alpha1.SpaceDomain{Domain: "example.com", TLS: &v1alpha1.DomainTLS{SelfSigned: true}},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{
+					resources.KnativeIngressGateway,
+					v.Name + ".kf.svc.cluster.local",
+				}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "Destination Host", resources.GatewayHost, v.Spec.HTTP[0].Route[0].Destination.Host)
+			},
+		},
+		"route service": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{routeServiceClaim("/some-path", "https://ratelimiter.example.com")},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+
+				token := routeservice.Token([]byte("some-key"), "some-uid", "https://ratelimiter.example.com")
+
+				// Requests checked by the proxy reach the App without the
+				// token.
+				verified := v.Spec.HTTP[0]
+				testutil.AssertEqual(t, "verified Headers", map[string]istio.StringMatch{
+					"x-kf-route-service-token": {Exact: token},
+				}, verified.Match[0].Headers)
+				testutil.AssertEqual(t, "verified Authority", network.GetServiceHostname("some-app", "some-namespace"), verified.Rewrite.Authority)
+				testutil.AssertEqual(t, "verified removed Headers", []string{
+					routeservice.TokenHeader,
+					routeservice.HopHeader,
+				}, verified.Route[0].Headers.Request.Remove)
+
+				// Everything else goes to the proxy with the Host intact.
+				proxied := v.Spec.HTTP[1]
+				testutil.AssertEqual(t, "proxied Headers", 0, len(proxied.Match[0].Headers))
+				testutil.AssertEqual(t, "proxied Rewrite", (*networking.HTTPRewrite)(nil), proxied.Rewrite)
+				testutil.AssertEqual(t, "Destination", networking.Destination{
+					Host: network.GetServiceHostname("kf-route-service-proxy", "kf"),
+					Port: networking.PortSelector{Number: 80},
+				}, proxied.Route[0].Destination)
+
+				set := proxied.Route[0].Headers.Request.Set
+				testutil.AssertEqual(t, "URL", "https://ratelimiter.example.com", set[routeservice.URLHeader])
+				testutil.AssertEqual(t, "Token", token, set[routeservice.TokenHeader])
+				testutil.AssertEqual(t, "Gateway", resources.GatewayHost, set[routeservice.GatewayHeader])
+				testutil.AssertEqual(t, "has Metadata", true, set[routeservice.ProxyMetadataHeader] != "")
+			},
+		},
+		"internal route service": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{routeServiceClaim("/some-path", "http://auth.internal:8080")},
+			Domain: v1alpha1.SpaceDomain{Domain: "example.com", Internal: true},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateway", resources.ClusterLocalGatewayHost, v.Spec.HTTP[1].Route[0].Headers.Request.Set[routeservice.GatewayHeader])
+			},
+		},
+		"route service without key": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims:          []*v1alpha1.RouteClaim{routeServiceClaim("/some-path", "https://ratelimiter.example.com")},
+			RouteServiceKey: []byte{},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("the route service key is missing"), err)
+			},
+		},
+		"route service without apps": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{routeServiceClaim("/some-path", "https://ratelimiter.example.com")},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "Fault", http.StatusServiceUnavailable, v.Spec.HTTP[0].Fault.Abort.HTTPStatus)
+			},
+		},
+		"claim without route service": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{{}},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+			},
+		},
+		"route service on another path": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{routeServiceClaim("/other-path", "https://ratelimiter.example.com")},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+			},
+		},
+		"traffic policy": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{trafficPolicyClaim(&v1alpha1.RouteTrafficPolicy{
+				TimeoutSeconds: &thirty,
+				Retries:        &three,
+				CORS: &v1alpha1.RouteCORSPolicy{
+					AllowOrigins:  []string{"https://example.com"},
+					AllowMethods:  []string{"GET"},
+					MaxAgeSeconds: &thirty,
+				},
+				RequestHeaders: &v1alpha1.RouteHeaderOperations{
+					Add: map[string]string{"X-Team": "payments"},
+				},
+				ResponseHeaders: &v1alpha1.RouteHeaderOperations{
+					Remove: []string{"Server"},
+				},
+			})},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+
+				httpRoute := v.Spec.HTTP[0]
+				testutil.AssertEqual(t, "Timeout", "30s", httpRoute.Timeout)
+				testutil.AssertEqual(t, "Retries", &networking.HTTPRetry{
+					Attempts:      3,
+					PerTryTimeout: "30s",
+				}, httpRoute.Retries)
+				testutil.AssertEqual(t, "CorsPolicy", &networking.CorsPolicy{
+					AllowOrigin:  []string{"https://example.com"},
+					AllowMethods: []string{"GET"},
+					MaxAge:       "30s",
+				}, httpRoute.CorsPolicy)
+				testutil.AssertEqual(t, "Headers", &networking.Headers{
+					Request: &networking.HeaderOperations{
+						Add: map[string]string{"X-Team": "payments"},
+					},
+					Response: &networking.HeaderOperations{
+						Remove: []string{"Server"},
+					},
+				}, httpRoute.Route[0].Headers)
+			},
+		},
+		"traffic policy with split": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", &ninety),
+				weightedRoute("app-2", &ten),
+			},
+			Claims: []*v1alpha1.RouteClaim{trafficPolicyClaim(&v1alpha1.RouteTrafficPolicy{
+				Retries: &three,
+				RequestHeaders: &v1alpha1.RouteHeaderOperations{
+					Add: map[string]string{"X-Team": "payments"},
+				},
+			})},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				assertWeights(t, v, map[string]int{"app-1": 90, "app-2": 10})
+
+				testutil.AssertEqual(t, "PerTryTimeout", resources.DefaultPerTryTimeout, v.Spec.HTTP[0].Retries.PerTryTimeout)
+				for _, dest := range v.Spec.HTTP[0].Route {
+					testutil.AssertEqual(t, "added header", "payments", dest.Headers.Request.Add["X-Team"])
+				}
+			},
+		},
+		"traffic policy without apps": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{trafficPolicyClaim(&v1alpha1.RouteTrafficPolicy{
+				TimeoutSeconds: &thirty,
+			})},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Timeout", "", v.Spec.HTTP[0].Timeout)
+			},
+		},
+		"header match": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", nil),
+				matchedRoute("app-2", &v1alpha1.RouteMatch{Headers: map[string]string{"X-Canary": "true"}}),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+
+				canary := v.Spec.HTTP[0]
+				testutil.AssertEqual(t, "canary Headers", map[string]istio.StringMatch{
+					"x-canary": {Exact: "true"},
+				}, canary.Match[0].Headers)
+				testutil.AssertEqual(t, "canary Authority", network.GetServiceHostname("app-2", "some-namespace"), canary.Rewrite.Authority)
+
+				fallback := v.Spec.HTTP[1]
+				testutil.AssertEqual(t, "fallback Headers", 0, len(fallback.Match[0].Headers))
+				testutil.AssertEqual(t, "fallback Authority", network.GetServiceHostname("app-1", "some-namespace"), fallback.Rewrite.Authority)
+			},
+		},
+		"cookie match": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", nil),
+				matchedRoute("app-2", &v1alpha1.RouteMatch{Cookies: map[string]string{"preview": "v2"}}),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "preview Headers", map[string]istio.StringMatch{
+					"cookie": {Regex: `^(.*?;\s*)?(preview=v2)(;.*)?$`},
+				}, v.Spec.HTTP[0].Match[0].Headers)
+			},
+		},
+		"match split between Apps": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", nil),
+				matchedRoute("app-2", &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}),
+				matchedRoute("app-3", &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "canary destinations", 2, len(v.Spec.HTTP[0].Route))
+				testutil.AssertEqual(t, "fallback Authority", network.GetServiceHostname("app-1", "some-namespace"), v.Spec.HTTP[1].Rewrite.Authority)
+			},
+		},
+		"match without default App": {
+			Routes: []*v1alpha1.Route{
+				matchedRoute("app-2", &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "canary Authority", network.GetServiceHostname("app-2", "some-namespace"), v.Spec.HTTP[0].Rewrite.Authority)
+				testutil.AssertEqual(t, "Fault", http.StatusServiceUnavailable, v.Spec.HTTP[1].Fault.Abort.HTTPStatus)
+			},
+		},
+		"default backend page": {
+			Routes: []*v1alpha1.Route{
+				matchedRoute("app-2", &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}),
+			},
+			DefaultBackend: &v1alpha1.RouteDefaultBackend{Type: v1alpha1.RouteDefaultBackendPage},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "Fault", (*networking.HTTPFaultInjection)(nil), v.Spec.HTTP[1].Fault)
+				testutil.AssertEqual(t, "Route", []networking.HTTPRouteDestination{
+					{
+						Destination: networking.Destination{
+							Host: network.GetServiceHostname("kf-default-backend", "kf"),
+							Port: networking.PortSelector{Number: 80},
+						},
+						Weight: 100,
+						Headers: &networking.Headers{
+							Request: &networking.HeaderOperations{
+								Set: map[string]string{
+									"X-Kf-Route": "some-host.example.com/some-path",
+									"X-Kf-Space": "some-namespace",
+								},
+							},
+						},
+					},
+				}, v.Spec.HTTP[1].Route)
+			},
+		},
+		"default backend redirect": {
+			Routes: []*v1alpha1.Route{
+				matchedRoute("app-2", &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}),
+			},
+			DefaultBackend: &v1alpha1.RouteDefaultBackend{
+				Type:        v1alpha1.RouteDefaultBackendRedirect,
+				RedirectURL: "https://www.example.com/not-found?from=kf",
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "Route", 0, len(v.Spec.HTTP[1].Route))
+				testutil.AssertEqual(t, "Redirect", &networking.HTTPRedirect{
+					URI:       "/not-found?from=kf",
+					Authority: "www.example.com",
+				}, v.Spec.HTTP[1].Redirect)
+			},
+		},
+		"default backend redirect without path": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("", nil),
+			},
+			DefaultBackend: &v1alpha1.RouteDefaultBackend{
+				Type:        v1alpha1.RouteDefaultBackendRedirect,
+				RedirectURL: "https://www.example.com",
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Redirect", &networking.HTTPRedirect{
+					URI:       "/",
+					Authority: "www.example.com",
+				}, v.Spec.HTTP[0].Redirect)
+			},
+		},
+		"default backend unavailable": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("", nil),
+			},
+			DefaultBackend: &v1alpha1.RouteDefaultBackend{Type: v1alpha1.RouteDefaultBackendUnavailable},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Fault", http.StatusServiceUnavailable, v.Spec.HTTP[0].Fault.Abort.HTTPStatus)
+			},
+		},
+		"default backend with apps": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", nil),
+			},
+			DefaultBackend: &v1alpha1.RouteDefaultBackend{Type: v1alpha1.RouteDefaultBackendPage},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "Authority", network.GetServiceHostname("app-1", "some-namespace"), v.Spec.HTTP[0].Rewrite.Authority)
+			},
+		},
+		"match with route service": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("app-1", nil),
+				matchedRoute("app-2", &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}),
+			},
+			Claims: []*v1alpha1.RouteClaim{routeServiceClaim("/some-path", "https://ratelimiter.example.com")},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 4, len(v.Spec.HTTP))
+
+				var got [][]string
+				for _, httpRoute := range v.Spec.HTTP {
+					var names []string
+					for name := range httpRoute.Match[0].Headers {
+						names = append(names, name)
+					}
+					sort.Strings(names)
+					got = append(got, names)
+				}
+
+				// More specific matches come first so verified canary
+				// requests don't fall through to the default App.
+				testutil.AssertEqual(t, "header matches", [][]string{
+					{"x-canary", "x-kf-route-service-token"},
+					{"x-kf-route-service-token"},
+					{"x-canary"},
+					nil,
+				}, got)
+				testutil.AssertEqual(t, "verified canary Authority", network.GetServiceHostname("app-2", "some-namespace"), v.Spec.HTTP[0].Rewrite.Authority)
+			},
+		},
+		"relative route service URL": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{routeServiceClaim("/some-path", "ratelimiter.example.com")},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertErrorContainsAll(t, err, []string{"invalid route service URL", "must be an http or https URL"})
+			},
+		},
+		"invalid route service URL": {
+			Routes: []*v1alpha1.Route{
+				weightedRoute("some-app", nil),
+			},
+			Claims: []*v1alpha1.RouteClaim{routeServiceClaim("/some-path", "https://ratelimiter.example.com:port")},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertErrorContainsAll(t, err, []string{"invalid route service URL", `invalid port ":port"`})
+			},
+		},
 		"Hosts without subdomain": {
 			Routes: []*v1alpha1.Route{
 				{
@@ -231,14 +746,202 @@ func TestMakeVirtualService(t *testing.T) {
 				testutil.AssertEqual(t, "Hosts", []string{"example.com"}, v.Spec.Hosts)
 			},
 		},
+		"TCP route": {
+			Routes: []*v1alpha1.Route{
+				tcpRoute("some-app", nil),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Name", v1alpha1.GenerateVirtualServiceName(v1alpha1.RouteSpecFields{
+					Domain: "tcp.example.com",
+					Port:   1234,
+				}), v.Name)
+				testutil.AssertEqual(t, "Port label", "1234", v.Labels[v1alpha1.RoutePort])
+				testutil.AssertEqual(t, "Port annotation", "1234", v.Annotations["port"])
+				testutil.AssertEqual(t, "Gateways", []string{resources.KfTCPGateway}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "HTTP len", 0, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "TCP", []networking.TCPRoute{
+					{
+						Match: []networking.L4MatchAttributes{{Port: 1234}},
+						Route: []networking.HTTPRouteDestination{
+							{
+								Destination: networking.Destination{
+									Host: network.GetServiceHostname("some-app-tcp", "some-namespace"),
+									Port: networking.PortSelector{Number: 8080},
+								},
+								Weight: 100,
+							},
+						},
+					},
+				}, v.Spec.TCP)
+			},
+		},
+		"TCP route split between Apps": {
+			Routes: []*v1alpha1.Route{
+				tcpRoute("app-1", &ninety),
+				tcpRoute("app-2", &ten),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+
+				got := map[string]int{}
+				for _, dest := range v.Spec.TCP[0].Route {
+					got[dest.Destination.Host] = dest.Weight
+				}
+
+				testutil.AssertEqual(t, "weights", map[string]int{
+					network.GetServiceHostname("app-1-tcp", "some-namespace"): 90,
+					network.GetServiceHostname("app-2-tcp", "some-namespace"): 10,
+				}, got)
+			},
+		},
+		"TCP route without Apps": {
+			Routes: []*v1alpha1.Route{
+				tcpRoute("", nil),
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "TCP len", 0, len(v.Spec.TCP))
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
-			s, err := resources.MakeVirtualService(tc.Routes)
+			key := tc.RouteServiceKey
+			if key == nil {
+				key = []byte("some-key")
+			}
+
+			s, err := resources.MakeVirtualService(tc.Routes, tc.Claims, tc.Domain, tc.DefaultBackend, key)
 			tc.Assert(t, s, err)
 		})
 	}
 }
 
+func TestReplacePathHTTPRoutes(t *testing.T) {
+	t.Parallel()
+
+	route := func(appName, urlPath string, match *v1alpha1.RouteMatch) *v1alpha1.Route {
+		return &v1alpha1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: v1alpha1.RouteSpec{
+				RouteSpecFields: v1alpha1.RouteSpecFields{
+					Hostname: "some-host",
+					Domain:   "example.com",
+					Path:     urlPath,
+				},
+				AppName: appName,
+				Match:   match,
+			},
+		}
+	}
+
+	makeHTTPRoutes := func(t *testing.T, routes ...*v1alpha1.Route) []networking.HTTPRoute {
+		t.Helper()
+
+		v, err := resources.MakeVirtualService(routes, nil, v1alpha1.SpaceDomain{}, nil, nil)
+		testutil.AssertNil(t, "err", err)
+		return v.Spec.HTTP
+	}
+
+	headerMatches := func(httpRoutes []networking.HTTPRoute) []map[string]istio.StringMatch {
+		var matches []map[string]istio.StringMatch
+		for _, httpRoute := range httpRoutes {
+			matches = append(matches, httpRoute.Match[0].Headers)
+		}
+		return matches
+	}
+
+	// The VirtualService holds the HTTPRoutes of /other-path and the ones
+	// of /some-path from when app-2 was bound with an x-canary match.
+	existing := append(
+		makeHTTPRoutes(t, route("app-3", "/other-path", nil)),
+		makeHTTPRoutes(t,
+			route("app-1", "/some-path", nil),
+			route("app-2", "/some-path", &v1alpha1.RouteMatch{Headers: map[string]string{"x-canary": "true"}}),
+		)...,
+	)
+
+	// app-2 is mapped again with an x-preview match instead.
+	desired := makeHTTPRoutes(t,
+		route("app-1", "/some-path", nil),
+		route("app-2", "/some-path", &v1alpha1.RouteMatch{Headers: map[string]string{"x-preview": "true"}}),
+	)
+
+	got := resources.ReplacePathHTTPRoutes(existing, desired)
+	testutil.AssertEqual(t, "HTTP len", 3, len(got))
+
+	for _, headers := range headerMatches(got) {
+		if _, ok := headers["x-canary"]; ok {
+			t.Fatalf("expected the x-canary matcher to be removed, got %v", headerMatches(got))
+		}
+	}
+
+	var other int
+	for _, httpRoute := range got {
+		if strings.Contains(httpRoute.Match[0].URI.Regex, "other-path") {
+			other++
+		}
+	}
+	testutil.AssertEqual(t, "other path routes", 1, other)
+	testutil.AssertEqual(t, "preview Headers", map[string]istio.StringMatch{
+		"x-preview": {Exact: "true"},
+	}, got[0].Match[0].Headers)
+}
+
+func tcpRoute(appName string, weight *int) *v1alpha1.Route {
+	return &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Domain: "tcp.example.com",
+				Port:   1234,
+				Weight: weight,
+			},
+			AppName: appName,
+		},
+	}
+}
+
+func routeServiceClaim(urlPath, serviceURL string) *v1alpha1.RouteClaim {
+	return &v1alpha1.RouteClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "some-namespace",
+			UID:       "some-uid",
+		},
+		Spec: v1alpha1.RouteClaimSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "some-host",
+				Domain:   "example.com",
+				Path:     urlPath,
+			},
+			RouteService: &v1alpha1.RouteServiceBinding{
+				InstanceName: "some-service",
+				URL:          serviceURL,
+			},
+		},
+	}
+}
+
+func trafficPolicyClaim(policy *v1alpha1.RouteTrafficPolicy) *v1alpha1.RouteClaim {
+	return &v1alpha1.RouteClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteClaimSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "some-host",
+				Domain:   "example.com",
+				Path:     "/some-path",
+			},
+			TrafficPolicy: policy,
+		},
+	}
+}
+
 func ExampleMakeVirtualService() {
 	vs, err := resources.MakeVirtualService([]*v1alpha1.Route{
 		{
@@ -267,7 +970,7 @@ func ExampleMakeVirtualService() {
 				},
 			},
 		},
-	})
+	}, nil, v1alpha1.SpaceDomain{Domain: "example.com"}, nil, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -276,7 +979,7 @@ func ExampleMakeVirtualService() {
 		fmt.Printf("Regex %d: %s\n", i, h.Match[0].URI.Regex)
 	}
 
-	// Output: Regex 0: ^(/.*)?
+	// Output: Regex 0: ^/some-path-2(/.*)?
 	// Regex 1: ^/some-path-1(/.*)?
-	// Regex 2: ^/some-path-2(/.*)?
+	// Regex 2: ^(/.*)?
 }
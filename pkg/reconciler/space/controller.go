@@ -25,6 +25,7 @@ import (
 
 	// TODO (juliaguo): replace with knative informer pkgs once they are merged in
 	limitrangeinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/limitrange"
+	networkpolicyinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/networkpolicy"
 	quotainformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/resourcequota"
 
 	"k8s.io/client-go/tools/cache"
@@ -43,6 +44,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	roleInformer := roleinformer.Get(ctx)
 	quotaInformer := quotainformer.Get(ctx)
 	limitRangeInformer := limitrangeinformer.Get(ctx)
+	networkPolicyInformer := networkpolicyinformer.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
@@ -52,6 +54,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		roleLister:          roleInformer.Lister(),
 		resourceQuotaLister: quotaInformer.Lister(),
 		limitRangeLister:    limitRangeInformer.Lister(),
+		networkPolicyLister: networkPolicyInformer.Lister(),
 	}
 
 	impl := controller.NewImpl(c, logger, "Spaces")
@@ -80,5 +83,10 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	networkPolicyInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	return impl
 }
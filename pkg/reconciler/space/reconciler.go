@@ -25,12 +25,15 @@ import (
 	"github.com/google/kf/pkg/reconciler/space/resources"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1listers "k8s.io/client-go/listers/core/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
@@ -48,6 +51,7 @@ type Reconciler struct {
 	roleLister          rbacv1listers.RoleLister
 	resourceQuotaLister v1listers.ResourceQuotaLister
 	limitRangeLister    v1listers.LimitRangeLister
+	networkPolicyLister networkingv1listers.NetworkPolicyLister
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -65,13 +69,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	switch {
 	case apierrs.IsNotFound(err):
 		logger.Errorf("space %q no longer exists\n", name)
-		return nil
+		return r.reconcileInternalDNS(ctx)
 
 	case err != nil:
 		return err
 
 	case original.GetDeletionTimestamp() != nil:
-		return nil
+		return r.reconcileInternalDNS(ctx)
 	}
 
 	// Don't modify the informers copy
@@ -240,7 +244,85 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		space.Status.PropagateLimitRangeStatus(actual)
 	}
 
-	return nil
+	// Sync egress network policy
+	{
+		logger.Debug("reconciling NetworkPolicy")
+		desired, err := resources.MakeNetworkPolicy(space)
+		if err != nil {
+			return err
+		}
+
+		actual, err := r.networkPolicyLister.NetworkPolicies(desired.Namespace).Get(desired.Name)
+		if errors.IsNotFound(err) {
+			actual, err = r.KubeClientSet.NetworkingV1().NetworkPolicies(desired.Namespace).Create(desired)
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else if !metav1.IsControlledBy(actual, space) {
+			space.Status.MarkNetworkPolicyNotOwned(desired.Name)
+			return fmt.Errorf("space: %q does not own network policy: %q", space.Name, desired.Name)
+		} else if actual, err = r.reconcileNetworkPolicy(desired, actual); err != nil {
+			return err
+		}
+
+		space.Status.PropagateNetworkPolicyStatus(actual)
+	}
+
+	// Sync internal domain DNS
+	return r.reconcileInternalDNS(ctx)
+}
+
+// reconcileInternalDNS adds the internal domains of every Space to the
+// cluster's CoreDNS configuration so hosts on them resolve inside the
+// cluster. Clusters that don't run CoreDNS are skipped, internal domains
+// can't be resolved on them.
+func (r *Reconciler) reconcileInternalDNS(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+	logger.Debug("reconciling internal domain DNS")
+
+	spaces, err := r.spaceLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	configMaps := r.KubeClientSet.CoreV1().ConfigMaps(resources.CoreDNSNamespace)
+	actual, err := configMaps.Get(resources.CoreDNSConfigMapName, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		logger.Debug("CoreDNS isn't configured, internal domains won't resolve")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	domains := resources.InternalDomains(spaces)
+
+	var gatewayIP string
+	if len(domains) > 0 {
+		gateway, err := r.KubeClientSet.
+			CoreV1().
+			Services(resources.ClusterLocalGatewayNamespace).
+			Get(resources.ClusterLocalGatewayName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get the cluster-local gateway: %s", err)
+		}
+		gatewayIP = gateway.Spec.ClusterIP
+	}
+
+	corefile := actual.Data[resources.CorefileKey]
+	desired := resources.MergeCorefile(corefile, resources.MakeInternalDNSStub(domains, gatewayIP))
+	if desired == corefile {
+		return nil
+	}
+
+	if actual.Data == nil {
+		actual.Data = make(map[string]string)
+	}
+	actual.Data[resources.CorefileKey] = desired
+
+	_, err = configMaps.Update(actual)
+	return err
 }
 
 func (r *Reconciler) reconcileNs(desired, actual *v1.Namespace) (*v1.Namespace, error) {
@@ -347,3 +429,25 @@ func (r *Reconciler) updateStatus(desired *v1alpha1.Space) (*v1alpha1.Space, err
 
 	return r.KfClientSet.KfV1alpha1().Spaces().UpdateStatus(existing)
 }
+
+func (r *Reconciler) reconcileNetworkPolicy(desired, actual *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff Spec (NetworkPolicy): %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.KubeClientSet.NetworkingV1().NetworkPolicies(existing.Namespace).Update(existing)
+}
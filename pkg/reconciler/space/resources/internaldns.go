@@ -0,0 +1,119 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+)
+
+const (
+	// CoreDNSNamespace and CoreDNSConfigMapName locate the ConfigMap holding
+	// the cluster's CoreDNS configuration.
+	CoreDNSNamespace     = "kube-system"
+	CoreDNSConfigMapName = "coredns"
+
+	// CorefileKey is the key of the Corefile in the CoreDNS ConfigMap.
+	CorefileKey = "Corefile"
+
+	// ClusterLocalGatewayNamespace and ClusterLocalGatewayName locate the
+	// Service of the gateway that serves internal domains.
+	ClusterLocalGatewayNamespace = "istio-system"
+	ClusterLocalGatewayName      = "cluster-local-gateway"
+
+	internalDNSBegin = "# BEGIN kf internal domains, managed by kf"
+	internalDNSEnd   = "# END kf internal domains"
+)
+
+// InternalDomains returns the sorted, unique internal domains of the Spaces.
+func InternalDomains(spaces []*v1alpha1.Space) []string {
+	seen := make(map[string]bool)
+	var domains []string
+	for _, space := range spaces {
+		if space.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		for _, domain := range space.Spec.Execution.Domains {
+			if !domain.Internal || seen[domain.Domain] {
+				continue
+			}
+
+			seen[domain.Domain] = true
+			domains = append(domains, domain.Domain)
+		}
+	}
+
+	sort.Strings(domains)
+	return domains
+}
+
+// MakeInternalDNSStub creates the CoreDNS server blocks that resolve every
+// host on the internal domains to the cluster-local gateway. Requests from
+// Pods in the mesh are then routed by their sidecars, and requests from Pods
+// outside it by the gateway.
+func MakeInternalDNSStub(domains []string, gatewayIP string) string {
+	if len(domains) == 0 || gatewayIP == "" {
+		return ""
+	}
+
+	var stub strings.Builder
+	fmt.Fprintln(&stub, internalDNSBegin)
+	for _, domain := range domains {
+		fmt.Fprintf(&stub, "%s:53 {\n", domain)
+		fmt.Fprintln(&stub, "    errors")
+		fmt.Fprintln(&stub, "    template IN A {")
+		fmt.Fprintf(&stub, "        answer \"{{ .Name }} 30 IN A %s\"\n", gatewayIP)
+		fmt.Fprintln(&stub, "    }")
+		fmt.Fprintln(&stub, "    template ANY ANY {")
+		fmt.Fprintln(&stub, "        rcode NOERROR")
+		fmt.Fprintln(&stub, "    }")
+		fmt.Fprintln(&stub, "}")
+	}
+	fmt.Fprintln(&stub, internalDNSEnd)
+
+	return stub.String()
+}
+
+// MergeCorefile replaces the block written by MakeInternalDNSStub in the
+// Corefile with the stub, the rest of the Corefile is kept as is. The stub is
+// appended if the Corefile doesn't have one yet and the block is removed if
+// the stub is empty.
+func MergeCorefile(corefile, stub string) string {
+	begin := strings.Index(corefile, internalDNSBegin)
+	end := strings.Index(corefile, internalDNSEnd)
+
+	if begin >= 0 && end > begin {
+		end += len(internalDNSEnd)
+		if end < len(corefile) && corefile[end] == '\n' {
+			end++
+		}
+
+		return corefile[:begin] + stub + corefile[end:]
+	}
+
+	if stub == "" {
+		return corefile
+	}
+
+	if corefile != "" && !strings.HasSuffix(corefile, "\n") {
+		corefile += "\n"
+	}
+
+	return corefile + stub
+}
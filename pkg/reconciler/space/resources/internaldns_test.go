@@ -0,0 +1,109 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleMakeInternalDNSStub() {
+	fmt.Print(MakeInternalDNSStub([]string{"apps.internal"}, "10.0.0.12"))
+
+	// Output: # BEGIN kf internal domains, managed by kf
+	// apps.internal:53 {
+	//     errors
+	//     template IN A {
+	//         answer "{{ .Name }} 30 IN A 10.0.0.12"
+	//     }
+	//     template ANY ANY {
+	//         rcode NOERROR
+	//     }
+	// }
+	// # END kf internal domains
+}
+
+func TestInternalDomains(t *testing.T) {
+	space := func(domains ...v1alpha1.SpaceDomain) *v1alpha1.Space {
+		s := &v1alpha1.Space{}
+		s.Spec.Execution.Domains = domains
+		return s
+	}
+
+	deleted := space(v1alpha1.SpaceDomain{Domain: "deleted.internal", Internal: true})
+	deleted.DeletionTimestamp = &metav1.Time{}
+
+	got := InternalDomains([]*v1alpha1.Space{
+		space(
+			v1alpha1.SpaceDomain{Domain: "example.com", Default: true},
+			v1alpha1.SpaceDomain{Domain: "b.internal", Internal: true},
+		),
+		space(
+			v1alpha1.SpaceDomain{Domain: "a.internal", Internal: true},
+			v1alpha1.SpaceDomain{Domain: "b.internal", Internal: true},
+		),
+		deleted,
+	})
+
+	testutil.AssertEqual(t, "domains", []string{"a.internal", "b.internal"}, got)
+}
+
+func TestMergeCorefile(t *testing.T) {
+	const corefile = ".:53 {\n    kubernetes cluster.local\n}\n"
+	stub := MakeInternalDNSStub([]string{"apps.internal"}, "10.0.0.12")
+	newStub := MakeInternalDNSStub([]string{"apps.internal", "svc.internal"}, "10.0.0.12")
+
+	cases := map[string]struct {
+		corefile string
+		stub     string
+		want     string
+	}{
+		"appends the stub": {
+			corefile: corefile,
+			stub:     stub,
+			want:     corefile + stub,
+		},
+		"adds a missing newline": {
+			corefile: ".:53 {\n}",
+			stub:     stub,
+			want:     ".:53 {\n}\n" + stub,
+		},
+		"replaces the stub": {
+			corefile: corefile + stub + "# after\n",
+			stub:     newStub,
+			want:     corefile + newStub + "# after\n",
+		},
+		"removes the stub": {
+			corefile: corefile + stub,
+			stub:     "",
+			want:     corefile,
+		},
+		"unchanged without a stub": {
+			corefile: corefile,
+			stub:     "",
+			want:     corefile,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "Corefile", tc.want, MergeCorefile(tc.corefile, tc.stub))
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"net"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/pkg/kmeta"
+	"github.com/knative/serving/pkg/resources"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// namespaceNameLabel is the label Kubernetes puts on every namespace holding
+// its name.
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// kubeDNSLabels select the cluster's DNS Pods.
+var kubeDNSLabels = map[string]string{"k8s-app": "kube-dns"}
+
+// allNetworks are the CIDRs used for the catch-all rule when a Space's
+// default egress policy is Allow.
+var allNetworks = []string{"0.0.0.0/0", "::/0"}
+
+// NetworkPolicyName gets the name of the egress NetworkPolicy given the space.
+func NetworkPolicyName(space *v1alpha1.Space) string {
+	return "space-egress"
+}
+
+// MakeNetworkPolicy creates a NetworkPolicy that restricts egress of every
+// Pod in the space according to the Space's network rules.
+//
+// NetworkPolicies can only allow traffic, so Deny rules are rendered as
+// exceptions on the IP blocks of the Allow rules that contain them. DNS and
+// traffic to other Pods in the cluster are always allowed.
+func MakeNetworkPolicy(space *v1alpha1.Space) (*networkingv1.NetworkPolicy, error) {
+	policy := &networkingv1.NetworkPolicy{}
+	policy.ObjectMeta = metav1.ObjectMeta{
+		Name:      NetworkPolicyName(space),
+		Namespace: NamespaceName(space),
+		OwnerReferences: []metav1.OwnerReference{
+			*kmeta.NewControllerRef(space),
+		},
+		Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+			managedByLabel: "kf",
+		}),
+	}
+
+	policy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
+	policy.Spec.Egress = append(policy.Spec.Egress, clusterEgressRules()...)
+
+	network := space.Spec.Network
+
+	var denied []*net.IPNet
+	for _, rule := range network.Egress {
+		if rule.Policy != v1alpha1.EgressPolicyDeny {
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(rule.CIDR)
+		if err != nil {
+			return nil, err
+		}
+		denied = append(denied, ipNet)
+	}
+
+	for _, rule := range network.Egress {
+		if rule.Policy != v1alpha1.EgressPolicyAllow {
+			continue
+		}
+
+		peer, err := ipBlockPeer(rule.CIDR, denied)
+		if err != nil {
+			return nil, err
+		}
+		if peer == nil {
+			continue
+		}
+
+		policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+			Ports: makeNetworkPolicyPorts(rule.Ports),
+			To:    []networkingv1.NetworkPolicyPeer{*peer},
+		})
+	}
+
+	if network.DefaultEgressPolicy != v1alpha1.EgressPolicyDeny {
+		var peers []networkingv1.NetworkPolicyPeer
+		for _, cidr := range allNetworks {
+			peer, err := ipBlockPeer(cidr, denied)
+			if err != nil {
+				return nil, err
+			}
+			if peer != nil {
+				peers = append(peers, *peer)
+			}
+		}
+
+		if len(peers) > 0 {
+			policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+				To: peers,
+			})
+		}
+	}
+
+	return policy, nil
+}
+
+// clusterEgressRules returns the rules that keep DNS and traffic to other Pods
+// in the cluster working regardless of the Space's egress rules. DNS is only
+// allowed to the cluster's DNS Pods so it can't be used to reach other
+// servers.
+func clusterEgressRules() []networkingv1.NetworkPolicyEgressRule {
+	udp := v1.ProtocolUDP
+	tcp := v1.ProtocolTCP
+	dns := intstr.FromInt(53)
+
+	return []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &dns},
+				{Protocol: &tcp, Port: &dns},
+			},
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{namespaceNameLabel: CoreDNSNamespace},
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: kubeDNSLabels,
+					},
+				},
+			},
+		},
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{NamespaceSelector: &metav1.LabelSelector{}},
+			},
+		},
+	}
+}
+
+// ipBlockPeer creates a peer for the given CIDR excluding any denied networks
+// within it. If a denied network covers the whole CIDR nil is returned.
+func ipBlockPeer(cidr string, denied []*net.IPNet) (*networkingv1.NetworkPolicyPeer, error) {
+	_, allowed, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &networkingv1.IPBlock{CIDR: allowed.String()}
+	for _, deny := range denied {
+		switch {
+		case containsNetwork(deny, allowed):
+			return nil, nil
+		case containsNetwork(allowed, deny):
+			block.Except = append(block.Except, deny.String())
+		}
+	}
+
+	return &networkingv1.NetworkPolicyPeer{IPBlock: block}, nil
+}
+
+// containsNetwork returns true if inner is a subset of (or equal to) outer.
+func containsNetwork(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+
+	return outerBits == innerBits &&
+		outerOnes <= innerOnes &&
+		outer.Contains(inner.IP)
+}
+
+func makeNetworkPolicyPorts(ports []v1alpha1.SpaceEgressPort) []networkingv1.NetworkPolicyPort {
+	var out []networkingv1.NetworkPolicyPort
+	for _, p := range ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = v1.ProtocolTCP
+		}
+		port := intstr.FromInt(int(p.Port))
+
+		out = append(out, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+
+	return out
+}
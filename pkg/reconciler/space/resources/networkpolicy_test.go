@@ -0,0 +1,165 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func ExampleMakeNetworkPolicy() {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	policy, err := MakeNetworkPolicy(space)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Name:", NetworkPolicyName(space))
+	fmt.Println("Namespace:", policy.Namespace)
+	fmt.Println("Managed by:", policy.Labels[managedByLabel])
+	fmt.Println("Policy types:", policy.Spec.PolicyTypes)
+	fmt.Println("Selects all Pods:", len(policy.Spec.PodSelector.MatchLabels) == 0)
+
+	// Output: Name: space-egress
+	// Namespace: my-space
+	// Managed by: kf
+	// Policy types: [Egress]
+	// Selects all Pods: true
+}
+
+func TestMakeNetworkPolicy(t *testing.T) {
+	cases := map[string]struct {
+		network v1alpha1.SpaceSpecNetwork
+		want    []string
+	}{
+		"default allows everything": {
+			network: v1alpha1.SpaceSpecNetwork{},
+			want: []string{
+				"to 0.0.0.0/0,::/0 on all ports",
+			},
+		},
+		"default deny has only cluster rules": {
+			network: v1alpha1.SpaceSpecNetwork{
+				DefaultEgressPolicy: v1alpha1.EgressPolicyDeny,
+			},
+			want: nil,
+		},
+		"deny rules are excluded from the default": {
+			network: v1alpha1.SpaceSpecNetwork{
+				DefaultEgressPolicy: v1alpha1.EgressPolicyAllow,
+				Egress: []v1alpha1.SpaceEgressRule{
+					{CIDR: "169.254.169.254/32", Policy: v1alpha1.EgressPolicyDeny},
+					{CIDR: "fd00::/8", Policy: v1alpha1.EgressPolicyDeny},
+				},
+			},
+			want: []string{
+				"to 0.0.0.0/0-169.254.169.254/32,::/0-fd00::/8 on all ports",
+			},
+		},
+		"allow rules with ports": {
+			network: v1alpha1.SpaceSpecNetwork{
+				DefaultEgressPolicy: v1alpha1.EgressPolicyDeny,
+				Egress: []v1alpha1.SpaceEgressRule{
+					{CIDR: "10.0.0.0/8", Policy: v1alpha1.EgressPolicyAllow, Ports: []v1alpha1.SpaceEgressPort{
+						{Port: 443},
+						{Protocol: "UDP", Port: 514},
+					}},
+					{CIDR: "10.20.0.0/16", Policy: v1alpha1.EgressPolicyDeny},
+					{CIDR: "192.168.1.7/24", Policy: v1alpha1.EgressPolicyAllow},
+				},
+			},
+			want: []string{
+				"to 10.0.0.0/8-10.20.0.0/16 on TCP/443,UDP/514",
+				"to 192.168.1.0/24 on all ports",
+			},
+		},
+		"deny takes precedence over allow": {
+			network: v1alpha1.SpaceSpecNetwork{
+				DefaultEgressPolicy: v1alpha1.EgressPolicyDeny,
+				Egress: []v1alpha1.SpaceEgressRule{
+					{CIDR: "10.20.0.0/16", Policy: v1alpha1.EgressPolicyAllow},
+					{CIDR: "10.0.0.0/8", Policy: v1alpha1.EgressPolicyDeny},
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			space := &v1alpha1.Space{}
+			space.Name = "my-space"
+			space.Spec.Network = tc.network
+
+			policy, err := MakeNetworkPolicy(space)
+			testutil.AssertNil(t, "MakeNetworkPolicy error", err)
+
+			testutil.AssertEqual(t, "cluster rules", clusterEgressRules(), policy.Spec.Egress[:2])
+			testutil.AssertEqual(t, "egress rules", tc.want, summarizeEgressRules(policy.Spec.Egress[2:]))
+		})
+	}
+}
+
+func TestClusterEgressRules_dns(t *testing.T) {
+	dnsRule := clusterEgressRules()[0]
+
+	testutil.AssertEqual(t, "peer count", 1, len(dnsRule.To))
+	peer := dnsRule.To[0]
+	testutil.AssertEqual(t, "namespace", map[string]string{"kubernetes.io/metadata.name": "kube-system"}, peer.NamespaceSelector.MatchLabels)
+	testutil.AssertEqual(t, "pods", map[string]string{"k8s-app": "kube-dns"}, peer.PodSelector.MatchLabels)
+	testutil.AssertEqual(t, "ports", 2, len(dnsRule.Ports))
+}
+
+func TestMakeNetworkPolicy_badCIDR(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Spec.Network.Egress = []v1alpha1.SpaceEgressRule{
+		{CIDR: "not-a-cidr", Policy: v1alpha1.EgressPolicyDeny},
+	}
+
+	_, err := MakeNetworkPolicy(space)
+	testutil.AssertErrorsEqual(t, fmt.Errorf("invalid CIDR address: not-a-cidr"), err)
+}
+
+// summarizeEgressRules converts IP block egress rules into a human readable
+// form of "to CIDR-EXCEPT,... on PROTOCOL/PORT,...".
+func summarizeEgressRules(rules []networkingv1.NetworkPolicyEgressRule) []string {
+	var out []string
+	for _, rule := range rules {
+		var peers []string
+		for _, peer := range rule.To {
+			peers = append(peers, strings.Join(append([]string{peer.IPBlock.CIDR}, peer.IPBlock.Except...), "-"))
+		}
+
+		ports := "all ports"
+		if len(rule.Ports) > 0 {
+			var tmp []string
+			for _, port := range rule.Ports {
+				tmp = append(tmp, fmt.Sprintf("%s/%s", *port.Protocol, port.Port.String()))
+			}
+			ports = strings.Join(tmp, ",")
+		}
+
+		out = append(out, fmt.Sprintf("to %s on %s", strings.Join(peers, ","), ports))
+	}
+
+	return out
+}
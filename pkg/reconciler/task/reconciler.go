@@ -0,0 +1,207 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
+	"github.com/google/kf/pkg/reconciler"
+	"github.com/google/kf/pkg/reconciler/task/resources"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// Reconciler reconciles a Task object with the K8s cluster.
+type Reconciler struct {
+	*reconciler.Base
+
+	// listers index properties about resources
+	taskLister  kflisters.TaskLister
+	appLister   kflisters.AppLister
+	spaceLister kflisters.SpaceLister
+	jobLister   batchv1listers.JobLister
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile is called by Kubernetes.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcileTask(
+		logging.WithLogger(ctx,
+			logging.FromContext(ctx).With("namespace", namespace)),
+		namespace,
+		name,
+	)
+}
+
+func (r *Reconciler) reconcileTask(ctx context.Context, namespace, name string) (err error) {
+	logger := logging.FromContext(ctx)
+	original, err := r.taskLister.Tasks(namespace).Get(name)
+	switch {
+	case apierrs.IsNotFound(err):
+		logger.Errorf("task %q no longer exists\n", name)
+		return nil
+
+	case err != nil:
+		return err
+
+	case original.GetDeletionTimestamp() != nil:
+		return nil
+	}
+
+	if r.IsNamespaceTerminating(namespace) {
+		logger.Errorf("skipping sync for task %q, namespace %q is terminating\n", name, namespace)
+		return nil
+	}
+
+	// Don't modify the informers copy
+	toReconcile := original.DeepCopy()
+
+	// Reconcile this copy of the task and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile)
+	if equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+
+	} else if _, uErr := r.updateStatus(namespace, toReconcile); uErr != nil {
+		logger.Warnw("Failed to update Task status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
+}
+
+// ApplyChanges updates the linked resources in the cluster with the current
+// status of the Task.
+func (r *Reconciler) ApplyChanges(ctx context.Context, task *v1alpha1.Task) error {
+	logger := logging.FromContext(ctx)
+	task.Status.InitializeConditions()
+
+	// Tasks only get run once regardless of success or failure status.
+	if v1alpha1.IsStatusFinal(task.Status.Status) {
+		return nil
+	}
+
+	app, err := r.appLister.Apps(task.Namespace).Get(task.Spec.AppName)
+	switch {
+	case apierrs.IsNotFound(err):
+		task.Status.MarkAppNotFound(task.Spec.AppName)
+		return nil
+	case err != nil:
+		return err
+	case app.Status.Image == "":
+		if task.Spec.Terminated {
+			task.Status.MarkTerminated()
+			return nil
+		}
+
+		logger.Info("Waiting for App image; exiting early")
+		task.Status.MarkAppImageNotReady(task.Spec.AppName)
+		return nil
+	}
+	task.Status.MarkAppReady()
+
+	space, err := r.spaceLister.Get(task.Namespace)
+	switch {
+	case apierrs.IsNotFound(err):
+		space = &v1alpha1.Space{}
+		space.SetDefaults(context.Background())
+	case err != nil:
+		return err
+	}
+
+	// reconcile Job
+	{
+		logger.Debug("reconciling Job")
+		condition := task.Status.JobCondition()
+		desired, err := resources.MakeJob(task, app, space)
+		if err != nil {
+			return condition.MarkTemplateError(err)
+		}
+
+		actual, err := r.jobLister.Jobs(desired.GetNamespace()).Get(desired.Name)
+		if apierrs.IsNotFound(err) {
+			if task.Spec.Terminated {
+				task.Status.MarkTerminated()
+				return nil
+			}
+
+			// Job doesn't exist, create a new one
+			actual, err = r.KubeClientSet.BatchV1().Jobs(desired.GetNamespace()).Create(desired)
+			if err != nil {
+				return condition.MarkReconciliationError("creating", err)
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if !metav1.IsControlledBy(actual, task) {
+			return condition.MarkChildNotOwned(desired.Name)
+		}
+
+		task.Status.PropagateJobStatus(actual)
+
+		if task.Spec.Terminated && condition.IsPending() {
+			logger.Info("Terminating Task")
+
+			// Delete the Pods along with the Job so the Task stops running.
+			propagationPolicy := metav1.DeletePropagationBackground
+			if err := r.KubeClientSet.
+				BatchV1().
+				Jobs(actual.Namespace).
+				Delete(actual.Name, &metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}); err != nil {
+				return condition.MarkReconciliationError("deleting", err)
+			}
+
+			task.Status.MarkTerminated()
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateStatus(namespace string, desired *v1alpha1.Task) (*v1alpha1.Task, error) {
+	actual, err := r.taskLister.Tasks(namespace).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().Tasks(namespace).UpdateStatus(existing)
+}
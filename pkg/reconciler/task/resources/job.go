@@ -0,0 +1,122 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/internal/envutil"
+	appresources "github.com/google/kf/pkg/reconciler/app/resources"
+	"github.com/knative/serving/pkg/resources"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// UserContainerName is the name of the container the Task runs in. It
+	// matches the name Knative uses for App containers so logs can be read
+	// the same way.
+	UserContainerName = "user-container"
+)
+
+// JobName gets the name of a Job for a Task.
+func JobName(task *v1alpha1.Task) string {
+	return task.Name
+}
+
+// MakeJob creates a Job that runs the Task using the App's image,
+// environment and injected VCAP variables.
+func MakeJob(
+	task *v1alpha1.Task,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+) (*batchv1.Job, error) {
+
+	image := app.Status.Image
+	if image == "" {
+		return nil, errors.New("waiting for source image in latestReadySource")
+	}
+
+	// don't modify the spec on the app
+	podSpec := app.Spec.Template.Spec.DeepCopy()
+
+	// At this point in the lifecycle there should be exactly one container
+	// if the webhhook is working but create one to avoid panics just in case.
+	if len(podSpec.Containers) == 0 {
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+	}
+
+	container := &podSpec.Containers[0]
+	container.Name = UserContainerName
+	container.Image = image
+
+	// The command is passed as a single argument so images built with
+	// buildpacks run it through their launcher with the App's environment.
+	container.Args = []string{task.Spec.Command}
+
+	// Tasks run to completion so they never become ready or live.
+	container.ReadinessProbe = nil
+	container.LivenessProbe = nil
+	container.Ports = nil
+
+	// Execution environment variables come before others because they're built
+	// to be overridden.
+	container.Env = append(space.Spec.Execution.Env, container.Env...)
+	container.Env = envutil.DeduplicateEnvVars(container.Env)
+
+	// Inject VCAP env vars from secret
+	container.EnvFrom = []corev1.EnvFromSource{
+		{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: appresources.KfInjectedEnvSecretName(app),
+				},
+			},
+		},
+	}
+
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	labels := resources.UnionMaps(
+		app.ComponentLabels("task"),
+		map[string]string{v1alpha1.TaskNameLabel: task.Name},
+	)
+
+	// Tasks are only run once, retries are up to the user.
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(task),
+			Namespace: task.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(task),
+			},
+			Labels: resources.UnionMaps(task.GetLabels(), labels),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
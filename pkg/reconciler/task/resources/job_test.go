@@ -0,0 +1,98 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the License);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an AS IS BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func ExampleJobName() {
+	task := &v1alpha1.Task{}
+	task.Name = "my-task"
+
+	fmt.Println(JobName(task))
+
+	// Output: my-task
+}
+
+func ExampleMakeJob() {
+	task := &v1alpha1.Task{}
+	task.Name = "my-task"
+	task.Namespace = "my-namespace"
+	task.Spec.AppName = "my-app"
+	task.Spec.Command = "rake db:migrate"
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Status.Image = "gcr.io/my-app:123"
+	app.Spec.Template.Spec.Containers = []corev1.Container{{
+		Env: []corev1.EnvVar{{Name: "APP_VAR", Value: "app"}},
+		ReadinessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{},
+			},
+		},
+	}}
+
+	space := &v1alpha1.Space{}
+	space.Spec.Execution.Env = []corev1.EnvVar{{Name: "SPACE_VAR", Value: "space"}}
+
+	job, err := MakeJob(task, app, space)
+	if err != nil {
+		panic(err)
+	}
+
+	container := job.Spec.Template.Spec.Containers[0]
+
+	fmt.Println("Name:", job.Name)
+	fmt.Println("Namespace:", job.Namespace)
+	fmt.Println("Task label:", job.Labels[v1alpha1.TaskNameLabel])
+	fmt.Println("Pod task label:", job.Spec.Template.Labels[v1alpha1.TaskNameLabel])
+	fmt.Println("Backoff limit:", *job.Spec.BackoffLimit)
+	fmt.Println("Restart policy:", job.Spec.Template.Spec.RestartPolicy)
+	fmt.Println("Container:", container.Name)
+	fmt.Println("Image:", container.Image)
+	fmt.Println("Args:", container.Args)
+	fmt.Println("Has readiness probe:", container.ReadinessProbe != nil)
+	fmt.Println("Env count:", len(container.Env))
+	fmt.Println("EnvFrom secret:", container.EnvFrom[0].SecretRef.Name)
+
+	// Output: Name: my-task
+	// Namespace: my-namespace
+	// Task label: my-task
+	// Pod task label: my-task
+	// Backoff limit: 0
+	// Restart policy: Never
+	// Container: user-container
+	// Image: gcr.io/my-app:123
+	// Args: [rake db:migrate]
+	// Has readiness probe: false
+	// Env count: 2
+	// EnvFrom secret: kf-injected-envs-my-app
+}
+
+func ExampleMakeJob_noImage() {
+	task := &v1alpha1.Task{}
+	app := &v1alpha1.App{}
+	space := &v1alpha1.Space{}
+
+	_, err := MakeJob(task, app, space)
+	fmt.Println("Error:", err)
+
+	// Output: Error: waiting for source image in latestReadySource
+}
@@ -0,0 +1,24 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package routeservice implements the proxy that sends a route's traffic
+// through its route service.
+//
+// Requests to a route with a route service bound reach the proxy first. The
+// proxy sends requests without a signature to the route service with the
+// X-CF-Forwarded-Url, X-CF-Proxy-Signature and X-CF-Proxy-Metadata headers
+// set. The route service forwards the requests it accepts to the
+// X-CF-Forwarded-Url, which brings them back to the proxy. The proxy checks
+// their signature and sends them on to the route's Apps.
+package routeservice
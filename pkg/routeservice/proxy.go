@@ -0,0 +1,222 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routeservice
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+)
+
+const (
+	// The headers Cloud Foundry sends to route services, see
+	// https://docs.cloudfoundry.org/services/route-services.html#headers
+	ForwardedURLHeader   = "X-CF-Forwarded-Url"
+	ProxySignatureHeader = "X-CF-Proxy-Signature"
+	ProxyMetadataHeader  = "X-CF-Proxy-Metadata"
+
+	// URLHeader, TokenHeader and GatewayHeader are set by the VirtualService
+	// on requests it sends to the proxy. They hold the route service's URL,
+	// the token that lets checked requests through to the Apps and the
+	// gateway the route is served on.
+	URLHeader     = "X-Kf-Route-Service-Url"
+	TokenHeader   = "X-Kf-Route-Service-Token"
+	GatewayHeader = "X-Kf-Route-Service-Gateway"
+
+	// HopHeader is set on requests the proxy sends back to the gateway so
+	// they aren't sent around in a loop if they don't reach the Apps.
+	HopHeader = "X-Kf-Route-Service-Hop"
+
+	// DefaultSignatureTimeout is how long a route service has to forward a
+	// request back, it matches Cloud Foundry's default.
+	DefaultSignatureTimeout = 60 * time.Second
+)
+
+// Proxy is an http.Handler that sends requests through their route service.
+type Proxy struct {
+	// Crypto signs requests sent to route services and checks requests they
+	// forward back.
+	Crypto *Crypto
+
+	// Gateways holds the hosts requests can be sent back to.
+	Gateways map[string]bool
+
+	// Timeout is how long signatures are valid for.
+	Timeout time.Duration
+
+	// Transport sends the requests, http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+
+	// Now returns the current time, time.Now is used if nil.
+	Now func() time.Time
+}
+
+var _ http.Handler = (*Proxy)(nil)
+
+// ServeHTTP implements http.Handler.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serviceURL := r.Header.Get(URLHeader)
+	token := r.Header.Get(TokenHeader)
+	gateway := r.Header.Get(GatewayHeader)
+	if serviceURL == "" || token == "" || !p.Gateways[gateway] {
+		http.Error(w, "the request wasn't sent by a route", http.StatusBadRequest)
+		return
+	}
+
+	if r.Header.Get(HopHeader) != "" {
+		http.Error(w, "the route service request was sent in a loop", http.StatusLoopDetected)
+		return
+	}
+
+	forwardedURL := ForwardedURL(r)
+
+	// Requests with a signature were forwarded back by the route service.
+	if header := r.Header.Get(ProxySignatureHeader); header != "" {
+		if err := p.validate(header, forwardedURL, serviceURL); err != nil {
+			log.Printf("rejected request to %s: %s", forwardedURL, err)
+			http.Error(w, "failed to validate the route service signature", http.StatusBadGateway)
+			return
+		}
+
+		p.forward(w, r, &url.URL{Scheme: "http", Host: gateway}, func(req *http.Request) {
+			req.Header.Del(ForwardedURLHeader)
+			req.Header.Del(ProxySignatureHeader)
+			req.Header.Del(ProxyMetadataHeader)
+			req.Header.Set(TokenHeader, token)
+			req.Header.Set(HopHeader, "1")
+		})
+		return
+	}
+
+	target, err := url.Parse(serviceURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		http.Error(w, "the route service URL is invalid", http.StatusBadGateway)
+		return
+	}
+
+	signature, err := p.Crypto.Seal(Signature{
+		RequestedTime:   p.now(),
+		ForwardedURL:    forwardedURL,
+		RouteServiceURL: serviceURL,
+	})
+	if err != nil {
+		log.Printf("failed to sign request to %s: %s", forwardedURL, err)
+		http.Error(w, "failed to sign the request", http.StatusInternalServerError)
+		return
+	}
+
+	p.forward(w, r, target, func(req *http.Request) {
+		// Requests go to the route service's URL, the original URL is in the
+		// forwarded URL header.
+		req.URL.Path = target.Path
+		req.URL.RawPath = target.RawPath
+		req.URL.RawQuery = target.RawQuery
+		req.Host = target.Host
+		req.Header.Set(ForwardedURLHeader, forwardedURL)
+		req.Header.Set(ProxySignatureHeader, signature)
+	})
+}
+
+// validate checks that the signature was made by the proxy for the route
+// service and the URL of the request, and that it hasn't expired.
+func (p *Proxy) validate(header, forwardedURL, serviceURL string) error {
+	signature, err := p.Crypto.Open(header)
+	if err != nil {
+		return err
+	}
+
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = DefaultSignatureTimeout
+	}
+
+	switch age := p.now().Sub(signature.RequestedTime); {
+	case age > timeout:
+		return fmt.Errorf("signature expired %s ago", age-timeout)
+	case age < -timeout:
+		return fmt.Errorf("signature is from the future")
+	}
+
+	if signature.RouteServiceURL != serviceURL {
+		return fmt.Errorf("signature was made for route service %q", signature.RouteServiceURL)
+	}
+
+	if !sameRequestURL(signature.ForwardedURL, forwardedURL) {
+		return fmt.Errorf("signature was made for %q", signature.ForwardedURL)
+	}
+
+	return nil
+}
+
+// forward proxies the request to the target with the kf headers removed,
+// the request is edited by modify before it's sent.
+func (p *Proxy) forward(w http.ResponseWriter, r *http.Request, target *url.URL, modify func(*http.Request)) {
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+
+			req.Header.Del(URLHeader)
+			req.Header.Del(TokenHeader)
+			req.Header.Del(GatewayHeader)
+			req.Header.Del(HopHeader)
+
+			modify(req)
+		},
+		Transport: p.Transport,
+	}
+
+	proxy.ServeHTTP(w, r)
+}
+
+func (p *Proxy) now() time.Time {
+	if p.Now == nil {
+		return time.Now()
+	}
+
+	return p.Now()
+}
+
+// ForwardedURL gets the URL a request was sent to from its Host, path and
+// query. The scheme is taken from the X-Forwarded-Proto header the gateway
+// sets.
+func ForwardedURL(r *http.Request) string {
+	scheme := r.Header.Get("X-Forwarded-Proto")
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// sameRequestURL checks if two URLs have the same host, path and query.
+// Route services may forward requests over HTTP or HTTPS so the scheme is
+// ignored.
+func sameRequestURL(a, b string) bool {
+	aURL, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	bURL, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return aURL.Host == bURL.Host && aURL.RequestURI() == bURL.RequestURI()
+}
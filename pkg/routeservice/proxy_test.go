@@ -0,0 +1,206 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routeservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+// recorder is a backend that records the last request it received.
+type recorder struct {
+	*httptest.Server
+	last *http.Request
+}
+
+func newRecorder() *recorder {
+	r := &recorder{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.last = req
+	}))
+	return r
+}
+
+func (r *recorder) host() string {
+	u, _ := url.Parse(r.URL)
+	return u.Host
+}
+
+type proxyFixture struct {
+	proxy        *Proxy
+	routeService *recorder
+	gateway      *recorder
+	now          time.Time
+}
+
+func newProxyFixture(t *testing.T) *proxyFixture {
+	crypto, err := NewCrypto([]byte("some-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &proxyFixture{
+		routeService: newRecorder(),
+		gateway:      newRecorder(),
+		now:          time.Now(),
+	}
+	f.proxy = &Proxy{
+		Crypto:   crypto,
+		Gateways: map[string]bool{f.gateway.host(): true},
+		Now:      func() time.Time { return f.now },
+	}
+
+	return f
+}
+
+func (f *proxyFixture) close() {
+	f.routeService.Close()
+	f.gateway.Close()
+}
+
+// request creates a request like the VirtualService sends to the proxy.
+func (f *proxyFixture) request(target string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set(URLHeader, f.routeService.URL+"/check")
+	req.Header.Set(TokenHeader, "some-token")
+	req.Header.Set(GatewayHeader, f.gateway.host())
+	req.Header.Set(ProxyMetadataHeader, "some-metadata")
+	return req
+}
+
+func (f *proxyFixture) serve(req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	f.proxy.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestProxy_roundTrip(t *testing.T) {
+	f := newProxyFixture(t)
+	defer f.close()
+
+	// The first request goes to the route service.
+	rec := f.serve(f.request("http://some-host.example.com/some-path?q=1"))
+	testutil.AssertEqual(t, "status", http.StatusOK, rec.Code)
+
+	sent := f.routeService.last
+	testutil.AssertEqual(t, "route service path", "/check", sent.URL.Path)
+	testutil.AssertEqual(t, "route service Host", f.routeService.host(), sent.Host)
+	testutil.AssertEqual(t, "forwarded URL", "http://some-host.example.com/some-path?q=1", sent.Header.Get(ForwardedURLHeader))
+	testutil.AssertEqual(t, "metadata", "some-metadata", sent.Header.Get(ProxyMetadataHeader))
+	testutil.AssertEqual(t, "token removed", "", sent.Header.Get(TokenHeader))
+	testutil.AssertEqual(t, "URL removed", "", sent.Header.Get(URLHeader))
+
+	signature := sent.Header.Get(ProxySignatureHeader)
+	testutil.AssertNotBlank(t, "signature", signature)
+
+	// The route service forwards it back with the signature.
+	back := f.request(sent.Header.Get(ForwardedURLHeader))
+	back.Header.Set(ProxySignatureHeader, signature)
+	f.now = f.now.Add(time.Second)
+
+	rec = f.serve(back)
+	testutil.AssertEqual(t, "status", http.StatusOK, rec.Code)
+
+	sent = f.gateway.last
+	testutil.AssertEqual(t, "gateway Host", "some-host.example.com", sent.Host)
+	testutil.AssertEqual(t, "gateway path", "/some-path", sent.URL.Path)
+	testutil.AssertEqual(t, "gateway query", "q=1", sent.URL.RawQuery)
+	testutil.AssertEqual(t, "token", "some-token", sent.Header.Get(TokenHeader))
+	testutil.AssertEqual(t, "hop", "1", sent.Header.Get(HopHeader))
+	testutil.AssertEqual(t, "signature removed", "", sent.Header.Get(ProxySignatureHeader))
+}
+
+func TestProxy_rejects(t *testing.T) {
+	cases := map[string]struct {
+		modify     func(f *proxyFixture, req *http.Request)
+		wantStatus int
+	}{
+		"expired signature": {
+			modify: func(f *proxyFixture, req *http.Request) {
+				f.now = f.now.Add(DefaultSignatureTimeout + time.Second)
+			},
+			wantStatus: http.StatusBadGateway,
+		},
+		"signature for another URL": {
+			modify: func(f *proxyFixture, req *http.Request) {
+				req.URL.Path = "/other-path"
+			},
+			wantStatus: http.StatusBadGateway,
+		},
+		"signature for another route service": {
+			modify: func(f *proxyFixture, req *http.Request) {
+				req.Header.Set(URLHeader, "https://other.example.com")
+			},
+			wantStatus: http.StatusBadGateway,
+		},
+		"forged signature": {
+			modify: func(f *proxyFixture, req *http.Request) {
+				req.Header.Set(ProxySignatureHeader, "forged")
+			},
+			wantStatus: http.StatusBadGateway,
+		},
+		"not sent by a route": {
+			modify: func(f *proxyFixture, req *http.Request) {
+				req.Header.Del(TokenHeader)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		"unknown gateway": {
+			modify: func(f *proxyFixture, req *http.Request) {
+				req.Header.Set(GatewayHeader, "evil.example.com")
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		"loop": {
+			modify: func(f *proxyFixture, req *http.Request) {
+				req.Header.Set(HopHeader, "1")
+			},
+			wantStatus: http.StatusLoopDetected,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			f := newProxyFixture(t)
+			defer f.close()
+
+			f.serve(f.request("http://some-host.example.com/some-path"))
+			signature := f.routeService.last.Header.Get(ProxySignatureHeader)
+
+			back := f.request("http://some-host.example.com/some-path")
+			back.Header.Set(ProxySignatureHeader, signature)
+			tc.modify(f, back)
+
+			rec := f.serve(back)
+			testutil.AssertEqual(t, "status", tc.wantStatus, rec.Code)
+			testutil.AssertEqual(t, "reached gateway", false, f.gateway.last != nil)
+		})
+	}
+}
+
+func TestForwardedURL(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "http://some-host.example.com/some-path?q=1&r=2", nil)
+	testutil.AssertEqual(t, "http", "http://some-host.example.com/some-path?q=1&r=2", ForwardedURL(req))
+
+	req.Header.Set("X-Forwarded-Proto", "https")
+	testutil.AssertEqual(t, "https", "https://some-host.example.com/some-path?q=1&r=2", ForwardedURL(req))
+}
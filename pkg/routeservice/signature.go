@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routeservice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Signature is the content of the X-CF-Proxy-Signature header. It's
+// encrypted so route services can't read or change it.
+type Signature struct {
+	// RequestedTime is when the proxy sent the request to the route service.
+	RequestedTime time.Time `json:"requested_time"`
+
+	// ForwardedURL is the URL the route service must forward the request to.
+	ForwardedURL string `json:"forwarded_url"`
+
+	// RouteServiceURL is the route service the request was sent to. It ties
+	// the signature to the route service bound to the route so signatures
+	// made for other route services can't be used.
+	RouteServiceURL string `json:"route_service_url"`
+}
+
+// Crypto encrypts and decrypts Signatures.
+type Crypto struct {
+	aead cipher.AEAD
+}
+
+// NewCrypto creates a Crypto that uses AES-GCM with a key derived from the
+// given one.
+func NewCrypto(key []byte) (*Crypto, error) {
+	if len(key) == 0 {
+		return nil, errors.New("the route service key must not be empty")
+	}
+
+	sum := sha256.Sum256(key)
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Crypto{aead: aead}, nil
+}
+
+// Seal encrypts the Signature into the value of the X-CF-Proxy-Signature
+// header.
+func (c *Crypto) Seal(signature Signature) (string, error) {
+	plaintext, err := json.Marshal(signature)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %s", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts the value of an X-CF-Proxy-Signature header.
+func (c *Crypto) Open(header string) (*Signature, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %s", err)
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("malformed signature: too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.New("signature wasn't made by Kf")
+	}
+
+	signature := &Signature{}
+	if err := json.Unmarshal(plaintext, signature); err != nil {
+		return nil, fmt.Errorf("malformed signature: %s", err)
+	}
+
+	return signature, nil
+}
+
+// Token creates the value of the TokenHeader for a route service bound to a
+// RouteClaim. The VirtualService only sends requests carrying the token to
+// the Apps, and only the proxy sets it, after it checked the signature.
+func Token(key []byte, claimUID, routeServiceURL string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(claimUID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(routeServiceURL))
+	return hex.EncodeToString(mac.Sum(nil))
+}
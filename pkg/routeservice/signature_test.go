@@ -0,0 +1,78 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routeservice
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestCrypto(t *testing.T) {
+	t.Parallel()
+
+	crypto, err := NewCrypto([]byte("some-key"))
+	testutil.AssertNil(t, "err", err)
+
+	want := Signature{
+		RequestedTime:   time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		ForwardedURL:    "http://some-host.example.com/some-path?q=1",
+		RouteServiceURL: "https://ratelimiter.example.com",
+	}
+
+	sealed, err := crypto.Seal(want)
+	testutil.AssertNil(t, "seal err", err)
+
+	other, err := crypto.Seal(want)
+	testutil.AssertNil(t, "seal err", err)
+	testutil.AssertEqual(t, "signatures differ", true, sealed != other)
+
+	got, err := crypto.Open(sealed)
+	testutil.AssertNil(t, "open err", err)
+	testutil.AssertEqual(t, "signature", want, *got)
+
+	otherCrypto, err := NewCrypto([]byte("other-key"))
+	testutil.AssertNil(t, "err", err)
+	_, err = otherCrypto.Open(sealed)
+	testutil.AssertErrorsEqual(t, errors.New("signature wasn't made by Kf"), err)
+
+	_, err = crypto.Open("not base64!")
+	testutil.AssertErrorContainsAll(t, err, []string{"malformed signature"})
+
+	_, err = crypto.Open("")
+	testutil.AssertErrorContainsAll(t, err, []string{"malformed signature: too short"})
+}
+
+func TestNewCrypto_emptyKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCrypto(nil)
+	testutil.AssertErrorsEqual(t, errors.New("the route service key must not be empty"), err)
+}
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("some-key")
+	token := Token(key, "some-uid", "https://ratelimiter.example.com")
+
+	testutil.AssertEqual(t, "length", 64, len(token))
+	testutil.AssertEqual(t, "stable", token, Token(key, "some-uid", "https://ratelimiter.example.com"))
+	testutil.AssertEqual(t, "differs by key", true, token != Token([]byte("other-key"), "some-uid", "https://ratelimiter.example.com"))
+	testutil.AssertEqual(t, "differs by claim", true, token != Token(key, "other-uid", "https://ratelimiter.example.com"))
+	testutil.AssertEqual(t, "differs by URL", true, token != Token(key, "some-uid", "https://auth.example.com"))
+}
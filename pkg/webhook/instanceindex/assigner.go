@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instanceindex
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	v1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// reservationTTL is how long an index handed to a new Pod stays reserved
+// while waiting for the Pod to show up in the lister.
+const reservationTTL = 2 * time.Minute
+
+// Assigner hands out instance indexes to new Pods. Indexes are reserved when
+// they're handed out so Pods that are created at the same time, before the
+// lister sees any of them, get different indexes.
+type Assigner struct {
+	podLister v1listers.PodLister
+	now       func() time.Time
+
+	mu       sync.Mutex
+	reserved map[string]map[int]time.Time
+}
+
+// NewAssigner creates an Assigner that reads the existing Pods of the App's
+// processes from the lister.
+func NewAssigner(podLister v1listers.PodLister) *Assigner {
+	return &Assigner{
+		podLister: podLister,
+		now:       time.Now,
+		reserved:  make(map[string]map[int]time.Time),
+	}
+}
+
+// Assign returns the instance index for a new Pod. False is returned if the
+// Pod doesn't run a process of an App.
+func (a *Assigner) Assign(pod *corev1.Pod) (int, bool, error) {
+	selector, ok := processSelector(pod)
+	if !ok {
+		return 0, false, nil
+	}
+
+	pods, err := a.podLister.Pods(pod.Namespace).List(labels.SelectorFromSet(selector))
+	if err != nil {
+		return 0, false, fmt.Errorf("couldn't list the Pods of the process: %v", err)
+	}
+
+	held := make(map[int]bool)
+	for _, p := range pods {
+		if index, ok := v1alpha1.InstanceIndexOf(p); ok {
+			held[index] = true
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := pod.Namespace + "/" + selector.String()
+	now := a.now()
+
+	// Reservations are released once a Pod holds the index or they expire
+	// because the Pod was never created.
+	reserved := make(map[int]bool)
+	for index, expiry := range a.reserved[key] {
+		if held[index] || now.After(expiry) {
+			delete(a.reserved[key], index)
+			continue
+		}
+		reserved[index] = true
+	}
+
+	index := v1alpha1.FreeInstanceIndex(pods, reserved)
+
+	if a.reserved[key] == nil {
+		a.reserved[key] = make(map[int]time.Time)
+	}
+	a.reserved[key][index] = now.Add(reservationTTL)
+
+	return index, true, nil
+}
+
+// processSelector gets the labels that select the Pods of the same process
+// as the Pod. The web process and each of the other processes are numbered
+// separately.
+func processSelector(pod *corev1.Pod) (labels.Set, bool) {
+	if pod.Labels[v1alpha1.ManagedByLabel] != "kf" || pod.Labels[v1alpha1.NameLabel] == "" {
+		return nil, false
+	}
+
+	selector := labels.Set{
+		v1alpha1.ManagedByLabel: "kf",
+		v1alpha1.NameLabel:      pod.Labels[v1alpha1.NameLabel],
+	}
+
+	switch component := pod.Labels[v1alpha1.ComponentLabel]; component {
+	case "app-server":
+		selector[v1alpha1.ComponentLabel] = component
+	case "process":
+		selector[v1alpha1.ComponentLabel] = component
+		selector[v1alpha1.ProcessTypeLabel] = pod.Labels[v1alpha1.ProcessTypeLabel]
+	default:
+		return nil, false
+	}
+
+	return selector, true
+}
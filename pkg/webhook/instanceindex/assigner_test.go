@@ -0,0 +1,161 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instanceindex
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func webPod(name, app, index string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "some-space",
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.NameLabel:      app,
+				v1alpha1.ComponentLabel: "app-server",
+			},
+		},
+	}
+
+	if index != "" {
+		pod.Annotations = map[string]string{v1alpha1.InstanceIndexAnnotation: index}
+	}
+
+	return pod
+}
+
+func processPod(name, app, processType, index string) *corev1.Pod {
+	pod := webPod(name, app, index)
+	pod.Labels[v1alpha1.ComponentLabel] = "process"
+	pod.Labels[v1alpha1.ProcessTypeLabel] = processType
+	return pod
+}
+
+func newTestAssigner(t *testing.T, pods ...*corev1.Pod) *Assigner {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+	})
+	for _, pod := range pods {
+		if err := indexer.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewAssigner(v1listers.NewPodLister(indexer))
+}
+
+func TestAssigner_Assign(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		existing []*corev1.Pod
+		pod      *corev1.Pod
+		wantOK   bool
+		wantIdx  int
+	}{
+		"first pod": {
+			pod:     webPod("new", "my-app", v1alpha1.UnassignedInstanceIndex),
+			wantOK:  true,
+			wantIdx: 0,
+		},
+		"next free index": {
+			existing: []*corev1.Pod{
+				webPod("a", "my-app", "0"),
+				webPod("b", "my-app", "2"),
+			},
+			pod:     webPod("new", "my-app", v1alpha1.UnassignedInstanceIndex),
+			wantOK:  true,
+			wantIdx: 1,
+		},
+		"other apps are ignored": {
+			existing: []*corev1.Pod{
+				webPod("a", "other-app", "0"),
+			},
+			pod:     webPod("new", "my-app", v1alpha1.UnassignedInstanceIndex),
+			wantOK:  true,
+			wantIdx: 0,
+		},
+		"processes are numbered separately": {
+			existing: []*corev1.Pod{
+				webPod("a", "my-app", "0"),
+				processPod("b", "my-app", "worker", "0"),
+				processPod("c", "my-app", "clock", "1"),
+			},
+			pod:     processPod("new", "my-app", "worker", v1alpha1.UnassignedInstanceIndex),
+			wantOK:  true,
+			wantIdx: 1,
+		},
+		"pods outside of kf are skipped": {
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "some-space"},
+			},
+			wantOK: false,
+		},
+		"other kf components are skipped": {
+			pod: func() *corev1.Pod {
+				pod := webPod("new", "my-app", "")
+				pod.Labels[v1alpha1.ComponentLabel] = "build"
+				return pod
+			}(),
+			wantOK: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			assigner := newTestAssigner(t, tc.existing...)
+
+			index, ok, err := assigner.Assign(tc.pod)
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "ok", tc.wantOK, ok)
+			if tc.wantOK {
+				testutil.AssertEqual(t, "index", tc.wantIdx, index)
+			}
+		})
+	}
+}
+
+func TestAssigner_Assign_reservations(t *testing.T) {
+	t.Parallel()
+
+	assigner := newTestAssigner(t)
+	now := time.Now()
+	assigner.now = func() time.Time { return now }
+
+	var got []int
+	for i := 0; i < 3; i++ {
+		index, _, err := assigner.Assign(webPod(fmt.Sprintf("new-%d", i), "my-app", ""))
+		testutil.AssertNil(t, "err", err)
+		got = append(got, index)
+	}
+	testutil.AssertEqual(t, "concurrent indexes", []int{0, 1, 2}, got)
+
+	// Reservations for Pods that never showed up expire.
+	now = now.Add(reservationTTL + time.Second)
+	index, _, err := assigner.Assign(webPod("later", "my-app", ""))
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "index after expiry", 0, index)
+}
@@ -0,0 +1,21 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package instanceindex is an admission webhook that gives each new Pod of an
+// App a stable ordinal, like Cloud Foundry's instance index.
+//
+// Containers read the index through the downward API when they start, so it
+// has to be on the Pod before it's scheduled rather than added afterwards by
+// the App controller.
+package instanceindex
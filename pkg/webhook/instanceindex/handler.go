@@ -0,0 +1,126 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instanceindex
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"go.uber.org/zap"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Handler is an admission webhook that sets the InstanceIndexAnnotation of
+// new App Pods. It never rejects a Pod, Pods it can't assign an index to
+// keep the unassigned index from their template.
+type Handler struct {
+	Assigner *Assigner
+	Logger   *zap.SugaredLogger
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// patchOperation is a single JSON patch operation, see
+// http://jsonpatch.com/
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var review admissionv1beta1.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		http.Error(w, "couldn't decode the admission review: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if review.Request == nil {
+		http.Error(w, "the admission review has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.Admit(review.Request)
+	review.Response.UID = review.Request.UID
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.Logger.Warnf("couldn't encode the admission review: %s", err)
+	}
+}
+
+// Admit adds the instance index to Pods being created.
+func (h *Handler) Admit(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	allowed := &admissionv1beta1.AdmissionResponse{Allowed: true}
+
+	if req.Operation != admissionv1beta1.Create || req.Kind.Kind != "Pod" {
+		return allowed
+	}
+
+	var pod corev1.Pod
+	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
+		h.Logger.Warnf("couldn't decode Pod: %s", err)
+		return allowed
+	}
+	pod.Namespace = req.Namespace
+
+	index, ok, err := h.Assigner.Assign(&pod)
+	switch {
+	case err != nil:
+		h.Logger.Warnf("couldn't assign an instance index in %s: %s", req.Namespace, err)
+		return allowed
+	case !ok:
+		return allowed
+	}
+
+	patch, err := json.Marshal(makeIndexPatch(&pod, index))
+	if err != nil {
+		h.Logger.Warnf("couldn't encode patch: %s", err)
+		return allowed
+	}
+
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	allowed.Patch = patch
+	allowed.PatchType = &patchType
+	return allowed
+}
+
+// makeIndexPatch creates the patch that sets the Pod's instance index.
+func makeIndexPatch(pod *corev1.Pod, index int) []patchOperation {
+	value := strconv.Itoa(index)
+
+	if pod.Annotations == nil {
+		return []patchOperation{{
+			Op:    "add",
+			Path:  "/metadata/annotations",
+			Value: map[string]string{v1alpha1.InstanceIndexAnnotation: value},
+		}}
+	}
+
+	// Slashes in keys are escaped as ~1 in JSON pointers, see
+	// https://tools.ietf.org/html/rfc6901
+	key := strings.Replace(v1alpha1.InstanceIndexAnnotation, "/", "~1", -1)
+
+	return []patchOperation{{
+		Op:    "add",
+		Path:  "/metadata/annotations/" + key,
+		Value: value,
+	}}
+}
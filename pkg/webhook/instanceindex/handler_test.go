@@ -0,0 +1,147 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instanceindex
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"go.uber.org/zap"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func podRequest(t *testing.T, pod *corev1.Pod) *admissionv1beta1.AdmissionRequest {
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &admissionv1beta1.AdmissionRequest{
+		UID:       types.UID("some-uid"),
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Namespace: "some-space",
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+func TestHandler_Admit(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		req       func(t *testing.T) *admissionv1beta1.AdmissionRequest
+		wantPatch []patchOperation
+	}{
+		"pod with annotations": {
+			req: func(t *testing.T) *admissionv1beta1.AdmissionRequest {
+				return podRequest(t, webPod("new", "my-app", v1alpha1.UnassignedInstanceIndex))
+			},
+			wantPatch: []patchOperation{{
+				Op:    "add",
+				Path:  "/metadata/annotations/kf.dev~1instance-index",
+				Value: "0",
+			}},
+		},
+		"pod without annotations": {
+			req: func(t *testing.T) *admissionv1beta1.AdmissionRequest {
+				return podRequest(t, webPod("new", "my-app", ""))
+			},
+			wantPatch: []patchOperation{{
+				Op:    "add",
+				Path:  "/metadata/annotations",
+				Value: map[string]interface{}{v1alpha1.InstanceIndexAnnotation: "0"},
+			}},
+		},
+		"pod outside of kf": {
+			req: func(t *testing.T) *admissionv1beta1.AdmissionRequest {
+				return podRequest(t, &corev1.Pod{})
+			},
+		},
+		"updates are skipped": {
+			req: func(t *testing.T) *admissionv1beta1.AdmissionRequest {
+				req := podRequest(t, webPod("new", "my-app", ""))
+				req.Operation = admissionv1beta1.Update
+				return req
+			},
+		},
+		"bad pod": {
+			req: func(t *testing.T) *admissionv1beta1.AdmissionRequest {
+				req := podRequest(t, webPod("new", "my-app", ""))
+				req.Object.Raw = []byte("{")
+				return req
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			handler := &Handler{
+				Assigner: newTestAssigner(t),
+				Logger:   zap.NewNop().Sugar(),
+			}
+
+			resp := handler.Admit(tc.req(t))
+			testutil.AssertEqual(t, "allowed", true, resp.Allowed)
+
+			var patch []patchOperation
+			if len(resp.Patch) > 0 {
+				if err := json.Unmarshal(resp.Patch, &patch); err != nil {
+					t.Fatal(err)
+				}
+			}
+			testutil.AssertEqual(t, "patch", tc.wantPatch, patch)
+		})
+	}
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{
+		Assigner: newTestAssigner(t),
+		Logger:   zap.NewNop().Sugar(),
+	}
+
+	body, err := json.Marshal(admissionv1beta1.AdmissionReview{
+		Request: podRequest(t, webPod("new", "my-app", "")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+	testutil.AssertEqual(t, "status", http.StatusOK, rec.Code)
+
+	var review admissionv1beta1.AdmissionReview
+	if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, "uid", types.UID("some-uid"), review.Response.UID)
+	testutil.AssertEqual(t, "allowed", true, review.Response.Allowed)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{}"))))
+	testutil.AssertEqual(t, "status without request", http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,202 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instanceindex
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// WebhookName is the name of the MutatingWebhookConfiguration that sends
+	// new Pods to the webhook.
+	WebhookName = "instance-index.webhook.kf.dev"
+
+	// certificateValidity is how long the webhook's certificate is valid
+	// for. A new certificate is made every time the webhook starts.
+	certificateValidity = 10 * 365 * 24 * time.Hour
+)
+
+// Options configures how the webhook is served.
+type Options struct {
+	// ServiceName is the name of the Service in front of the webhook.
+	ServiceName string
+
+	// Namespace is the namespace of the Service.
+	Namespace string
+
+	// Port is the port the webhook listens on.
+	Port int
+}
+
+// Run registers the webhook and serves it until stopCh is closed.
+func Run(kubeClient kubernetes.Interface, handler http.Handler, opts Options, stopCh <-chan struct{}) error {
+	certPEM, keyPEM, err := MakeCertificate(opts, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := register(kubeClient, opts, certPEM); err != nil {
+		return fmt.Errorf("failed to register webhook: %v", err)
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %v", err)
+	}
+
+	server := &http.Server{
+		Addr:      fmt.Sprintf(":%d", opts.Port),
+		Handler:   handler,
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}},
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServeTLS("", ""); err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-stopCh:
+		return server.Close()
+	case err := <-errCh:
+		return err
+	}
+}
+
+// MakeCertificate creates a self-signed certificate for the webhook's
+// Service. The certificate is its own CA so it's also the CA bundle the API
+// server uses to trust the webhook.
+func MakeCertificate(opts Options, now time.Time) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %s", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate serial number: %s", err)
+	}
+
+	host := fmt.Sprintf("%s.%s.svc", opts.ServiceName, opts.Namespace)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host, Organization: []string{"kf"}},
+		DNSNames:              []string{opts.ServiceName, fmt.Sprintf("%s.%s", opts.ServiceName, opts.Namespace), host, host + ".cluster.local"},
+		NotBefore:             now,
+		NotAfter:              now.Add(certificateValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate: %s", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal key: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}),
+		nil
+}
+
+// MakeWebhookConfiguration creates the configuration that sends new Pods in
+// Kf's spaces to the webhook. Failures are ignored so Pods can still be
+// created while the webhook is down, they just don't get an index.
+func MakeWebhookConfiguration(opts Options, caBundle []byte) *admissionregistrationv1beta1.MutatingWebhookConfiguration {
+	failurePolicy := admissionregistrationv1beta1.Ignore
+	path := "/"
+
+	return &admissionregistrationv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: WebhookName,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+			},
+		},
+		Webhooks: []admissionregistrationv1beta1.Webhook{{
+			Name: WebhookName,
+			Rules: []admissionregistrationv1beta1.RuleWithOperations{{
+				Operations: []admissionregistrationv1beta1.OperationType{
+					admissionregistrationv1beta1.Create,
+				},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+				},
+			}},
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{
+					Namespace: opts.Namespace,
+					Name:      opts.ServiceName,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			FailurePolicy: &failurePolicy,
+			// Only Pods in spaces are sent to the webhook.
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					v1alpha1.ManagedByLabel: "kf",
+				},
+			},
+		}},
+	}
+}
+
+// register creates or updates the webhook's configuration.
+func register(kubeClient kubernetes.Interface, opts Options, caBundle []byte) error {
+	desired := MakeWebhookConfiguration(opts, caBundle)
+	client := kubeClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations()
+
+	actual, err := client.Get(desired.Name, metav1.GetOptions{})
+	switch {
+	case apierrs.IsNotFound(err):
+		_, err = client.Create(desired)
+		return err
+	case err != nil:
+		return err
+	}
+
+	existing := actual.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.Webhooks = desired.Webhooks
+	_, err = client.Update(existing)
+	return err
+}
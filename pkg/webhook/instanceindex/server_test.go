@@ -0,0 +1,68 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instanceindex
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestMakeCertificate(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{ServiceName: "pod-webhook", Namespace: "kf", Port: 8444}
+	now := time.Now()
+
+	certPEM, keyPEM, err := MakeCertificate(opts, now)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "has key", true, len(keyPEM) > 0)
+
+	block, _ := pem.Decode(certPEM)
+	testutil.AssertEqual(t, "has certificate", true, block != nil)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	testutil.AssertNil(t, "parse err", err)
+
+	// The certificate is used as its own CA bundle.
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:     "pod-webhook.kf.svc",
+		Roots:       roots,
+		CurrentTime: now.Add(time.Hour),
+	})
+	testutil.AssertNil(t, "verify err", err)
+}
+
+func TestMakeWebhookConfiguration(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{ServiceName: "pod-webhook", Namespace: "kf", Port: 8444}
+	config := MakeWebhookConfiguration(opts, []byte("some-ca"))
+
+	testutil.AssertEqual(t, "name", WebhookName, config.Name)
+	testutil.AssertEqual(t, "webhooks", 1, len(config.Webhooks))
+
+	webhook := config.Webhooks[0]
+	testutil.AssertEqual(t, "service", "pod-webhook", webhook.ClientConfig.Service.Name)
+	testutil.AssertEqual(t, "namespace", "kf", webhook.ClientConfig.Service.Namespace)
+	testutil.AssertEqual(t, "ca bundle", []byte("some-ca"), webhook.ClientConfig.CABundle)
+	testutil.AssertEqual(t, "failure policy", "Ignore", string(*webhook.FailurePolicy))
+	testutil.AssertEqual(t, "resources", []string{"pods"}, webhook.Rules[0].Resources)
+}